@@ -0,0 +1,264 @@
+package arbitrage
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"log"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+)
+
+const DEFAULT_PARALLEL = 8
+
+// Opportunity is buying Quantity of the pair's target coin on BuyEx, withdrawing
+// it to SellEx and selling what arrives there. Amounts are in the base coin.
+type Opportunity struct {
+	Pair         *pair.Pair
+	BuyEx        exchange.Exchange
+	SellEx       exchange.Exchange
+	BuyRate      float64 // average buy price, fee excluded
+	SellRate     float64 // average sell price, fee excluded
+	Quantity     float64 // target coin bought on BuyEx
+	SellQuantity float64 // target coin sold on SellEx, Quantity less the withdraw fee
+	Cost         float64 // base spent on BuyEx, fee included
+	Revenue      float64 // base received on SellEx, fee deducted
+	Profit       float64 // Revenue - Cost
+	ProfitRate   float64 // Profit / Cost
+}
+
+// Scanner looks for opportunities in the pairs listed on at least two of the
+// exchanges in the ExchangeManager.
+type Scanner struct {
+	MinProfitRate float64 // opportunities below the rate are dropped
+	Parallel      int     // order books fetched at the same time
+
+	exMan *exchange.ExchangeManager
+}
+
+func CreateScanner(minProfitRate float64) *Scanner {
+	return &Scanner{
+		MinProfitRate: minProfitRate,
+		Parallel:      DEFAULT_PARALLEL,
+		exMan:         exchange.CreateExchangeManager(),
+	}
+}
+
+// Scan fetches the order books of every common pair and returns the
+// opportunities ordered by ProfitRate, highest first.
+func (s *Scanner) Scan() []*Opportunity {
+	return s.ScanExchanges(s.exMan.GetExchanges())
+}
+
+// ScanExchanges is Scan limited to the given exchanges.
+func (s *Scanner) ScanExchanges(exchanges []exchange.Exchange) []*Opportunity {
+	listed := make(map[int][]exchange.Exchange)
+	pairs := make(map[int]*pair.Pair)
+	for i, e1 := range exchanges {
+		for _, e2 := range exchanges[i+1:] {
+			for _, p := range s.exMan.SubsetPairs(e1, e2) {
+				pairs[p.ID] = p
+			}
+		}
+	}
+	for _, p := range pairs {
+		for _, ex := range exchanges {
+			if ex.HasPair(p) {
+				listed[p.ID] = append(listed[p.ID], ex)
+			}
+		}
+	}
+
+	books := s.fetchOrderBooks(pairs, listed)
+
+	opportunities := []*Opportunity{}
+	for id, p := range pairs {
+		for _, buyEx := range listed[id] {
+			for _, sellEx := range listed[id] {
+				if buyEx == sellEx {
+					continue
+				}
+				asks, bids := books[bookKey(buyEx, p)], books[bookKey(sellEx, p)]
+				if asks == nil || bids == nil {
+					continue
+				}
+				if o := Evaluate(p, buyEx, sellEx, asks, bids); o != nil && o.ProfitRate >= s.MinProfitRate {
+					opportunities = append(opportunities, o)
+				}
+			}
+		}
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].ProfitRate > opportunities[j].ProfitRate
+	})
+	return opportunities
+}
+
+func bookKey(ex exchange.Exchange, p *pair.Pair) string {
+	return string(ex.GetName()) + "/" + p.Name
+}
+
+func (s *Scanner) fetchOrderBooks(pairs map[int]*pair.Pair, listed map[int][]exchange.Exchange) map[string]*exchange.Maker {
+	parallel := s.Parallel
+	if parallel <= 0 {
+		parallel = DEFAULT_PARALLEL
+	}
+	semaphore := make(chan struct{}, parallel)
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	books := make(map[string]*exchange.Maker)
+	for id, p := range pairs {
+		for _, ex := range listed[id] {
+			wg.Add(1)
+			go func(ex exchange.Exchange, p *pair.Pair) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				maker, err := ex.OrderBook(p)
+				if err != nil || maker == nil {
+					log.Printf("%s %s OrderBook Err: %v", ex.GetName(), p.Name, err)
+					return
+				}
+				mutex.Lock()
+				books[bookKey(ex, p)] = maker
+				mutex.Unlock()
+			}(ex, p)
+		}
+	}
+	wg.Wait()
+	return books
+}
+
+// Evaluate finds the most profitable quantity to buy from the asks of buyBook on
+// buyEx and sell into the bids of sellBook on sellEx. It returns nil if the target
+// coin cannot be moved between the exchanges or nothing is profitable.
+func Evaluate(p *pair.Pair, buyEx, sellEx exchange.Exchange, buyBook, sellBook *exchange.Maker) *Opportunity {
+	if !buyEx.CanWithdraw(p.Target) || !sellEx.CanDeposit(p.Target) {
+		return nil
+	}
+
+	buyFee, sellFee := buyEx.GetFee(p), sellEx.GetFee(p)
+	// the quantities are summed and snapped to the lot size exactly, a float
+	// artefact below a step would lose the whole step
+	txFee := decimal.NewFromFloat(buyEx.GetTxFee(p.Target))
+	lotSize := decimal.NewFromFloat(math.Max(buyEx.GetLotSize(p), sellEx.GetLotSize(p)))
+
+	asks := orderbook.SortedAsks(buyBook)
+	bids := orderbook.SortedBids(sellBook)
+
+	// the quantity can only be profitable up to where the fee-adjusted prices cross
+	maxQuantity := decimal.Zero
+	i, j := 0, 0
+	askLeft, bidLeft := decimal.Zero, decimal.Zero
+	if len(asks) > 0 {
+		askLeft = decimal.NewFromFloat(asks[0].Quantity)
+	}
+	if len(bids) > 0 {
+		bidLeft = decimal.NewFromFloat(bids[0].Quantity)
+	}
+	for i < len(asks) && j < len(bids) && asks[i].Rate*(1+buyFee) < bids[j].Rate*(1-sellFee) {
+		q := minDecimal(askLeft, bidLeft)
+		maxQuantity = maxQuantity.Add(q)
+		askLeft = askLeft.Sub(q)
+		bidLeft = bidLeft.Sub(q)
+		if askLeft.Sign() <= 0 {
+			if i++; i < len(asks) {
+				askLeft = decimal.NewFromFloat(asks[i].Quantity)
+			}
+		}
+		if bidLeft.Sign() <= 0 {
+			if j++; j < len(bids) {
+				bidLeft = decimal.NewFromFloat(bids[j].Quantity)
+			}
+		}
+	}
+	// the withdraw fee is paid in the target coin, buy it on top of the sold quantity
+	maxQuantity = minDecimal(maxQuantity.Add(txFee), depth(asks))
+
+	var best *Opportunity
+	for _, candidate := range candidateQuantities(asks, bids, txFee, maxQuantity) {
+		quantity := candidate.Floor(lotSize)
+		sellQuantity := quantity.Sub(txFee).Floor(lotSize)
+		if quantity.Sign() <= 0 || sellQuantity.Sign() <= 0 {
+			continue
+		}
+		buy, err := orderbook.Buy(buyBook, quantity.Float64())
+		if err != nil {
+			continue
+		}
+		sell, err := orderbook.Sell(sellBook, sellQuantity.Float64())
+		if err != nil {
+			continue
+		}
+
+		o := &Opportunity{
+			Pair:         p,
+			BuyEx:        buyEx,
+			SellEx:       sellEx,
+			BuyRate:      buy.VWAP,
+			SellRate:     sell.VWAP,
+			Quantity:     quantity.Float64(),
+			SellQuantity: sellQuantity.Float64(),
+			Cost:         buy.Amount * (1 + buyFee),
+			Revenue:      sell.Amount * (1 - sellFee),
+		}
+		o.Profit = o.Revenue - o.Cost
+		o.ProfitRate = o.Profit / o.Cost
+		if o.Profit > 0 && (best == nil || o.Profit > best.Profit) {
+			best = o
+		}
+	}
+	return best
+}
+
+// candidateQuantities are the quantities bought where a level of the asks is
+// used up, or a level of the bids for the quantity sold, the bought one less the
+// withdraw fee, up to max, and max. The profit is piecewise linear in the bought
+// quantity between them, its maximum is at one of them.
+func candidateQuantities(asks, bids []exchange.Order, txFee, max decimal.Decimal) []decimal.Decimal {
+	quantities := []decimal.Decimal{}
+	cumulative := decimal.Zero
+	for _, ask := range asks {
+		cumulative = cumulative.Add(decimal.NewFromFloat(ask.Quantity))
+		if cumulative.Cmp(max) >= 0 {
+			break
+		}
+		quantities = append(quantities, cumulative)
+	}
+	cumulative = txFee
+	for _, bid := range bids {
+		cumulative = cumulative.Add(decimal.NewFromFloat(bid.Quantity))
+		if cumulative.Cmp(max) >= 0 {
+			break
+		}
+		quantities = append(quantities, cumulative)
+	}
+	quantities = append(quantities, max)
+	sort.Slice(quantities, func(i, j int) bool { return quantities[i].Cmp(quantities[j]) < 0 })
+	return quantities
+}
+
+func depth(orders []exchange.Order) decimal.Decimal {
+	total := decimal.Zero
+	for _, order := range orders {
+		total = total.Add(decimal.NewFromFloat(order.Quantity))
+	}
+	return total
+}
+
+func minDecimal(a, b decimal.Decimal) decimal.Decimal {
+	if b.Cmp(a) < 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,221 @@
+package backtest
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/paper"
+	"github.com/bitontop/gored/recorder"
+)
+
+const DEFAULT_BLOCK_TIME = time.Minute
+
+// Strategy is called by the Engine for every recorded event, after the Venue
+// has applied it. Orders and withdrawals are made through the Venues.
+type Strategy interface {
+	OnStart(engine *Engine)
+	OnBook(venue *Venue, p *pair.Pair, maker *exchange.Maker)
+	OnTrade(venue *Venue, p *pair.Pair, trade *recorder.Trade)
+}
+
+// Transfer is a withdrawal between two Venues, Quantity arrives at Arrive.
+type Transfer struct {
+	From     exchange.ExchangeName
+	To       exchange.ExchangeName
+	Coin     *coin.Coin
+	Quantity float64
+	Fee      float64
+	Sent     time.Time
+	Arrive   time.Time
+}
+
+// Engine replays recorded books and trades of several Venues in timestamp order
+// and values the balances in the Quote coin at every event, before the Strategy
+// acts on it, and at the end.
+type Engine struct {
+	Quote      *coin.Coin
+	BlockTimes map[string]time.Duration // by coin code, DEFAULT_BLOCK_TIME if missing
+
+	venues    map[exchange.ExchangeName]*Venue
+	events    []*event
+	time      time.Time
+	transfers []*Transfer
+	prices    map[int]float64 // last mid of Quote|coin, by coin ID
+	report    *Report
+}
+
+type event struct {
+	timestamp float64
+	snapshot  *recorder.Snapshot
+	trade     *recorder.Trade
+}
+
+func CreateEngine(quote *coin.Coin) *Engine {
+	return &Engine{
+		Quote:      quote,
+		BlockTimes: make(map[string]time.Duration),
+		venues:     make(map[exchange.ExchangeName]*Venue),
+		prices:     make(map[int]float64),
+	}
+}
+
+// AddVenue simulates the exchange, its recorded events are matched by name.
+func (e *Engine) AddVenue(ex exchange.Exchange) *Venue {
+	venue := &Venue{
+		Exchange: ex,
+		engine:   e,
+		account:  paper.NewAccount(ex.GetName()),
+		books:    make(map[string]*exchange.Maker),
+		queues:   make(map[string]float64),
+	}
+	e.venues[ex.GetName()] = venue
+	return venue
+}
+
+func (e *Engine) Venue(name exchange.ExchangeName) *Venue {
+	return e.venues[name]
+}
+
+func (e *Engine) AddSnapshots(snapshots []*recorder.Snapshot) {
+	for _, snapshot := range snapshots {
+		e.events = append(e.events, &event{timestamp: snapshot.Timestamp, snapshot: snapshot})
+	}
+}
+
+func (e *Engine) AddTrades(trades []*recorder.Trade) {
+	for _, trade := range trades {
+		e.events = append(e.events, &event{timestamp: trade.Timestamp, trade: trade})
+	}
+}
+
+// Time is the timestamp of the event being replayed.
+func (e *Engine) Time() time.Time {
+	return e.time
+}
+
+func (e *Engine) blockTime(c *coin.Coin) time.Duration {
+	if blockTime, ok := e.BlockTimes[c.Code]; ok {
+		return blockTime
+	}
+	return DEFAULT_BLOCK_TIME
+}
+
+func (e *Engine) addFill(fill *Fill) {
+	e.report.Fills = append(e.report.Fills, fill)
+	e.report.Fees[fill.Pair.Base.Code] += fill.Fee
+}
+
+func (e *Engine) addTransfer(transfer *Transfer) {
+	e.transfers = append(e.transfers, transfer)
+	e.report.Transfers = append(e.report.Transfers, transfer)
+}
+
+// Run replays the events through the strategy and reports the result.
+// Events of exchanges without a Venue, or of unknown pairs, are skipped.
+func (e *Engine) Run(strategy Strategy) *Report {
+	sort.SliceStable(e.events, func(i, j int) bool {
+		return e.events[i].timestamp < e.events[j].timestamp
+	})
+	e.report = &Report{Fees: make(map[string]float64)}
+	if len(e.events) > 0 {
+		e.time = toTime(e.events[0].timestamp)
+		e.report.Start = e.time
+	}
+	strategy.OnStart(e)
+
+	for _, ev := range e.events {
+		e.time = toTime(ev.timestamp)
+		e.arrive()
+
+		if ev.snapshot != nil {
+			venue, p := e.lookup(ev.snapshot.ExName, ev.snapshot.Pair)
+			if venue == nil {
+				continue
+			}
+			maker := ev.snapshot.Maker(p)
+			venue.onBook(p, maker)
+			if p.Base.ID == e.Quote.ID {
+				if mid, err := orderbook.Mid(maker); err == nil {
+					e.prices[p.Target.ID] = mid
+				}
+			}
+			e.report.addEquity(e.time, e.equity())
+			strategy.OnBook(venue, p, maker)
+		} else {
+			venue, p := e.lookup(ev.trade.ExName, ev.trade.Pair)
+			if venue == nil {
+				continue
+			}
+			venue.onTrade(p, ev.trade)
+			e.report.addEquity(e.time, e.equity())
+			strategy.OnTrade(venue, p, ev.trade)
+		}
+	}
+
+	e.report.End = e.time
+	e.report.addEquity(e.time, e.equity())
+	e.report.InTransit = append([]*Transfer{}, e.transfers...)
+	e.report.finish()
+	return e.report
+}
+
+func (e *Engine) lookup(exName exchange.ExchangeName, pairName string) (*Venue, *pair.Pair) {
+	venue, ok := e.venues[exName]
+	if !ok {
+		return nil, nil
+	}
+	p := pair.GetPairByKey(pairName)
+	if p == nil || !venue.HasPair(p) {
+		log.Printf("%s %s is not a pair of the venue, skip the event", exName, pairName)
+		return nil, nil
+	}
+	return venue, p
+}
+
+// arrive credits the transfers that arrived by the current time.
+func (e *Engine) arrive() {
+	pending := e.transfers[:0]
+	for _, transfer := range e.transfers {
+		if transfer.Arrive.After(e.time) {
+			pending = append(pending, transfer)
+			continue
+		}
+		e.venues[transfer.To].account.Credit(transfer.Coin, transfer.Quantity)
+	}
+	e.transfers = pending
+}
+
+// equity values the balances, locked and in transit, at the last mids in Quote.
+// Coins without a price yet are left out.
+func (e *Engine) equity() float64 {
+	total := 0.0
+	value := func(coinID int, quantity float64) {
+		if coinID == e.Quote.ID {
+			total += quantity
+		} else {
+			total += quantity * e.prices[coinID]
+		}
+	}
+	for _, venue := range e.venues {
+		for id, quantity := range venue.account.Holdings() {
+			value(id, quantity)
+		}
+	}
+	for _, transfer := range e.transfers {
+		value(transfer.Coin.ID, transfer.Quantity)
+	}
+	return total
+}
+
+func toTime(timestamp float64) time.Time {
+	return time.Unix(0, int64(timestamp*1e6))
+}
@@ -0,0 +1,74 @@
+package backtest
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Fill is a part of an order filled in a Venue. Fee is in the pair's base coin.
+type Fill struct {
+	Time     time.Time
+	ExName   exchange.ExchangeName
+	Pair     *pair.Pair
+	OrderID  string
+	Side     string
+	Rate     float64
+	Quantity float64
+	Fee      float64
+	Maker    bool
+}
+
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Report is the result of an Engine run. The equities are in the Engine's
+// Quote coin and MaxDrawdown is the largest fall from a peak, relative to it.
+type Report struct {
+	Start       time.Time
+	End         time.Time
+	StartEquity float64
+	EndEquity   float64
+	PnL         float64
+	MaxDrawdown float64
+	Fills       []*Fill
+	Fees        map[string]float64 // by coin code
+	Transfers   []*Transfer
+	InTransit   []*Transfer // not arrived at the End
+	Equity      []EquityPoint
+}
+
+func (r *Report) addEquity(t time.Time, equity float64) {
+	r.Equity = append(r.Equity, EquityPoint{t, equity})
+}
+
+func (r *Report) finish() {
+	if len(r.Equity) == 0 {
+		return
+	}
+	r.StartEquity = r.Equity[0].Equity
+	r.EndEquity = r.Equity[len(r.Equity)-1].Equity
+	r.PnL = r.EndEquity - r.StartEquity
+
+	peak := 0.0
+	for _, point := range r.Equity {
+		if point.Equity > peak {
+			peak = point.Equity
+		} else if peak > 0 && (peak-point.Equity)/peak > r.MaxDrawdown {
+			r.MaxDrawdown = (peak - point.Equity) / peak
+		}
+	}
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("%v - %v  Equity: %v -> %v  PnL: %v  MaxDrawdown: %.2f%%  Fills: %d  Transfers: %d",
+		r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), r.StartEquity, r.EndEquity, r.PnL, r.MaxDrawdown*100, len(r.Fills), len(r.Transfers))
+}
@@ -0,0 +1,290 @@
+package backtest
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/paper"
+	"github.com/bitontop/gored/recorder"
+)
+
+// Venue is a simulated exchange.Exchange in an Engine. The constraints and the
+// name come from the wrapped exchange, the order books from the recorded
+// snapshots and the balances and orders are kept in a paper.Account.
+//
+// Orders are rounded to the pair's PriceFilter, towards the passive side, and
+// LotSize. The part that crosses the book fills at once at the book's rates
+// with the TakerFee, the rest rests behind the quantity already at its rate.
+// A resting order fills with the MakerFee at its own rate when recorded trades
+// go through it after the queue ahead, or when a later book crosses it. The
+// quantity filled from a book is not offered again until the next book.
+//
+// A Venue is driven by its Engine and is not safe for concurrent use.
+type Venue struct {
+	exchange.Exchange
+
+	engine  *Engine
+	account *paper.Account
+	books   map[string]*exchange.Maker // current book by pair name
+	queues  map[string]float64         // quantity ahead of the resting orders, by order ID
+}
+
+func (v *Venue) SetBalance(c *coin.Coin, balance float64) {
+	v.account.SetBalance(c, balance)
+}
+
+// GetBalance is the available balance, the part locked by open orders excluded.
+func (v *Venue) GetBalance(c *coin.Coin) float64 {
+	return v.account.Balance(c)
+}
+
+// GetLockedBalance is the balance locked by open orders.
+func (v *Venue) GetLockedBalance(c *coin.Coin) float64 {
+	return v.account.Locked(c)
+}
+
+func (v *Venue) UpdateAllBalances() {
+}
+
+// OrderBook is the latest recorded book of the pair at the Engine's time.
+func (v *Venue) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	maker, ok := v.books[p.Name]
+	if !ok {
+		return nil, fmt.Errorf("%s %s has no recorded order book at %v", v.GetName(), p.Name, v.engine.Time())
+	}
+	copied := *maker
+	copied.Bids = append([]exchange.Order{}, maker.Bids...)
+	copied.Asks = append([]exchange.Order{}, maker.Asks...)
+	return &copied, nil
+}
+
+func (v *Venue) makerFee(p *pair.Pair) float64 {
+	if pc := v.GetPairConstraint(p); pc != nil {
+		return pc.MakerFee
+	}
+	return v.GetFee(p)
+}
+
+func (v *Venue) takerFee(p *pair.Pair) float64 {
+	if pc := v.GetPairConstraint(p); pc != nil {
+		return pc.TakerFee
+	}
+	return v.GetFee(p)
+}
+
+func (v *Venue) LimitBuy(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return v.placeOrder(p, "Buy", quantity, rate)
+}
+
+func (v *Venue) LimitSell(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return v.placeOrder(p, "Sell", quantity, rate)
+}
+
+func (v *Venue) placeOrder(p *pair.Pair, side string, quantity, rate float64) (*exchange.Order, error) {
+	if !v.HasPair(p) {
+		return nil, fmt.Errorf("%s %s Invalid Pair: %v", v.GetName(), side, p.Name)
+	}
+	rate, quantity, err := exchange.ValidateOrder(v, p, side, rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	reserve := quantity
+	if side == "Buy" {
+		reserve = quantity * rate * (1 + math.Max(v.makerFee(p), v.takerFee(p)))
+	}
+	order, err := v.account.Place(p, side, quantity, rate, reserve)
+	if err != nil {
+		return nil, err
+	}
+
+	if maker, ok := v.books[p.Name]; ok {
+		// the part crossing the book fills as a taker
+		v.account.Cross(order, maker, func(quantity, rate float64) {
+			v.fill(order, quantity, rate, false)
+		})
+		// the rest of the order waits behind the book's quantity at its rate
+		own := orderbook.SortedBids(maker)
+		if side == "Sell" {
+			own = orderbook.SortedAsks(maker)
+		}
+		for _, level := range own {
+			if level.Rate == rate {
+				v.queues[order.OrderID] = level.Quantity
+			}
+		}
+	}
+
+	copied := order.Order
+	return &copied, nil
+}
+
+// fill settles quantity of the order at the rate and records the Fill.
+func (v *Venue) fill(order *paper.Order, quantity, rate float64, isMaker bool) {
+	p := order.Pair
+	fee := v.takerFee(p)
+	if isMaker {
+		fee = v.makerFee(p)
+	}
+	feeAmount := v.account.Fill(order, quantity, rate, fee)
+
+	v.engine.addFill(&Fill{
+		Time:     v.engine.Time(),
+		ExName:   v.GetName(),
+		Pair:     p,
+		OrderID:  order.OrderID,
+		Side:     order.Side,
+		Rate:     rate,
+		Quantity: quantity,
+		Fee:      feeAmount,
+		Maker:    isMaker,
+	})
+}
+
+// onBook sets the pair's current book and fills the resting orders it crosses,
+// in placement order, from the quantity of the book no earlier order took.
+func (v *Venue) onBook(p *pair.Pair, maker *exchange.Maker) {
+	v.books[p.Name] = maker
+	v.account.ResetTaken(p)
+
+	bids, asks := orderbook.SortedBids(maker), orderbook.SortedAsks(maker)
+	for _, order := range v.account.OpenOrders() {
+		if order.Pair.Name != p.Name {
+			continue
+		}
+
+		crossed := 0.0
+		v.account.Cross(order, maker, func(quantity, rate float64) {
+			crossed += quantity
+		})
+		if crossed > 0 {
+			v.fill(order, crossed, order.Rate, true)
+		}
+
+		// the queue only moves forward, by fills and cancels ahead of the order
+		own := bids
+		if order.Side == "Sell" {
+			own = asks
+		}
+		queue := 0.0
+		for _, level := range own {
+			if level.Rate == order.Rate {
+				queue = level.Quantity
+			}
+		}
+		v.queues[order.OrderID] = math.Min(v.queues[order.OrderID], queue)
+	}
+}
+
+// onTrade fills the resting orders the trade goes through, after their queue.
+func (v *Venue) onTrade(p *pair.Pair, trade *recorder.Trade) {
+	left := trade.Quantity
+	for _, order := range v.account.OpenOrders() {
+		if left <= 0 {
+			break
+		}
+		if order.Pair.Name != p.Name {
+			continue
+		}
+		// a taker buy goes through the sell orders and a taker sell through the buy orders
+		if order.Side == trade.Side {
+			continue
+		}
+		if (order.Side == "Buy" && trade.Rate > order.Rate) || (order.Side == "Sell" && trade.Rate < order.Rate) {
+			continue
+		}
+
+		quantity := left
+		if trade.Rate == order.Rate {
+			ahead := math.Min(v.queues[order.OrderID], quantity)
+			v.queues[order.OrderID] -= ahead
+			quantity -= ahead
+		}
+		quantity = math.Min(quantity, order.Quantity-order.DealQuantity)
+		if quantity <= 0 {
+			continue
+		}
+		left -= quantity
+		v.fill(order, quantity, order.Rate, true)
+	}
+}
+
+func (v *Venue) OrderStatus(order *exchange.Order) error {
+	o := v.account.Find(order.OrderID)
+	if o == nil {
+		return fmt.Errorf("%s OrderStatus Order Not Found: %v", v.GetName(), order.OrderID)
+	}
+	order.Status = o.Status
+	order.DealRate = o.DealRate
+	order.DealQuantity = o.DealQuantity
+	return nil
+}
+
+// ListOrders returns the open orders, ordered by placement.
+func (v *Venue) ListOrders() ([]*exchange.Order, error) {
+	orders := []*exchange.Order{}
+	for _, o := range v.account.OpenOrders() {
+		copied := o.Order
+		orders = append(orders, &copied)
+	}
+	return orders, nil
+}
+
+func (v *Venue) CancelOrder(order *exchange.Order) error {
+	o, err := v.account.Cancel(order.OrderID)
+	if err != nil {
+		return err
+	}
+	order.Status = o.Status
+	order.Canceled = true
+	order.DealRate = o.DealRate
+	order.DealQuantity = o.DealQuantity
+	return nil
+}
+
+func (v *Venue) CancelAllOrder() error {
+	v.account.CancelAll()
+	return nil
+}
+
+// Withdraw sends the coin to the Venue named by addr in the same Engine. The
+// quantity less the TxFee arrives after the destination's Confirmation count
+// of the coin's block time.
+func (v *Venue) Withdraw(c *coin.Coin, quantity float64, addr, tag string) bool {
+	to, ok := v.engine.venues[exchange.ExchangeName(addr)]
+	if !ok || to == v || quantity <= 0 {
+		return false
+	}
+	if !v.CanWithdraw(c) || !to.CanDeposit(c) || v.account.Balance(c) < quantity {
+		return false
+	}
+	arrive := quantity - v.GetTxFee(c)
+	if arrive <= 0 {
+		return false
+	}
+
+	v.account.Credit(c, -quantity)
+	v.engine.addTransfer(&Transfer{
+		From:     v.GetName(),
+		To:       to.GetName(),
+		Coin:     c,
+		Quantity: arrive,
+		Fee:      quantity - arrive,
+		Sent:     v.engine.Time(),
+		Arrive:   v.engine.Time().Add(time.Duration(to.GetConfirmation(c)) * v.engine.blockTime(c)),
+	})
+	return true
+}
+
+func (v *Venue) DoAccoutOperation(operation *exchange.AccountOperation) error {
+	return fmt.Errorf("%s Operation type invalid for backtest: %v", v.GetName(), operation.Type)
+}
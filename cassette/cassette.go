@@ -0,0 +1,276 @@
+package cassette
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Mode string
+
+const (
+	RECORD Mode = "RECORD" // send the requests and save the responses
+	REPLAY Mode = "REPLAY" // answer from the cassette, nothing is sent
+)
+
+const SCRUBBED = "SCRUBBED"
+
+// the parameters that change on every signed request, their values are
+// scrubbed so a replayed request matches the recorded one
+var DEFAULT_VOLATILE_PARAMS = []string{
+	"signature", "sign", "sig", "signed",
+	"timestamp", "nonce", "tonce", "time", "ts", "recvwindow", "requesttime",
+	"signaturenonce", "signaturemethod", "signatureversion", "accesskeyid",
+	"api_key", "apikey", "key", "access_key", "accesskey",
+	"clientoid", "client-order-id",
+}
+
+// Interaction is one recorded request and its response. The request is stored
+// scrubbed, it is the key of the replay.
+type Interaction struct {
+	Method   string
+	URL      string
+	Body     string `json:",omitempty"`
+	Status   int
+	Header   map[string][]string `json:",omitempty"`
+	Response string
+}
+
+// Transport is an http.RoundTripper that records the requests to a cassette
+// file or replays them from it. Set it with exchange.SetHttpTransport.
+//
+// Secrets, e.g. the API key and secret, are replaced by SCRUBBED wherever they
+// appear in the cassette, as are the values of the VolatileParams in the query
+// and body. A replayed request matches the first unused interaction with the
+// same method, scrubbed URL and body, or the last used one when all are used.
+type Transport struct {
+	Path           string
+	Mode           Mode
+	Secrets        []string
+	VolatileParams []string
+	Next           http.RoundTripper // the transport of RECORD, http.DefaultTransport if nil
+
+	mutex        sync.Mutex
+	interactions []*Interaction
+	used         []bool
+	missed       []string
+}
+
+// Open loads the cassette for REPLAY, or starts an empty one for RECORD.
+func Open(path string, mode Mode, secrets ...string) (*Transport, error) {
+	t := &Transport{
+		Path:           path,
+		Mode:           mode,
+		Secrets:        secrets,
+		VolatileParams: DEFAULT_VOLATILE_PARAMS,
+	}
+	if mode == REPLAY {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &t.interactions); err != nil {
+			return nil, fmt.Errorf("Cassette %s Unmarshal Err: %v", path, err)
+		}
+		t.used = make([]bool, len(t.interactions))
+	} else if mode != RECORD {
+		return nil, fmt.Errorf("Invalid cassette mode: %v", mode)
+	}
+	return t, nil
+}
+
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body := []byte{}
+	if request.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	method, strUrl, strBody := request.Method, t.scrubURL(request.URL), t.scrubBody(string(body))
+
+	if t.Mode == REPLAY {
+		interaction, err := t.find(method, strUrl, strBody)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+			StatusCode:    interaction.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header(interaction.Header),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response)),
+			ContentLength: int64(len(interaction.Response)),
+			Request:       request,
+		}, nil
+	}
+
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	header := make(map[string][]string)
+	for key, values := range response.Header {
+		for _, value := range values {
+			header[key] = append(header[key], t.scrub(value))
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.interactions = append(t.interactions, &Interaction{
+		Method:   method,
+		URL:      strUrl,
+		Body:     strBody,
+		Status:   response.StatusCode,
+		Header:   header,
+		Response: t.scrub(string(data)),
+	})
+	return response, nil
+}
+
+func (t *Transport) find(method, strUrl, strBody string) (*Interaction, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	last := -1
+	for i, interaction := range t.interactions {
+		if interaction.Method != method || interaction.URL != strUrl || interaction.Body != strBody {
+			continue
+		}
+		if !t.used[i] {
+			t.used[i] = true
+			return interaction, nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return t.interactions[last], nil
+	}
+	t.missed = append(t.missed, strings.TrimSpace(fmt.Sprintf("%s %s %s", method, strUrl, strBody)))
+	return nil, fmt.Errorf("Cassette %s has no interaction for %s %s %s", t.Path, method, strUrl, strBody)
+}
+
+// Missed lists the requests REPLAY found no interaction for. Many adapters only
+// log a failed request, a test replaying them should check it is empty.
+func (t *Transport) Missed() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]string{}, t.missed...)
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (t *Transport) Save() error {
+	if t.Mode != RECORD {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(t.Path, data, os.FileMode(0644))
+}
+
+// scrub replaces the secrets in the text.
+func (t *Transport) scrub(text string) string {
+	for _, secret := range t.Secrets {
+		if secret != "" {
+			text = strings.Replace(text, secret, SCRUBBED, -1)
+			text = strings.Replace(text, url.QueryEscape(secret), SCRUBBED, -1)
+		}
+	}
+	return text
+}
+
+func (t *Transport) volatile(key string) bool {
+	key = strings.ToLower(key)
+	for _, param := range t.VolatileParams {
+		if strings.ToLower(param) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubURL scrubs the secrets and the volatile query parameters, and sorts the
+// query so the order the adapter builds it in does not matter.
+func (t *Transport) scrubURL(u *url.URL) string {
+	copied := *u
+	copied.RawQuery = t.scrubQuery(u.RawQuery)
+	return t.scrub(copied.String())
+}
+
+func (t *Transport) scrubQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil || rawQuery == "" {
+		return rawQuery
+	}
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := []string{}
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if t.volatile(key) {
+				value = SCRUBBED
+			}
+			params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// scrubBody scrubs a JSON object or form body, other bodies only of the secrets.
+func (t *Transport) scrubBody(body string) string {
+	if body == "" {
+		return body
+	}
+
+	object := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(body), &object); err == nil {
+		for key := range object {
+			if t.volatile(key) {
+				object[key] = SCRUBBED
+			}
+		}
+		// json.Marshal sorts the keys
+		if data, err := json.Marshal(object); err == nil {
+			return t.scrub(string(data))
+		}
+	}
+
+	if strings.Contains(body, "=") && !strings.ContainsAny(body, " \n{") {
+		return t.scrub(t.scrubQuery(body))
+	}
+	return t.scrub(body)
+}
@@ -0,0 +1,266 @@
+package decimal
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, value × 10^-scale. It is immutable, the
+// zero value is 0.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+var Zero = Decimal{}
+
+var ten = big.NewInt(10)
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// New returns value × 10^-scale, e.g. New(125, 2) is 1.25 and New(125, -2) is 12500.
+func New(value int64, scale int32) Decimal {
+	return Decimal{value: big.NewInt(value), scale: scale}.normalize()
+}
+
+// NewFromString parses a decimal string, with an optional sign and exponent,
+// e.g. "-12.50" or "1e-8".
+func NewFromString(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	exp := int64(0)
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(str[i+1:], 10, 32); err != nil {
+			return Zero, fmt.Errorf("Invalid decimal %q: %v", s, err)
+		}
+		str = str[:i]
+	}
+
+	digits, scale := str, int64(0)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		digits = str[:i] + str[i+1:]
+		scale = int64(len(str) - i - 1)
+	}
+	if digits == "" || digits == "-" || digits == "+" || strings.ContainsAny(digits[1:], "+-") {
+		return Zero, fmt.Errorf("Invalid decimal %q", s)
+	}
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("Invalid decimal %q", s)
+	}
+
+	scale -= exp
+	if scale < 0 {
+		value.Mul(value, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{value: value, scale: int32(scale)}.normalize(), nil
+}
+
+// NewFromFloat converts the float to the shortest decimal that parses back to
+// it, so 0.1 is 0.1 and not 0.1000000000000000055511151231257827.
+// NaN and infinities are 0.
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Zero
+	}
+	d, _ := NewFromString(strconv.FormatFloat(f, 'e', -1, 64))
+	return d
+}
+
+func (d Decimal) int() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// normalize removes the trailing zeros of the value, and a negative scale.
+func (d Decimal) normalize() Decimal {
+	value := new(big.Int).Set(d.int())
+	scale := d.scale
+	if value.Sign() == 0 {
+		return Zero
+	}
+	if scale < 0 {
+		return Decimal{value: value.Mul(value, pow10(-scale)), scale: 0}
+	}
+	remainder := new(big.Int)
+	for scale > 0 {
+		quotient, r := new(big.Int).QuoRem(value, ten, remainder)
+		if r.Sign() != 0 {
+			break
+		}
+		value, scale = quotient, scale-1
+	}
+	return Decimal{value: value, scale: scale}
+}
+
+// align returns the values of a and b at their common scale.
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	x := new(big.Int).Mul(a.int(), pow10(scale-a.scale))
+	y := new(big.Int).Mul(b.int(), pow10(scale-b.scale))
+	return x, y, scale
+}
+
+func (d Decimal) Add(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return Decimal{value: x.Add(x, y), scale: scale}.normalize()
+}
+
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return Decimal{value: x.Sub(x, y), scale: scale}.normalize()
+}
+
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.int(), d2.int()), scale: d.scale + d2.scale}.normalize()
+}
+
+// Div divides to the given decimal places, truncating toward zero.
+// It panics on division by zero.
+func (d Decimal) Div(d2 Decimal, places int32) Decimal {
+	if d2.Sign() == 0 {
+		panic("decimal: division by zero")
+	}
+	x, y := new(big.Int).Set(d.int()), new(big.Int).Set(d2.int())
+	if exp := places + d2.scale - d.scale; exp >= 0 {
+		x.Mul(x, pow10(exp))
+	} else {
+		y.Mul(y, pow10(-exp))
+	}
+	return Decimal{value: x.Quo(x, y), scale: places}.normalize()
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Sign returns -1, 0 or 1.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or 1 as d is less than, equal to or greater than d2.
+func (d Decimal) Cmp(d2 Decimal) int {
+	x, y, _ := align(d, d2)
+	return x.Cmp(y)
+}
+
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// Floor returns the largest multiple of step not above d, d if step is not positive.
+func (d Decimal) Floor(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	x, y, scale := align(d, step)
+	q := new(big.Int).Div(x, y) // Euclidean, floor for a positive y
+	return Decimal{value: q.Mul(q, y), scale: scale}.normalize()
+}
+
+// Ceil returns the smallest multiple of step not below d, d if step is not positive.
+func (d Decimal) Ceil(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	return d.Neg().Floor(step).Neg()
+}
+
+// Round returns the nearest multiple of step, the halves round up, d if step
+// is not positive.
+func (d Decimal) Round(step Decimal) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	half := step.Div(New(2, 0), step.scale+1)
+	return d.Add(half).Floor(step)
+}
+
+// Places is the number of decimal places of d, without trailing zeros.
+func (d Decimal) Places() int32 {
+	return d.normalize().scale
+}
+
+// String formats d without exponent and trailing zeros.
+func (d Decimal) String() string {
+	return d.StringFixed(d.Places())
+}
+
+// StringFixed formats d with the given decimal places, the digits beyond are
+// truncated toward zero. Round or Floor d to its step first.
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	value := new(big.Int).Set(d.int())
+	if places >= d.scale {
+		value.Mul(value, pow10(places-d.scale))
+	} else {
+		value.Quo(value, pow10(d.scale-places))
+	}
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Neg(value)
+	}
+	digits := value.String()
+	if places == 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(places) {
+		digits = strings.Repeat("0", int(places)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(places)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 is the nearest float to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON writes d as a string, as the exchanges do.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON reads a string or a number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "null" || str == "" {
+		*d = Zero
+		return nil
+	}
+	parsed, err := NewFromString(str)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
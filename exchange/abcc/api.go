@@ -297,6 +297,11 @@ func (e *Abcc) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
 	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
@@ -334,6 +339,11 @@ func (e *Abcc) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
 	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
@@ -446,12 +456,12 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Abcc) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
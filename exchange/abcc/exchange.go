@@ -7,12 +7,8 @@ package abcc
 import (
 	"fmt"
 	"log"
-	"sort"
-	"strconv"
 	"sync"
 
-	cmap "github.com/orcaman/concurrent-map"
-
 	"github.com/bitontop/gored/coin"
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
@@ -29,11 +25,12 @@ type Abcc struct {
 
 	Source    exchange.DataSource // / exchange API / microservicve api 1 / PSQL
 	SourceURI string
+
+	staging *exchange.Constraints // the constraints being fetched by fetchConstraint
 }
 
-var pairConstraintMap cmap.ConcurrentMap
-var coinConstraintMap cmap.ConcurrentMap
-var balanceMap cmap.ConcurrentMap
+var constraints *exchange.Constraints
+var balanceMap *exchange.Balances
 
 var instance *Abcc
 var once sync.Once
@@ -52,9 +49,8 @@ func CreateAbcc(config *exchange.Config) *Abcc {
 			SourceURI:  config.SourceURI,
 		}
 
-		balanceMap = cmap.New()
-		coinConstraintMap = cmap.New()
-		pairConstraintMap = cmap.New()
+		balanceMap = exchange.NewBalances(instance.GetName())
+		constraints = exchange.NewConstraints(instance.GetName())
 
 		if err := instance.InitData(); err != nil {
 			log.Printf("%v", err)
@@ -67,22 +63,24 @@ func CreateAbcc(config *exchange.Config) *Abcc {
 func (e *Abcc) InitData() error {
 	switch e.Source {
 	case exchange.EXCHANGE_API:
-		if err := e.GetCoinsData(); err != nil {
-			return err
-		}
-		if err := e.GetPairsData(); err != nil {
+		if err := e.fetchConstraint(); err != nil {
 			return err
 		}
 		break
 	case exchange.MICROSERVICE_API:
+		exchangeData := utils.GetExchangeDataFromMicroservice(e.SourceURI, e.GetName())
+		if exchangeData == nil {
+			return fmt.Errorf("%s Initial Data Error.", e.GetName())
+		} else {
+			constraints.Load(exchangeData.CoinConstraint, exchangeData.PairConstraint)
+		}
 		break
 	case exchange.JSON_FILE:
 		exchangeData := utils.GetExchangeDataFromJSON(e.SourceURI, e.GetName())
 		if exchangeData == nil {
 			return fmt.Errorf("%s Initial Data Error.", e.GetName())
 		} else {
-			coinConstraintMap = exchangeData.CoinConstraint
-			pairConstraintMap = exchangeData.PairConstraint
+			constraints.Load(exchangeData.CoinConstraint, exchangeData.PairConstraint)
 		}
 		break
 	case exchange.PSQL:
@@ -92,6 +90,22 @@ func (e *Abcc) InitData() error {
 	return nil
 }
 
+// fetchConstraint fetches the coins and pairs from the exchange API into a stage,
+// swapped in once both are fetched. Readers keep the current data meanwhile.
+func (e *Abcc) fetchConstraint() error {
+	update := *e
+	update.staging = constraints.Stage()
+	return constraints.Fetch(update.staging, update.GetCoinsData, update.GetPairsData)
+}
+
+// store returns the staged constraints during fetchConstraint, otherwise the current ones.
+func (e *Abcc) store() *exchange.Constraints {
+	if e != nil && e.staging != nil {
+		return e.staging
+	}
+	return constraints
+}
+
 /**************** Exchange Information ****************/
 func (e *Abcc) GetID() int {
 	return e.ID
@@ -102,11 +116,7 @@ func (e *Abcc) GetName() exchange.ExchangeName {
 }
 
 func (e *Abcc) GetBalance(coin *coin.Coin) float64 {
-	if tmp, ok := balanceMap.Get(coin.Code); ok {
-		return tmp.(float64)
-	} else {
-		return 0.0
-	}
+	return balanceMap.Get(coin.Code)
 }
 
 func (e *Abcc) GetTradingWebURL(pair *pair.Pair) string {
@@ -115,97 +125,47 @@ func (e *Abcc) GetTradingWebURL(pair *pair.Pair) string {
 
 /*************** Coins on the Exchanges ***************/
 func (e *Abcc) GetCoinConstraint(coin *coin.Coin) *exchange.CoinConstraint {
-	if tmp, ok := coinConstraintMap.Get(fmt.Sprintf("%d", coin.ID)); ok {
-		return tmp.(*exchange.CoinConstraint)
-	}
-	return nil
+	return e.store().GetCoin(coin)
 }
 
 func (e *Abcc) SetCoinConstraint(coinConstraint *exchange.CoinConstraint) {
-	coinConstraintMap.Set(fmt.Sprintf("%d", coinConstraint.CoinID), coinConstraint)
+	e.store().SetCoin(coinConstraint)
 }
 
 func (e *Abcc) GetCoins() []*coin.Coin {
-	coinList := []*coin.Coin{}
-	keySort := []int{}
-	for _, key := range coinConstraintMap.Keys() {
-		id, _ := strconv.Atoi(key)
-		keySort = append(keySort, id)
-	}
-	sort.Ints(keySort)
-	for _, key := range keySort {
-		c := coin.GetCoinByID(key)
-		if c != nil {
-			coinList = append(coinList, c)
-		}
-	}
-	return coinList
+	return e.store().Coins()
 }
 
 func (e *Abcc) GetSymbolByCoin(coin *coin.Coin) string {
-	key := fmt.Sprintf("%d", coin.ID)
-	if tmp, ok := coinConstraintMap.Get(key); ok {
-		cc := tmp.(*exchange.CoinConstraint)
-		return cc.ExSymbol
+	if coinConstraint := e.GetCoinConstraint(coin); coinConstraint != nil {
+		return coinConstraint.ExSymbol
 	}
 	return ""
 }
 
 func (e *Abcc) GetCoinBySymbol(symbol string) *coin.Coin {
-	for _, id := range coinConstraintMap.Keys() {
-		if tmp, ok := coinConstraintMap.Get(id); ok {
-			cc := tmp.(*exchange.CoinConstraint)
-			if cc.ExSymbol == symbol {
-				return cc.Coin
-			}
-		}
-	}
-	return nil
+	return e.store().CoinBySymbol(symbol)
 }
 
 func (e *Abcc) DeleteCoin(coin *coin.Coin) {
-	coinConstraintMap.Remove(fmt.Sprintf("%d", coin.ID))
+	e.store().DeleteCoin(coin)
 }
 
 /*************** Pairs on the Exchanges ***************/
 func (e *Abcc) GetPairConstraint(pair *pair.Pair) *exchange.PairConstraint {
-	if tmp, ok := pairConstraintMap.Get(fmt.Sprintf("%d", pair.ID)); ok {
-		return tmp.(*exchange.PairConstraint)
-	}
-	return nil
+	return e.store().GetPair(pair)
 }
 
 func (e *Abcc) SetPairConstraint(pairConstraint *exchange.PairConstraint) {
-	pairConstraintMap.Set(fmt.Sprintf("%d", pairConstraint.PairID), pairConstraint)
+	e.store().SetPair(pairConstraint)
 }
 
 func (e *Abcc) GetPairs() []*pair.Pair {
-	pairList := []*pair.Pair{}
-	keySort := []int{}
-	for _, key := range pairConstraintMap.Keys() {
-		id, _ := strconv.Atoi(key)
-		keySort = append(keySort, id)
-	}
-	sort.Ints(keySort)
-	for _, key := range keySort {
-		p := pair.GetPairByID(key)
-		if p != nil {
-			pairList = append(pairList, p)
-		}
-	}
-	return pairList
+	return e.store().Pairs()
 }
 
 func (e *Abcc) GetPairBySymbol(symbol string) *pair.Pair {
-	for _, id := range pairConstraintMap.Keys() {
-		if tmp, ok := pairConstraintMap.Get(id); ok {
-			pc := tmp.(*exchange.PairConstraint)
-			if pc.ExSymbol == symbol {
-				return pc.Pair
-			}
-		}
-	}
-	return nil
+	return e.store().PairBySymbol(symbol)
 }
 
 func (e *Abcc) GetSymbolByPair(pair *pair.Pair) string {
@@ -217,11 +177,11 @@ func (e *Abcc) GetSymbolByPair(pair *pair.Pair) string {
 }
 
 func (e *Abcc) HasPair(pair *pair.Pair) bool {
-	return pairConstraintMap.Has(fmt.Sprintf("%d", pair.ID))
+	return e.store().HasPair(pair)
 }
 
 func (e *Abcc) DeletePair(pair *pair.Pair) {
-	pairConstraintMap.Remove(fmt.Sprintf("%d", pair.ID))
+	e.store().DeletePair(pair)
 }
 
 /**************** Exchange Constraint ****************/
@@ -241,9 +201,13 @@ func (e *Abcc) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
-func (e *Abcc) UpdateConstraint() {
-	e.GetCoinsData()
-	e.GetPairsData()
+// UpdateConstraint refreshes the constraints from the microservice when it is
+// the data source, from the exchange API otherwise.
+func (e *Abcc) UpdateConstraint() error {
+	if e.Source == exchange.MICROSERVICE_API {
+		return e.InitData()
+	}
+	return e.fetchConstraint()
 }
 
 /**************** Coin Constraint ****************/
@@ -288,6 +252,14 @@ func (e *Abcc) GetFee(pair *pair.Pair) float64 {
 	return pairConstraint.TakerFee
 }
 
+func (e *Abcc) GetMakerFee(pair *pair.Pair) float64 {
+	return exchange.MakerFee(e, pair)
+}
+
+func (e *Abcc) GetTakerFee(pair *pair.Pair) float64 {
+	return exchange.TakerFee(e, pair)
+}
+
 func (e *Abcc) GetLotSize(pair *pair.Pair) float64 {
 	pairConstraint := e.GetPairConstraint(pair)
 	if pairConstraint == nil {
@@ -0,0 +1,65 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"sync"
+
+	cmap "github.com/orcaman/concurrent-map"
+)
+
+// Balances holds the available balances of an exchange account by coin code.
+// It is not loaded until UpdateAllBalances sets a balance, GetBalance is 0
+// meanwhile and ValidateOrder skips the balance check.
+// A nil Balances reads as not loaded.
+type Balances struct {
+	balances cmap.ConcurrentMap
+	mutex    sync.RWMutex
+	loaded   bool
+}
+
+var balancesMap = make(map[ExchangeName]*Balances)
+var balancesMutex sync.RWMutex
+
+// NewBalances returns the balances of the exchange, found by ValidateOrder.
+func NewBalances(name ExchangeName) *Balances {
+	b := &Balances{balances: cmap.New()}
+	balancesMutex.Lock()
+	defer balancesMutex.Unlock()
+	balancesMap[name] = b
+	return b
+}
+
+func getBalances(name ExchangeName) *Balances {
+	balancesMutex.RLock()
+	defer balancesMutex.RUnlock()
+	return balancesMap[name]
+}
+
+func (b *Balances) Get(code string) float64 {
+	if b == nil {
+		return 0.0
+	}
+	if tmp, ok := b.balances.Get(code); ok {
+		return tmp.(float64)
+	}
+	return 0.0
+}
+
+func (b *Balances) Set(code string, balance float64) {
+	b.balances.Set(code, balance)
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.loaded = true
+}
+
+func (b *Balances) Loaded() bool {
+	if b == nil {
+		return false
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.loaded
+}
@@ -59,20 +59,9 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *Bcex) GetCoinsData() error {
-	jsonResponse := &JsonResponse{}
-	coinsData := make(map[string]*CoinsData)
-
-	strRequestUrl := "/api_market/getTokenPrecision"
-	strUrl := API_URL + strRequestUrl
-
-	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
-		return fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
-	} else if jsonResponse.Code != 0 {
-		return fmt.Errorf("%s Get Coins Failed: %v", e.GetName(), jsonResponse.Message)
-	}
-	if err := json.Unmarshal(jsonResponse.Data, &coinsData); err != nil {
-		return fmt.Errorf("%s Get Coins Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	coinsData, err := e.getTokenPrecision()
+	if err != nil {
+		return err
 	}
 
 	for key, _ := range coinsData {
@@ -107,6 +96,26 @@ func (e *Bcex) GetCoinsData() error {
 	return nil
 }
 
+/*The precisions and the order amount limits by token*/
+func (e *Bcex) getTokenPrecision() (map[string]*CoinsData, error) {
+	jsonResponse := &JsonResponse{}
+	coinsData := make(map[string]*CoinsData)
+
+	strRequestUrl := "/api_market/getTokenPrecision"
+	strUrl := API_URL + strRequestUrl
+
+	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	} else if jsonResponse.Code != 0 {
+		return nil, fmt.Errorf("%s Get Coins Failed: %v", e.GetName(), jsonResponse.Message)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &coinsData); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+	return coinsData, nil
+}
+
 /* GetPairsData - Get Pairs Information (If API provide)
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -131,6 +140,11 @@ func (e *Bcex) GetPairsData() error {
 	if err := json.Unmarshal(jsonResponse.Data, &pairsData); err != nil {
 		return fmt.Errorf("%s Get Pairs Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
 	}
+	// the order amount limits are listed by token
+	tokensData, err := e.getTokenPrecision()
+	if err != nil {
+		return err
+	}
 
 	for _, v := range pairsData.Main {
 		for _, data := range v {
@@ -159,6 +173,11 @@ func (e *Bcex) GetPairsData() error {
 			}
 
 			if p != nil {
+				minQty, maxQty := 0.0, 0.0
+				if token, ok := tokensData[data.Token]; ok {
+					minQty, _ = strconv.ParseFloat(token.OrderAmountMin, 64)
+					maxQty, _ = strconv.ParseFloat(token.OrderAmountMax, 64)
+				}
 				pairConstraint := &exchange.PairConstraint{
 					PairID:      p.ID,
 					Pair:        p,
@@ -167,6 +186,8 @@ func (e *Bcex) GetPairsData() error {
 					TakerFee:    DEFAULT_TAKER_FEE,
 					LotSize:     math.Pow10(-1 * lotsize),
 					PriceFilter: math.Pow10(-1 * ticksize),
+					MinQty:      minQty,
+					MaxQty:      maxQty,
 					Listed:      true,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -339,6 +360,11 @@ func (e *Bcex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/api_market/placeOrder"
@@ -379,6 +405,11 @@ func (e *Bcex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/api_market/placeOrder"
@@ -491,12 +522,12 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bcex) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
@@ -545,7 +576,7 @@ func (e *Bcex) ApiKeyPost(strRequestPath string, mapParams map[string]interface{
 	request.Header.Add("Content-Type", "application/json")
 
 	// 发出请求
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -317,6 +317,11 @@ func (e *Bgogo) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -355,6 +360,11 @@ func (e *Bgogo) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -488,7 +498,7 @@ func (e *Bgogo) ApiKeyGet(strRequestPath string, mapParams map[string]string) st
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -523,7 +533,7 @@ func (e *Bgogo) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[st
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -7,12 +7,8 @@ package bgogo
 import (
 	"fmt"
 	"log"
-	"sort"
-	"strconv"
 	"sync"
 
-	cmap "github.com/orcaman/concurrent-map"
-
 	"github.com/bitontop/gored/coin"
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
@@ -29,11 +25,12 @@ type Bgogo struct {
 
 	Source    exchange.DataSource // / exchange API / microservicve api 1 / PSQL
 	SourceURI string
+
+	staging *exchange.Constraints // the constraints being fetched by fetchConstraint
 }
 
-var pairConstraintMap cmap.ConcurrentMap
-var coinConstraintMap cmap.ConcurrentMap
-var balanceMap cmap.ConcurrentMap
+var constraints *exchange.Constraints
+var balanceMap *exchange.Balances
 
 var instance *Bgogo
 var once sync.Once
@@ -52,9 +49,8 @@ func CreateBgogo(config *exchange.Config) *Bgogo {
 			SourceURI:  config.SourceURI,
 		}
 
-		balanceMap = cmap.New()
-		coinConstraintMap = cmap.New()
-		pairConstraintMap = cmap.New()
+		balanceMap = exchange.NewBalances(instance.GetName())
+		constraints = exchange.NewConstraints(instance.GetName())
 
 		if err := instance.InitData(); err != nil {
 			log.Printf("%v", err)
@@ -67,22 +63,24 @@ func CreateBgogo(config *exchange.Config) *Bgogo {
 func (e *Bgogo) InitData() error {
 	switch e.Source {
 	case exchange.EXCHANGE_API:
-		if err := e.GetCoinsData(); err != nil {
-			return err
-		}
-		if err := e.GetPairsData(); err != nil {
+		if err := e.fetchConstraint(); err != nil {
 			return err
 		}
 		break
 	case exchange.MICROSERVICE_API:
+		exchangeData := utils.GetExchangeDataFromMicroservice(e.SourceURI, e.GetName())
+		if exchangeData == nil {
+			return fmt.Errorf("%s Initial Data Error.", e.GetName())
+		} else {
+			constraints.Load(exchangeData.CoinConstraint, exchangeData.PairConstraint)
+		}
 		break
 	case exchange.JSON_FILE:
 		exchangeData := utils.GetExchangeDataFromJSON(e.SourceURI, e.GetName())
 		if exchangeData == nil {
 			return fmt.Errorf("%s Initial Data Error.", e.GetName())
 		} else {
-			coinConstraintMap = exchangeData.CoinConstraint
-			pairConstraintMap = exchangeData.PairConstraint
+			constraints.Load(exchangeData.CoinConstraint, exchangeData.PairConstraint)
 		}
 		break
 	case exchange.PSQL:
@@ -92,6 +90,22 @@ func (e *Bgogo) InitData() error {
 	return nil
 }
 
+// fetchConstraint fetches the coins and pairs from the exchange API into a stage,
+// swapped in once both are fetched. Readers keep the current data meanwhile.
+func (e *Bgogo) fetchConstraint() error {
+	update := *e
+	update.staging = constraints.Stage()
+	return constraints.Fetch(update.staging, update.GetCoinsData, update.GetPairsData)
+}
+
+// store returns the staged constraints during fetchConstraint, otherwise the current ones.
+func (e *Bgogo) store() *exchange.Constraints {
+	if e != nil && e.staging != nil {
+		return e.staging
+	}
+	return constraints
+}
+
 /**************** Exchange Information ****************/
 func (e *Bgogo) GetID() int {
 	return e.ID
@@ -106,108 +120,52 @@ func (e *Bgogo) GetTradingWebURL(pair *pair.Pair) string {
 }
 
 func (e *Bgogo) GetBalance(coin *coin.Coin) float64 {
-	if tmp, ok := balanceMap.Get(coin.Code); ok {
-		return tmp.(float64)
-	} else {
-		return 0.0
-	}
+	return balanceMap.Get(coin.Code)
 }
 
 /*************** Coins on the Exchanges ***************/
 func (e *Bgogo) GetCoinConstraint(coin *coin.Coin) *exchange.CoinConstraint {
-	if tmp, ok := coinConstraintMap.Get(fmt.Sprintf("%d", coin.ID)); ok {
-		return tmp.(*exchange.CoinConstraint)
-	}
-	return nil
+	return e.store().GetCoin(coin)
 }
 
 func (e *Bgogo) SetCoinConstraint(coinConstraint *exchange.CoinConstraint) {
-	coinConstraintMap.Set(fmt.Sprintf("%d", coinConstraint.CoinID), coinConstraint)
+	e.store().SetCoin(coinConstraint)
 }
 
 func (e *Bgogo) GetCoins() []*coin.Coin {
-	coinList := []*coin.Coin{}
-	keySort := []int{}
-	for _, key := range coinConstraintMap.Keys() {
-		id, _ := strconv.Atoi(key)
-		keySort = append(keySort, id)
-	}
-	sort.Ints(keySort)
-	for _, key := range keySort {
-		c := coin.GetCoinByID(key)
-		if c != nil {
-			coinList = append(coinList, c)
-		}
-	}
-	return coinList
+	return e.store().Coins()
 }
 
 func (e *Bgogo) GetCoinBySymbol(symbol string) *coin.Coin {
-	for _, id := range coinConstraintMap.Keys() {
-		if tmp, ok := coinConstraintMap.Get(id); ok {
-			cc := tmp.(*exchange.CoinConstraint)
-			if cc.ExSymbol == symbol {
-				return cc.Coin
-			}
-		} else {
-			log.Printf("Get ID %s CoinConstraint Err", id)
-		}
-	}
-	return nil
+	return e.store().CoinBySymbol(symbol)
 }
 
 func (e *Bgogo) GetSymbolByCoin(coin *coin.Coin) string {
-	key := fmt.Sprintf("%d", coin.ID)
-	if tmp, ok := coinConstraintMap.Get(key); ok {
-		cc := tmp.(*exchange.CoinConstraint)
-		return cc.ExSymbol
+	if coinConstraint := e.GetCoinConstraint(coin); coinConstraint != nil {
+		return coinConstraint.ExSymbol
 	}
 	return ""
 }
 
 func (e *Bgogo) DeleteCoin(coin *coin.Coin) {
-	coinConstraintMap.Remove(fmt.Sprintf("%d", coin.ID))
+	e.store().DeleteCoin(coin)
 }
 
 /*************** Pairs on the Exchanges ***************/
 func (e *Bgogo) GetPairConstraint(pair *pair.Pair) *exchange.PairConstraint {
-	if tmp, ok := pairConstraintMap.Get(fmt.Sprintf("%d", pair.ID)); ok {
-		return tmp.(*exchange.PairConstraint)
-	}
-	return nil
+	return e.store().GetPair(pair)
 }
 
 func (e *Bgogo) SetPairConstraint(pairConstraint *exchange.PairConstraint) {
-	pairConstraintMap.Set(fmt.Sprintf("%d", pairConstraint.PairID), pairConstraint)
+	e.store().SetPair(pairConstraint)
 }
 
 func (e *Bgogo) GetPairs() []*pair.Pair {
-	pairList := []*pair.Pair{}
-	keySort := []int{}
-	for _, key := range pairConstraintMap.Keys() {
-		id, _ := strconv.Atoi(key)
-		keySort = append(keySort, id)
-	}
-	sort.Ints(keySort)
-	for _, key := range keySort {
-		p := pair.GetPairByID(key)
-		if p != nil {
-			pairList = append(pairList, p)
-		}
-	}
-	return pairList
+	return e.store().Pairs()
 }
 
 func (e *Bgogo) GetPairBySymbol(symbol string) *pair.Pair {
-	for _, id := range pairConstraintMap.Keys() {
-		if tmp, ok := pairConstraintMap.Get(id); ok {
-			pc := tmp.(*exchange.PairConstraint)
-			if pc.ExSymbol == symbol {
-				return pc.Pair
-			}
-		}
-	}
-	return nil
+	return e.store().PairBySymbol(symbol)
 }
 
 func (e *Bgogo) GetSymbolByPair(pair *pair.Pair) string {
@@ -219,11 +177,11 @@ func (e *Bgogo) GetSymbolByPair(pair *pair.Pair) string {
 }
 
 func (e *Bgogo) HasPair(pair *pair.Pair) bool {
-	return pairConstraintMap.Has(fmt.Sprintf("%d", pair.ID))
+	return e.store().HasPair(pair)
 }
 
 func (e *Bgogo) DeletePair(pair *pair.Pair) {
-	pairConstraintMap.Remove(fmt.Sprintf("%d", pair.ID))
+	e.store().DeletePair(pair)
 }
 
 /**************** Exchange Constraint ****************/
@@ -243,9 +201,13 @@ func (e *Bgogo) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
-func (e *Bgogo) UpdateConstraint() {
-	e.GetCoinsData()
-	e.GetPairsData()
+// UpdateConstraint refreshes the constraints from the microservice when it is
+// the data source, from the exchange API otherwise.
+func (e *Bgogo) UpdateConstraint() error {
+	if e.Source == exchange.MICROSERVICE_API {
+		return e.InitData()
+	}
+	return e.fetchConstraint()
 }
 
 /**************** Coin Constraint ****************/
@@ -290,6 +252,14 @@ func (e *Bgogo) GetFee(pair *pair.Pair) float64 {
 	return pairConstraint.TakerFee
 }
 
+func (e *Bgogo) GetMakerFee(pair *pair.Pair) float64 {
+	return exchange.MakerFee(e, pair)
+}
+
+func (e *Bgogo) GetTakerFee(pair *pair.Pair) float64 {
+	return exchange.TakerFee(e, pair)
+}
+
 func (e *Bgogo) GetLotSize(pair *pair.Pair) float64 {
 	pairConstraint := e.GetPairConstraint(pair)
 	if pairConstraint == nil {
@@ -337,8 +337,10 @@ func (e *Bibox) doWithdraw(operation *exchange.AccountOperation) error {
 	if operation.WithdrawTag != "" {
 		body["addr_remark"] = operation.WithdrawTag
 	}
-	// body["totp_code"] = 123456     //googleAuth, need if new addr
-	// body["trade_pwd"] = "tradePWD" //tradePWD, need if new addr
+	if err := e.secondFactor(body); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
 	// body["memo"] = "" // memo is required for some tokens, such as EOS
 
 	mapParams["body"] = body
@@ -418,11 +420,24 @@ func (e *Bibox) UpdateAllBalances() {
 
 }
 
-// googleAuth: google验证码
+/*The google auth code of the Two_Factor seed and the trade password, needed for new addresses*/
+func (e *Bibox) secondFactor(body map[string]interface{}) error {
+	if e.Two_Factor != "" {
+		code, err := exchange.TwoFactorCode(e.Two_Factor)
+		if err != nil {
+			return fmt.Errorf("%s %v", e.GetName(), err)
+		}
+		body["totp_code"] = code // the 6 digits, a leading 0 included
+	}
+	if e.TradePassword != "" {
+		body["trade_pwd"] = e.TradePassword
+	}
+	return nil
+}
+
 // tag： 提现地址备注
 // memo： 提现标签(can be "", not required)
-// need to update interface to use more params
-func (e *Bibox) Withdraw(coin *coin.Coin, quantity float64, addr, tag string /* , googleAuth int, tradePWD, memo string */) bool {
+func (e *Bibox) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		log.Printf("bibox API Key or Secret Key are nil.")
 		return false
@@ -439,8 +454,10 @@ func (e *Bibox) Withdraw(coin *coin.Coin, quantity float64, addr, tag string /*
 	body := make(map[string]interface{})
 	body["coin_symbol"] = e.GetSymbolByCoin(coin)
 	body["amount"] = quantity
-	body["totp_code"] = 123456     //googleAuth
-	body["trade_pwd"] = "tradePWD" //tradePWD
+	if err := e.secondFactor(body); err != nil {
+		log.Printf("%v", err)
+		return false
+	}
 	body["addr"] = addr
 	body["addr_remark"] = tag
 	body["memo"] = "" //memo
@@ -468,6 +485,11 @@ func (e *Bibox) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/orderpending"
@@ -513,6 +535,11 @@ func (e *Bibox) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/orderpending"
@@ -681,7 +708,7 @@ func (e *Bibox) ApiKeyPOST(strRequestPath string, mapParams map[string]interface
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	request.Header.Add("Accept", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -720,7 +747,7 @@ func (e *Bibox) ApiKeyPOSTInner(strRequestPath string, mapParams map[string]inte
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	request.Header.Add("Accept", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -7,12 +7,8 @@ package bibox
 import (
 	"fmt"
 	"log"
-	"sort"
-	"strconv"
 	"sync"
 
-	cmap "github.com/orcaman/concurrent-map"
-
 	"github.com/bitontop/gored/coin"
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
@@ -24,16 +20,19 @@ type Bibox struct {
 	Name    string `bson:"name"`
 	Website string `bson:"website"`
 
-	API_KEY    string
-	API_SECRET string
+	API_KEY       string
+	API_SECRET    string
+	Two_Factor    string // the TOTP seed, for the totp_code of withdrawals
+	TradePassword string
 
 	Source    exchange.DataSource // / exchange API / microservicve api 1 / PSQL
 	SourceURI string
+
+	staging *exchange.Constraints // the constraints being fetched by fetchConstraint
 }
 
-var pairConstraintMap cmap.ConcurrentMap
-var coinConstraintMap cmap.ConcurrentMap
-var balanceMap cmap.ConcurrentMap
+var constraints *exchange.Constraints
+var balanceMap *exchange.Balances
 
 var instance *Bibox
 var once sync.Once
@@ -46,15 +45,16 @@ func CreateBibox(config *exchange.Config) *Bibox {
 			Name:    "Bibox",
 			Website: "https://www.bibox.com/",
 
-			API_KEY:    config.API_KEY,
-			API_SECRET: config.API_SECRET,
-			Source:     config.Source,
-			SourceURI:  config.SourceURI,
+			API_KEY:       config.API_KEY,
+			API_SECRET:    config.API_SECRET,
+			Two_Factor:    config.Two_Factor,
+			TradePassword: config.TradePassword,
+			Source:        config.Source,
+			SourceURI:     config.SourceURI,
 		}
 
-		balanceMap = cmap.New()
-		coinConstraintMap = cmap.New()
-		pairConstraintMap = cmap.New()
+		balanceMap = exchange.NewBalances(instance.GetName())
+		constraints = exchange.NewConstraints(instance.GetName())
 
 		if err := instance.InitData(); err != nil {
 			log.Printf("%v", err)
@@ -67,22 +67,24 @@ func CreateBibox(config *exchange.Config) *Bibox {
 func (e *Bibox) InitData() error {
 	switch e.Source {
 	case exchange.EXCHANGE_API:
-		if err := e.GetCoinsData(); err != nil {
-			return err
-		}
-		if err := e.GetPairsData(); err != nil {
+		if err := e.fetchConstraint(); err != nil {
 			return err
 		}
 		break
 	case exchange.MICROSERVICE_API:
+		exchangeData := utils.GetExchangeDataFromMicroservice(e.SourceURI, e.GetName())
+		if exchangeData == nil {
+			return fmt.Errorf("%s Initial Data Error.", e.GetName())
+		} else {
+			constraints.Load(exchangeData.CoinConstraint, exchangeData.PairConstraint)
+		}
 		break
 	case exchange.JSON_FILE:
 		exchangeData := utils.GetExchangeDataFromJSON(e.SourceURI, e.GetName())
 		if exchangeData == nil {
 			return fmt.Errorf("%s Initial Data Error.", e.GetName())
 		} else {
-			coinConstraintMap = exchangeData.CoinConstraint
-			pairConstraintMap = exchangeData.PairConstraint
+			constraints.Load(exchangeData.CoinConstraint, exchangeData.PairConstraint)
 		}
 		break
 	case exchange.PSQL:
@@ -92,6 +94,22 @@ func (e *Bibox) InitData() error {
 	return nil
 }
 
+// fetchConstraint fetches the coins and pairs from the exchange API into a stage,
+// swapped in once both are fetched. Readers keep the current data meanwhile.
+func (e *Bibox) fetchConstraint() error {
+	update := *e
+	update.staging = constraints.Stage()
+	return constraints.Fetch(update.staging, update.GetCoinsData, update.GetPairsData)
+}
+
+// store returns the staged constraints during fetchConstraint, otherwise the current ones.
+func (e *Bibox) store() *exchange.Constraints {
+	if e != nil && e.staging != nil {
+		return e.staging
+	}
+	return constraints
+}
+
 /**************** Exchange Information ****************/
 func (e *Bibox) GetID() int {
 	return e.ID
@@ -102,11 +120,7 @@ func (e *Bibox) GetName() exchange.ExchangeName {
 }
 
 func (e *Bibox) GetBalance(coin *coin.Coin) float64 {
-	if tmp, ok := balanceMap.Get(coin.Code); ok {
-		return tmp.(float64)
-	} else {
-		return 0.0
-	}
+	return balanceMap.Get(coin.Code)
 }
 
 func (e *Bibox) GetTradingWebURL(pair *pair.Pair) string {
@@ -115,97 +129,47 @@ func (e *Bibox) GetTradingWebURL(pair *pair.Pair) string {
 
 /*************** Coins on the Exchanges ***************/
 func (e *Bibox) GetCoinConstraint(coin *coin.Coin) *exchange.CoinConstraint {
-	if tmp, ok := coinConstraintMap.Get(fmt.Sprintf("%d", coin.ID)); ok {
-		return tmp.(*exchange.CoinConstraint)
-	}
-	return nil
+	return e.store().GetCoin(coin)
 }
 
 func (e *Bibox) SetCoinConstraint(coinConstraint *exchange.CoinConstraint) {
-	coinConstraintMap.Set(fmt.Sprintf("%d", coinConstraint.CoinID), coinConstraint)
+	e.store().SetCoin(coinConstraint)
 }
 
 func (e *Bibox) GetCoins() []*coin.Coin {
-	coinList := []*coin.Coin{}
-	keySort := []int{}
-	for _, key := range coinConstraintMap.Keys() {
-		id, _ := strconv.Atoi(key)
-		keySort = append(keySort, id)
-	}
-	sort.Ints(keySort)
-	for _, key := range keySort {
-		c := coin.GetCoinByID(key)
-		if c != nil {
-			coinList = append(coinList, c)
-		}
-	}
-	return coinList
+	return e.store().Coins()
 }
 
 func (e *Bibox) GetSymbolByCoin(coin *coin.Coin) string {
-	key := fmt.Sprintf("%d", coin.ID)
-	if tmp, ok := coinConstraintMap.Get(key); ok {
-		cc := tmp.(*exchange.CoinConstraint)
-		return cc.ExSymbol
+	if coinConstraint := e.GetCoinConstraint(coin); coinConstraint != nil {
+		return coinConstraint.ExSymbol
 	}
 	return ""
 }
 
 func (e *Bibox) GetCoinBySymbol(symbol string) *coin.Coin {
-	for _, id := range coinConstraintMap.Keys() {
-		if tmp, ok := coinConstraintMap.Get(id); ok {
-			cc := tmp.(*exchange.CoinConstraint)
-			if cc.ExSymbol == symbol {
-				return cc.Coin
-			}
-		}
-	}
-	return nil
+	return e.store().CoinBySymbol(symbol)
 }
 
 func (e *Bibox) DeleteCoin(coin *coin.Coin) {
-	coinConstraintMap.Remove(fmt.Sprintf("%d", coin.ID))
+	e.store().DeleteCoin(coin)
 }
 
 /*************** Pairs on the Exchanges ***************/
 func (e *Bibox) GetPairConstraint(pair *pair.Pair) *exchange.PairConstraint {
-	if tmp, ok := pairConstraintMap.Get(fmt.Sprintf("%d", pair.ID)); ok {
-		return tmp.(*exchange.PairConstraint)
-	}
-	return nil
+	return e.store().GetPair(pair)
 }
 
 func (e *Bibox) SetPairConstraint(pairConstraint *exchange.PairConstraint) {
-	pairConstraintMap.Set(fmt.Sprintf("%d", pairConstraint.PairID), pairConstraint)
+	e.store().SetPair(pairConstraint)
 }
 
 func (e *Bibox) GetPairs() []*pair.Pair {
-	pairList := []*pair.Pair{}
-	keySort := []int{}
-	for _, key := range pairConstraintMap.Keys() {
-		id, _ := strconv.Atoi(key)
-		keySort = append(keySort, id)
-	}
-	sort.Ints(keySort)
-	for _, key := range keySort {
-		p := pair.GetPairByID(key)
-		if p != nil {
-			pairList = append(pairList, p)
-		}
-	}
-	return pairList
+	return e.store().Pairs()
 }
 
 func (e *Bibox) GetPairBySymbol(symbol string) *pair.Pair {
-	for _, id := range pairConstraintMap.Keys() {
-		if tmp, ok := pairConstraintMap.Get(id); ok {
-			pc := tmp.(*exchange.PairConstraint)
-			if pc.ExSymbol == symbol {
-				return pc.Pair
-			}
-		}
-	}
-	return nil
+	return e.store().PairBySymbol(symbol)
 }
 
 func (e *Bibox) GetSymbolByPair(pair *pair.Pair) string {
@@ -217,11 +181,11 @@ func (e *Bibox) GetSymbolByPair(pair *pair.Pair) string {
 }
 
 func (e *Bibox) HasPair(pair *pair.Pair) bool {
-	return pairConstraintMap.Has(fmt.Sprintf("%d", pair.ID))
+	return e.store().HasPair(pair)
 }
 
 func (e *Bibox) DeletePair(pair *pair.Pair) {
-	pairConstraintMap.Remove(fmt.Sprintf("%d", pair.ID))
+	e.store().DeletePair(pair)
 }
 
 /**************** Exchange Constraint ****************/
@@ -241,9 +205,13 @@ func (e *Bibox) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
-func (e *Bibox) UpdateConstraint() {
-	e.GetCoinsData()
-	e.GetPairsData()
+// UpdateConstraint refreshes the constraints from the microservice when it is
+// the data source, from the exchange API otherwise.
+func (e *Bibox) UpdateConstraint() error {
+	if e.Source == exchange.MICROSERVICE_API {
+		return e.InitData()
+	}
+	return e.fetchConstraint()
 }
 
 /**************** Coin Constraint ****************/
@@ -288,6 +256,14 @@ func (e *Bibox) GetFee(pair *pair.Pair) float64 {
 	return pairConstraint.TakerFee
 }
 
+func (e *Bibox) GetMakerFee(pair *pair.Pair) float64 {
+	return exchange.MakerFee(e, pair)
+}
+
+func (e *Bibox) GetTakerFee(pair *pair.Pair) float64 {
+	return exchange.TakerFee(e, pair)
+}
+
 func (e *Bibox) GetLotSize(pair *pair.Pair) float64 {
 	pairConstraint := e.GetPairConstraint(pair)
 	if pairConstraint == nil {
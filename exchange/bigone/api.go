@@ -158,6 +158,7 @@ func (e *Bigone) GetPairsData() error {
 			p = e.GetPairBySymbol(data.Name)
 		}
 		if p != nil {
+			minNotional, _ := strconv.ParseFloat(data.MinQuoteValue, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -166,6 +167,7 @@ func (e *Bigone) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * data.BaseScale),
 				PriceFilter: math.Pow10(-1 * data.QuoteScale),
+				MinNotional: minNotional,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -322,18 +324,20 @@ func (e *Bigone) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/viewer/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["asset_pair_name"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "ASK"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest(strRequest, mapParams, "POST")
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -363,18 +367,20 @@ func (e *Bigone) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/viewer/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["asset_pair_name"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BID"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest(strRequest, mapParams, "POST")
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -544,7 +550,7 @@ func (e *Bigone) ApiKeyRequest(strRequestPath string, mapParams map[string]strin
 	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
 	request.Header.Add("Content-Type", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if err != nil {
 		log.Printf("123err=%v", err)
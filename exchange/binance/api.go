@@ -10,9 +10,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bitontop/gored/coin"
@@ -129,6 +129,7 @@ func (e *Binance) GetPairsData() error {
 				var err error
 				lotsize := 0.0
 				priceFilter := 0.0
+				minQty, maxQty, minNotional, maxOrders := 0.0, 0.0, 0.0, 0
 				for _, filter := range data.Filters {
 					switch filter.FilterType {
 					case "LOT_SIZE":
@@ -137,6 +138,15 @@ func (e *Binance) GetPairsData() error {
 							log.Printf("%s Lot Size Err: %v", e.GetName(), err)
 							lotsize = DEFAULT_LOT_SIZE
 						}
+						minQty, _ = strconv.ParseFloat(filter.MinQty, 64)
+						maxQty, _ = strconv.ParseFloat(filter.MaxQty, 64)
+					case "MIN_NOTIONAL":
+						minNotional, _ = strconv.ParseFloat(filter.MinNotional, 64)
+					case "MAX_NUM_ORDERS":
+						maxOrders = filter.MaxNumOrders
+						if maxOrders == 0 {
+							maxOrders = filter.Limit
+						}
 					case "PRICE_FILTER":
 						priceFilter, err = strconv.ParseFloat(filter.TickSize, 64)
 						if err != nil {
@@ -153,6 +163,10 @@ func (e *Binance) GetPairsData() error {
 					TakerFee:    DEFAULT_TAKER_FEE,
 					LotSize:     lotsize,
 					PriceFilter: priceFilter,
+					MinQty:      minQty,
+					MaxQty:      maxQty,
+					MinNotional: minNotional,
+					MaxOrders:   maxOrders,
 					Listed:      true,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -256,7 +270,6 @@ func (e *Binance) doWithdraw(operation *exchange.AccountOperation) error {
 		mapParams["addressTag"] = operation.WithdrawTag
 	}
 	mapParams["amount"] = operation.WithdrawAmount
-	mapParams["timestamp"] = fmt.Sprintf("%d", time.Now().UnixNano()/1e6)
 
 	jsonSubmitWithdraw := e.WApiKeyRequest("POST", mapParams, strRequest)
 	if operation.DebugMode {
@@ -308,6 +321,82 @@ func (e *Binance) UpdateAllBalances() {
 	}
 }
 
+/*Get the account's commission, in 1/10000, and set it as the fee of all the pairs*/
+func (e *Binance) UpdateFees() error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	accountBalance := AccountBalances{}
+	strRequest := "/api/v3/account"
+
+	jsonAccountReturn := e.ApiKeyGet(make(map[string]string), strRequest)
+	if err := json.Unmarshal([]byte(jsonAccountReturn), &accountBalance); err != nil {
+		return fmt.Errorf("%s UpdateFees Unmarshal Err: %v %v", e.GetName(), err, jsonAccountReturn)
+	} else if accountBalance.Code != 0 {
+		return e.apiError("UpdateFees", accountBalance.Code, accountBalance.Msg, jsonAccountReturn)
+	}
+
+	makerFee := float64(accountBalance.MakerCommission) / 10000
+	takerFee := float64(accountBalance.TakerCommission) / 10000
+	for _, p := range e.GetPairs() {
+		if pairConstraint := e.GetPairConstraint(p); pairConstraint != nil {
+			update := *pairConstraint
+			update.MakerFee = makerFee
+			update.TakerFee = takerFee
+			e.SetPairConstraint(&update)
+		}
+	}
+	return nil
+}
+
+/*The permissions of the API key. The trading rights of keys without IP restriction expire, the key expires with them unless they already did*/
+func (e *Binance) CheckCredentials() (*exchange.Credentials, error) {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	apiRestrictions := ApiRestrictions{}
+	strRequest := "/sapi/v1/account/apiRestrictions"
+
+	jsonRestrictionsReturn := e.ApiKeyGet(make(map[string]string), strRequest)
+	if err := json.Unmarshal([]byte(jsonRestrictionsReturn), &apiRestrictions); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Unmarshal Err: %v %v", e.GetName(), err, jsonRestrictionsReturn)
+	} else if apiRestrictions.Code != 0 {
+		return nil, e.apiError("CheckCredentials", apiRestrictions.Code, apiRestrictions.Msg, jsonRestrictionsReturn)
+	}
+
+	credentials := exchange.NewCredentials(e.GetName())
+	credentials.Permissions[exchange.READ] = apiRestrictions.EnableReading
+	credentials.Permissions[exchange.TRADE] = apiRestrictions.EnableSpotAndMarginTrading
+	credentials.Permissions[exchange.WITHDRAW] = apiRestrictions.EnableWithdrawals
+	credentials.Permissions[exchange.MARGIN] = apiRestrictions.EnableMargin
+	credentials.Permissions[exchange.FUTURES] = apiRestrictions.EnableFutures
+	credentials.IPRestricted = apiRestrictions.IPRestrict
+	if expireTS := apiRestrictions.TradingAuthorityExpirationTime / 1000; expireTS > time.Now().Unix() {
+		credentials.ExpireTS = expireTS
+	} else if expireTS > 0 {
+		credentials.Permissions[exchange.TRADE] = false
+	}
+	return credentials, nil
+}
+
+/*Get the server time, the timestamps of the signed requests are on its clock*/
+func (e *Binance) ServerTime() (time.Time, error) {
+	serverTime := ServerTime{}
+
+	strUrl := API_URL + "/api/v3/time"
+
+	jsonTimeReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonTimeReturn), &serverTime); err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Unmarshal Err: %v %v", e.GetName(), err, jsonTimeReturn)
+	} else if serverTime.ServerTime == 0 {
+		return time.Time{}, fmt.Errorf("%s ServerTime Failed: %v", e.GetName(), jsonTimeReturn)
+	}
+
+	return time.Unix(0, serverTime.ServerTime*int64(time.Millisecond)), nil
+}
+
 /* Withdraw(coin *coin.Coin, quantity float64, addr, tag string) */
 func (e *Binance) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
 	if e.API_KEY == "" || e.API_SECRET == "" {
@@ -325,7 +414,6 @@ func (e *Binance) Withdraw(coin *coin.Coin, quantity float64, addr, tag string)
 		mapParams["addressTag"] = tag
 	}
 	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["timestamp"] = fmt.Sprintf("%d", time.Now().UnixNano()/1e6)
 
 	jsonSubmitWithdraw := e.WApiKeyRequest("POST", mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &withdraw); err != nil {
@@ -345,25 +433,27 @@ func (e *Binance) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v3/order"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
 	mapParams["timeInForce"] = "GTC"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["quantity"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitSell Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if placeOrder.Code != 0 {
-		return nil, fmt.Errorf("%s LimitSell failed:%v Message:%v", e.GetName(), placeOrder.Code, placeOrder.Msg)
+		return nil, e.apiError("LimitSell", placeOrder.Code, placeOrder.Msg, jsonPlaceReturn)
 	}
 
 	order := &exchange.Order{
@@ -383,25 +473,27 @@ func (e *Binance) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v3/order"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
 	mapParams["timeInForce"] = "GTC"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["quantity"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitBuy Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if placeOrder.Code != 0 {
-		return nil, fmt.Errorf("%s LimitBuy failed:%v Message:%v", e.GetName(), placeOrder.Code, placeOrder.Msg)
+		return nil, e.apiError("LimitBuy", placeOrder.Code, placeOrder.Msg, jsonPlaceReturn)
 	}
 
 	order := &exchange.Order{
@@ -433,7 +525,7 @@ func (e *Binance) OrderStatus(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonOrderStatus), &orderStatus); err != nil {
 		return fmt.Errorf("%s OrderStatus Unmarshal Err: %v %v", e.GetName(), err, jsonOrderStatus)
 	} else if orderStatus.Code != 0 {
-		return fmt.Errorf("%s Get OrderStatus Error: %v %s", e.GetName(), orderStatus.Code, orderStatus.Msg)
+		return e.apiError("OrderStatus", orderStatus.Code, orderStatus.Msg, jsonOrderStatus)
 	}
 
 	if orderStatus.Status == "CANCELED" {
@@ -478,7 +570,7 @@ func (e *Binance) CancelOrder(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonCancelOrder), &cancelOrder); err != nil {
 		return fmt.Errorf("%s CancelOrder Unmarshal Err: %v %v", e.GetName(), err, jsonCancelOrder)
 	} else if cancelOrder.Code != 0 {
-		return fmt.Errorf("%s CancelOrder Error: %v %s", e.GetName(), cancelOrder.Code, cancelOrder.Msg)
+		return e.apiError("CancelOrder", cancelOrder.Code, cancelOrder.Msg, jsonCancelOrder)
 	}
 
 	order.Status = exchange.Canceling
@@ -491,27 +583,58 @@ func (e *Binance) CancelAllOrder() error {
 	return nil
 }
 
+/*Map the error code of the API to the exchange errors, the payload attached*/
+func (e *Binance) apiError(op string, code int, msg, payload string) error {
+	err := errorCodes[code]
+	switch {
+	case code == -1013 && (strings.Contains(msg, "MIN_NOTIONAL") || strings.Contains(msg, "MAX_NUM_ORDERS")):
+		err = exchange.ErrOrderLimit
+	case code == -2010 && !strings.Contains(strings.ToLower(msg), "insufficient"):
+		err = nil
+	}
+	return exchange.NewApiError(e.GetName(), op, code, msg, payload, err)
+}
+
 /*************** Signature Http Request ***************/
+/*The query is signed with the timestamp on the server clock,
+with the wider window of the local clock until it is synced*/
+func (e *Binance) signer() exchange.Signer {
+	clock := exchange.GetClock(e.GetName())
+	recvWindow := int64(RECV_WINDOW)
+	if clock.Status().LastSync.IsZero() {
+		recvWindow = RECV_WINDOW_UNSYNCED
+	}
+	return &exchange.HmacQuerySigner{
+		Key:        e.API_KEY,
+		Secret:     e.API_SECRET,
+		KeyHeader:  "X-MBX-APIKEY",
+		RecvWindow: recvWindow,
+		Now:        clock.Now,
+	}
+}
+
 /*Method: API Request and Signature is required
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Binance) ApiKeyGet(mapParams map[string]string, strRequestPath string) string {
-	mapParams["recvWindow"] = "50000" //"50000000"
-	mapParams["timestamp"] = fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond))
-	mapParams["signature"] = exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
+	signRequest := &exchange.SignRequest{Method: "GET", Path: strRequestPath, Query: exchange.Map2UrlQuery(mapParams)}
+	if err := e.signer().Sign(signRequest); err != nil {
+		return err.Error()
+	}
 
-	payload := exchange.Map2UrlQuery(mapParams)
-	strUrl := API_URL + strRequestPath + "?" + payload
+	strUrl := API_URL + strRequestPath + "?" + signRequest.Query
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
 		return err.Error()
 	}
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -531,21 +654,23 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Binance) ApiKeyRequest(strMethod string, mapParams map[string]string, strRequestPath string) string {
-	mapParams["recvWindow"] = "50000" //"50000000"
-	mapParams["timestamp"] = fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond))
-	mapParams["signature"] = exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
+	signRequest := &exchange.SignRequest{Method: strMethod, Path: strRequestPath, Query: exchange.Map2UrlQuery(mapParams)}
+	if err := e.signer().Sign(signRequest); err != nil {
+		return err.Error()
+	}
 
-	payload := exchange.Map2UrlQuery(mapParams)
 	strUrl := API_URL + strRequestPath
 
-	request, err := http.NewRequest(strMethod, strUrl, bytes.NewBuffer([]byte(payload)))
+	request, err := http.NewRequest(strMethod, strUrl, bytes.NewBuffer([]byte(signRequest.Query)))
 	if nil != err {
 		return err.Error()
 	}
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -565,21 +690,23 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Binance) WApiKeyRequest(strMethod string, mapParams map[string]string, strRequestPath string) string {
-	mapParams["recvWindow"] = "50000" //"50000000"
-	mapParams["timestamp"] = fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond))
-	signature := exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
+	signRequest := &exchange.SignRequest{Method: strMethod, Path: strRequestPath, Query: exchange.Map2UrlQuery(mapParams)}
+	if err := e.signer().Sign(signRequest); err != nil {
+		return err.Error()
+	}
 
-	payload := fmt.Sprintf("%s&signature=%s", exchange.Map2UrlQuery(mapParams), signature)
 	strUrl := API_URL + strRequestPath
 
-	request, err := http.NewRequest(strMethod, strUrl, bytes.NewBuffer([]byte(payload)))
+	request, err := http.NewRequest(strMethod, strUrl, bytes.NewBuffer([]byte(signRequest.Query)))
 	if nil != err {
 		return err.Error()
 	}
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
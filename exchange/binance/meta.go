@@ -4,10 +4,35 @@ package binance
 // Distributed under the MIT software license, see the accompanying
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
+import (
+	"github.com/bitontop/gored/exchange"
+)
+
 const (
 	DEFAULT_ID           = 1
 	DEFAULT_TAKER_FEE    = 0.001
 	DEFAULT_MAKER_FEE    = 0.001
 	DEFAULT_LOT_SIZE     = 0.00000001
 	DEFAULT_PRICE_FILTER = 0.00000001 //PRICE FILTER
+	RECV_WINDOW          = 5000       // ms, the timestamps are on the server clock
+	RECV_WINDOW_UNSYNCED = 50000      // ms, until the clock is synced
 )
+
+// errorCodes maps the Binance error codes to the exchange errors,
+// -1013 and -2010 are refined by the message in apiError.
+var errorCodes = map[int]error{
+	-1001: exchange.ErrMaintenance, // DISCONNECTED
+	-1003: exchange.ErrRateLimited, // TOO_MANY_REQUESTS
+	-1015: exchange.ErrRateLimited, // TOO_MANY_ORDERS
+	-1016: exchange.ErrMaintenance, // SERVICE_SHUTTING_DOWN
+	-1021: exchange.ErrTimestamp,   // INVALID_TIMESTAMP
+	-1022: exchange.ErrAuth,        // INVALID_SIGNATURE
+	-1013: exchange.ErrInvalidPrecision,
+	-1111: exchange.ErrInvalidPrecision, // BAD_PRECISION
+	-1121: exchange.ErrInvalidSymbol,    // BAD_SYMBOL
+	-2010: exchange.ErrInsufficientFunds,
+	-2011: exchange.ErrOrderNotFound, // CANCEL_REJECTED, unknown or closed order
+	-2013: exchange.ErrOrderNotFound, // NO_SUCH_ORDER
+	-2014: exchange.ErrAuth,          // BAD_API_KEY_FMT
+	-2015: exchange.ErrAuth,          // REJECTED_MBX_KEY
+}
@@ -5,13 +5,15 @@ package binance
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
 type AccountBalances struct {
-	MakerCommission  int  `json:"makerCommission"`
-	TakerCommission  int  `json:"takerCommission"`
-	BuyerCommission  int  `json:"buyerCommission"`
-	SellerCommission int  `json:"sellerCommission"`
-	CanTrade         bool `json:"canTrade"`
-	CanWithdraw      bool `json:"canWithdraw"`
-	CanDeposit       bool `json:"canDeposit"`
+	Code             int    `json:"code"`
+	Msg              string `json:"msg"`
+	MakerCommission  int    `json:"makerCommission"`
+	TakerCommission  int    `json:"takerCommission"`
+	BuyerCommission  int    `json:"buyerCommission"`
+	SellerCommission int    `json:"sellerCommission"`
+	CanTrade         bool   `json:"canTrade"`
+	CanWithdraw      bool   `json:"canWithdraw"`
+	CanDeposit       bool   `json:"canDeposit"`
 	Balances         []struct {
 		Asset  string `json:"asset"`
 		Free   string `json:"free"`
@@ -50,6 +52,26 @@ type OrderBook struct {
 	Asks         [][]interface{} `json:"asks"`
 }
 
+type ApiRestrictions struct {
+	Code                           int    `json:"code"`
+	Msg                            string `json:"msg"`
+	IPRestrict                     bool   `json:"ipRestrict"`
+	CreateTime                     int64  `json:"createTime"`
+	EnableReading                  bool   `json:"enableReading"`
+	EnableSpotAndMarginTrading     bool   `json:"enableSpotAndMarginTrading"`
+	EnableWithdrawals              bool   `json:"enableWithdrawals"`
+	EnableInternalTransfer         bool   `json:"enableInternalTransfer"`
+	EnableMargin                   bool   `json:"enableMargin"`
+	EnableFutures                  bool   `json:"enableFutures"`
+	EnableVanillaOptions           bool   `json:"enableVanillaOptions"`
+	PermitsUniversalTransfer       bool   `json:"permitsUniversalTransfer"`
+	TradingAuthorityExpirationTime int64  `json:"tradingAuthorityExpirationTime"`
+}
+
+type ServerTime struct {
+	ServerTime int64 `json:"serverTime"`
+}
+
 type PairsData struct {
 	Timezone   string `json:"timezone"`
 	ServerTime int64  `json:"serverTime"`
@@ -78,6 +100,7 @@ type PairsData struct {
 			StepSize         string `json:"stepSize,omitempty"`
 			MinNotional      string `json:"minNotional,omitempty"`
 			Limit            int    `json:"limit,omitempty"`
+			MaxNumOrders     int    `json:"maxNumOrders,omitempty"`
 			MaxNumAlgoOrders int    `json:"maxNumAlgoOrders,omitempty"`
 		} `json:"filters"`
 	} `json:"symbols"`
@@ -273,6 +273,11 @@ func (e *BitATM) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]interface{})
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
 	mapParams["Price"] = rate
@@ -312,6 +317,11 @@ func (e *BitATM) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]interface{})
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
 	mapParams["Price"] = rate
@@ -449,7 +459,7 @@ func (e *BitATM) ApiKeyGET(strRequestPath string, mapParams map[string]interface
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	request.Header.Add("Accept", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -497,7 +507,7 @@ func (e *BitATM) ApiKeyPOST(strRequestPath string, mapParams map[string]interfac
 	request.Header.Add("Accept", "application/json")
 	//request.Header.Add("apisign", signature)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
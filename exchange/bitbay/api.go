@@ -152,6 +152,8 @@ func (e *Bitbay) GetPairsData() error {
 			p = e.GetPairBySymbol(data.Market.Code)
 		}
 		if p != nil {
+			minQty, _ := strconv.ParseFloat(data.Market.First.MinOffer, 64)
+			minNotional, _ := strconv.ParseFloat(data.Market.Second.MinOffer, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -160,6 +162,8 @@ func (e *Bitbay) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * data.Market.First.Scale),
 				PriceFilter: math.Pow10(-1 * data.Market.Second.Scale),
+				MinQty:      minQty,
+				MinNotional: minNotional,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -266,6 +270,11 @@ func (e *Bitbay) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := fmt.Sprintf("/trading/offer/", e.GetSymbolByPair(pair))
 
@@ -302,6 +311,11 @@ func (e *Bitbay) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := fmt.Sprintf("/trading/offer/", e.GetSymbolByPair(pair))
 
@@ -440,7 +454,7 @@ func (e *Bitbay) ApiKeyGET(strRequestPath string, mapParams map[string]interface
 	//request.Header.Add("Accept", "application/json")
 	//request.Header.Add("apisign", signature)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
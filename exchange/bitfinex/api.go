@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -205,6 +204,8 @@ func (e *Bitfinex) GetPairsData() error {
 				}
 
 				if p != nil {
+					minQty, _ := strconv.ParseFloat(data.MinimumOrderSize, 64)
+					maxQty, _ := strconv.ParseFloat(data.MaximumOrderSize, 64)
 					pairConstraint := &exchange.PairConstraint{
 						PairID:   p.ID,
 						Pair:     p,
@@ -214,6 +215,8 @@ func (e *Bitfinex) GetPairsData() error {
 						LotSize:  DEFAULT_LOT_SIZE,
 						// api gives wrong precision value
 						PriceFilter: DEFAULT_PRICE_FILTER, //math.Pow10(data.PricePrecision * -1),
+						MinQty:      minQty,
+						MaxQty:      maxQty,
 						Listed:      true,
 					}
 					e.SetPairConstraint(pairConstraint)
@@ -373,16 +376,18 @@ func (e *Bitfinex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/order/new"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 	mapParams["side"] = "sell"
 	mapParams["type"] = "exchange limit"
 
@@ -410,16 +415,18 @@ func (e *Bitfinex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/order/new"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 	mapParams["side"] = "buy"
 	mapParams["type"] = "exchange limit"
 
@@ -524,7 +531,7 @@ func (e *Bitfinex) ApiKeyPost(mapParams map[string]interface{}, strRequestPath s
 	strMethod := "POST"
 
 	mapParams["request"] = strRequestPath
-	mapParams["nonce"] = fmt.Sprintf("%v", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	//Signature Request Params
 	payload, _ := json.Marshal(mapParams)
@@ -533,7 +540,7 @@ func (e *Bitfinex) ApiKeyPost(mapParams map[string]interface{}, strRequestPath s
 
 	strUrl := API_URL + strRequestPath
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest(strMethod, strUrl, nil)
 	if nil != err {
@@ -165,6 +165,7 @@ func (e *Bitforex) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * data.AmountPrecision),
 				PriceFilter: math.Pow10(-1 * data.PricePrecision),
+				MinQty:      data.MinOrderAmount,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -283,6 +284,11 @@ func (e *Bitforex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/trade/placeOrder"
@@ -321,6 +327,11 @@ func (e *Bitforex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/trade/placeOrder"
@@ -464,7 +475,7 @@ func (e *Bitforex) ApiKeyPost(strRequestPath string, mapParams map[string]interf
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("Accept-Language", "zh-cn")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -481,12 +492,12 @@ func (e *Bitforex) ApiKeyPost(strRequestPath string, mapParams map[string]interf
 
 func (e *Bitforex) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
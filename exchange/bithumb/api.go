@@ -124,6 +124,12 @@ func (e *Bithumb) GetPairsData() error {
 		return fmt.Errorf("%s Get Pairs Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
 	}
 
+	// the minimum order quantity is listed by coin
+	minTxAmts := make(map[string]float64)
+	for _, data := range pairsData.CoinConfig {
+		minTxAmts[data.Name], _ = strconv.ParseFloat(data.MinTxAmt, 64)
+	}
+
 	for _, data := range pairsData.SpotConfig {
 		symbols := strings.Split(data.Symbol, "-")
 		p := &pair.Pair{}
@@ -155,6 +161,7 @@ func (e *Bithumb) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * logSize),
 				PriceFilter: math.Pow10(-1 * priceSize),
+				MinQty:      minTxAmts[symbols[0]],
 				Listed:      true,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -534,6 +541,11 @@ func (e *Bithumb) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
@@ -573,6 +585,11 @@ func (e *Bithumb) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
@@ -697,12 +714,12 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bithumb) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
@@ -757,7 +774,7 @@ func (e *Bithumb) ApiKeyRequest(strMethod string, mapParams map[string]string, s
 
 	request.Header.Add("Content-Type", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
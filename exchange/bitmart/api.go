@@ -127,6 +127,8 @@ func (e *Bitmart) GetPairsData() error {
 			if err != nil {
 				return fmt.Errorf("%s lotSize parse error: %v, %v", e.GetName(), err, data.QuoteIncrement)
 			}
+			minQty, _ := strconv.ParseFloat(data.BaseMinSize, 64)
+			maxQty, _ := strconv.ParseFloat(data.BaseMaxSize, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -135,6 +137,8 @@ func (e *Bitmart) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     lotSize,
 				PriceFilter: math.Pow10(-1 * data.PriceMaxPrecision),
+				MinQty:      minQty,
+				MaxQty:      maxQty,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -247,17 +251,19 @@ func (e *Bitmart) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/v2/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "sell"
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -284,17 +290,19 @@ func (e *Bitmart) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/v2/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "buy"
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -385,9 +393,13 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bitmart) ApiKeyRequest(strMethod string, strRequestPath string, mapParams map[string]string) string {
-	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
 	strUrl := API_URL + strRequestPath
 
+	signRequest := &exchange.SignRequest{Method: strMethod, Path: strRequestPath, Params: mapParams}
+	if err := e.signer().Sign(signRequest); err != nil {
+		return err.Error()
+	}
+
 	request := &http.Request{}
 	var err error
 
@@ -400,8 +412,7 @@ func (e *Bitmart) ApiKeyRequest(strMethod string, strRequestPath string, mapPara
 		request, err = http.NewRequest(strMethod, strUrl, strings.NewReader(jsonParams))
 	} else if strMethod == "GET" {
 		if mapParams != nil {
-			strParams := exchange.Map2UrlQuery(mapParams)
-			strUrl = strUrl + "?" + strParams
+			strUrl = strUrl + "?" + signRequest.Query
 		}
 		request, err = http.NewRequest(strMethod, strUrl, nil)
 	}
@@ -410,17 +421,14 @@ func (e *Bitmart) ApiKeyRequest(strMethod string, strRequestPath string, mapPara
 		return err.Error()
 	}
 
-	if strMethod != "GET" {
-		request.Header.Add("X-BM-SIGNATURE", exchange.ComputeHmac256NoDecode(exchange.Map2UrlQueryUrl(mapParams), e.API_SECRET))
-	}
-
 	request.Header.Add("User-Agent", "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/39.0.2171.71 Safari/537.36")
 	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add("X-BM-TIMESTAMP", fmt.Sprintf("%v", timestamp))
-	request.Header.Add("X-BM-AUTHORIZATION", "Bearer "+e.GetToken(e.API_KEY, e.API_SECRET, e.Passphrase))
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
 	// 发出请求
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -436,25 +444,48 @@ func (e *Bitmart) ApiKeyRequest(strMethod string, strRequestPath string, mapPara
 	return string(body)
 }
 
+/*The access token is kept by the signer until it expires, a new one is got for new keys*/
+func (e *Bitmart) signer() *exchange.BearerSigner {
+	signerMutex.Lock()
+	defer signerMutex.Unlock()
+	if signer == nil || signer.Key != e.API_KEY || signer.Secret != e.API_SECRET || signer.Memo != e.Passphrase {
+		signer = &exchange.BearerSigner{
+			Key:    e.API_KEY,
+			Secret: e.API_SECRET,
+			Memo:   e.Passphrase,
+			Token:  e.accessToken,
+		}
+	}
+	return signer
+}
+
 func (e *Bitmart) GetToken(key string, secret string, memo string) string {
-	token := exchange.ComputeHmac256NoDecode((key + ":" + secret + ":" + memo), secret)
+	clientSecret := (&exchange.BearerSigner{Key: key, Secret: secret, Memo: memo}).ClientSecret()
+	token, _, err := e.accessToken(key, clientSecret)
+	if err != nil {
+		log.Printf("%v", err)
+	}
+
+	return token
+}
 
+func (e *Bitmart) accessToken(key, clientSecret string) (string, time.Duration, error) {
 	mapParams := make(map[string]string)
 	mapParams["grant_type"] = "client_credentials"
 	mapParams["client_id"] = key
-	mapParams["client_secret"] = token
+	mapParams["client_secret"] = clientSecret
 
 	accessToken := AccessToken{}
 	strRequest := "https://openapi.bitmart.com/v2/authentication"
 
 	jsonBitmart := e.TokenReq(strRequest, mapParams)
-	err := json.Unmarshal([]byte(jsonBitmart), &accessToken)
-	if err != nil {
-		log.Printf("Create AccessToken json unmarshal error : %v", jsonBitmart)
+	if err := json.Unmarshal([]byte(jsonBitmart), &accessToken); err != nil {
+		return "", 0, fmt.Errorf("%s Create AccessToken Json Unmarshal Err: %v %v", e.GetName(), err, jsonBitmart)
+	} else if accessToken.AccessToken == "" {
+		return "", 0, fmt.Errorf("%s Create AccessToken Failed: %v", e.GetName(), jsonBitmart)
 	}
-	token = accessToken.AccessToken
 
-	return token
+	return accessToken.AccessToken, time.Duration(accessToken.ExpiresIn) * time.Second, nil
 }
 
 func (e *Bitmart) TokenReq(resource string, mapParams map[string]string) string {
@@ -468,7 +499,7 @@ func (e *Bitmart) TokenReq(resource string, mapParams map[string]string) string
 	req.Header.Add("Accept", "application/json")
 
 	// 发出请求
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(req)
 	if err != nil {
 		log.Printf("err=%v", err)
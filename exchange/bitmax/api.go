@@ -134,6 +134,9 @@ func (e *Bitmax) GetPairsData() error {
 			p = e.GetPairBySymbol(data.Symbol)
 		}
 		if p != nil && data.Status != "NoTrading" {
+			minQty, _ := strconv.ParseFloat(data.MinQty, 64)
+			maxQty, _ := strconv.ParseFloat(data.MaxQty, 64)
+			minNotional, _ := strconv.ParseFloat(data.MinNotional, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -142,6 +145,9 @@ func (e *Bitmax) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * data.QtyScale),
 				PriceFilter: math.Pow10(-1 * data.PriceScale),
+				MinQty:      minQty,
+				MaxQty:      maxQty,
+				MinNotional: minNotional,
 				Listed:      data.Status != "NoTrading",
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -305,6 +311,11 @@ func (e *Bitmax) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestUrl := fmt.Sprintf("/%v/api/v1/order", e.Account_Group)
@@ -347,6 +358,11 @@ func (e *Bitmax) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestUrl := fmt.Sprintf("/%v/api/v1/order", e.Account_Group)
@@ -484,7 +500,7 @@ func (e *Bitmax) ApiKeyGet(mapParams map[string]string, strRequestPath, path str
 	request.Header.Add("x-auth-timestamp", timestamp)
 
 	// 发出请求
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -512,7 +528,7 @@ func (e *Bitmax) ApiKeyRequest(mapParams map[string]string, strMethod, strReques
 		jsonParams = string(bytesParams)
 	}
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest(strMethod, strUrl, strings.NewReader(jsonParams))
 	if nil != err {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -21,7 +22,8 @@ import (
 
 /*The Base Endpoint URL*/
 const (
-	API_URL = "https://www.bitmex.com/api/v1"
+	API_URL  = "https://www.bitmex.com/api/v1"
+	API_PATH = "/api/v1" // the path of API_URL, signed with the request path
 )
 
 /*API Base Knowledge
@@ -156,6 +158,7 @@ func (e *Bitmex) GetPairsData() error {
 					TakerFee:    data.TakerFee,
 					LotSize:     data.LotSize,
 					PriceFilter: data.TickSize,
+					MaxQty:      data.MaxOrderQty,
 					Listed:      true,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -226,6 +229,117 @@ func (e *Bitmex) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
 
 /*************** Private API ***************/
 func (e *Bitmex) DoAccoutOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.BalanceList:
+		return e.getAllBalance(operation)
+	case exchange.Withdraw:
+		return e.doWithdraw(operation)
+	}
+	return fmt.Errorf("Operation type invalid: %v", operation.Type)
+}
+
+/*The margin of every currency, BitMEX only holds XBt, in satoshis*/
+func (e *Bitmex) getAllBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	errResponse := ErrorResponse{}
+	margins := []Margin{}
+	strRequest := "/user/margin"
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = "all"
+
+	jsonAllBalanceReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.MapParams = fmt.Sprintf("%+v", mapParams)
+		operation.CallResponce = jsonAllBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonAllBalanceReturn), &errResponse); err == nil && errResponse.Error.Message != "" {
+		operation.Error = e.apiError("getAllBalance", &errResponse, jsonAllBalanceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal([]byte(jsonAllBalanceReturn), &margins); err != nil {
+		operation.Error = fmt.Errorf("%s getAllBalance Json Unmarshal Err: %v %v", e.GetName(), err, jsonAllBalanceReturn)
+		return operation.Error
+	}
+
+	for _, margin := range margins {
+		if margin.Currency != "XBt" {
+			continue
+		}
+		b := exchange.AssetBalance{
+			Coin:             e.GetCoinBySymbol("XBT"),
+			BalanceAvailable: float64(margin.AvailableMargin) / 1e8,
+			BalanceFrozen:    float64(margin.WalletBalance-margin.AvailableMargin) / 1e8,
+		}
+		operation.BalanceList = append(operation.BalanceList, b)
+	}
+
+	return nil
+}
+
+/*BitMEX only withdraws XBt, the amount in satoshis, confirmed by the TOTP code of the Two_Factor seed*/
+func (e *Bitmex) withdrawParams(coin *coin.Coin, quantity float64, addr string) (map[string]string, error) {
+	if e.Two_Factor == "" {
+		return nil, fmt.Errorf("%s Two Factor Seed is nil", e.GetName())
+	} else if e.GetSymbolByCoin(coin) != "XBT" {
+		return nil, fmt.Errorf("%s Withdraw Not Supported: %v", e.GetName(), e.GetSymbolByCoin(coin))
+	}
+	otpToken, err := exchange.TwoFactorCode(e.Two_Factor)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", e.GetName(), err)
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = "XBt"
+	mapParams["amount"] = strconv.FormatInt(int64(math.Round(quantity*1e8)), 10)
+	mapParams["address"] = addr
+	mapParams["otpToken"] = otpToken
+	return mapParams, nil
+}
+
+func (e *Bitmex) doWithdraw(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	quantity, err := strconv.ParseFloat(operation.WithdrawAmount, 64)
+	if err != nil {
+		operation.Error = fmt.Errorf("%s Withdraw Amount Err: %v", e.GetName(), err)
+		return operation.Error
+	}
+	mapParams, err := e.withdrawParams(operation.Coin, quantity, operation.WithdrawAddress)
+	if err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	errResponse := ErrorResponse{}
+	withdraw := WithdrawResponse{}
+	strRequest := "/user/requestWithdrawal"
+
+	jsonSubmitWithdraw := e.ApiKeyPost(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.MapParams = fmt.Sprintf("%+v", mapParams)
+		operation.CallResponce = jsonSubmitWithdraw
+	}
+
+	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &errResponse); err == nil && errResponse.Error.Message != "" {
+		operation.Error = fmt.Errorf("%s Withdraw Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		return operation.Error
+	}
+	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &withdraw); err != nil {
+		operation.Error = fmt.Errorf("%s Withdraw Json Unmarshal Err: %v %v", e.GetName(), err, jsonSubmitWithdraw)
+		return operation.Error
+	}
+
+	operation.WithdrawID = withdraw.TransactID
+
 	return nil
 }
 func (e *Bitmex) UpdateAllBalances() {
@@ -242,7 +356,28 @@ func (e *Bitmex) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) b
 		log.Printf("%s API Key or Secret Key are nil.", e.GetName())
 		return false
 	}
-	return false
+
+	mapParams, err := e.withdrawParams(coin, quantity, addr)
+	if err != nil {
+		log.Printf("%v", err)
+		return false
+	}
+
+	errResponse := ErrorResponse{}
+	withdraw := WithdrawResponse{}
+	strRequest := "/user/requestWithdrawal"
+
+	jsonSubmitWithdraw := e.ApiKeyPost(mapParams, strRequest)
+	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &errResponse); err == nil && errResponse.Error.Message != "" {
+		log.Printf("%s Withdraw Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		return false
+	}
+	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &withdraw); err != nil {
+		log.Printf("%s Withdraw Json Unmarshal Err: %v %v", e.GetName(), err, jsonSubmitWithdraw)
+		return false
+	}
+
+	return true
 }
 
 func (e *Bitmex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
@@ -250,9 +385,14 @@ func (e *Bitmex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	errResponse := ErrorResponse{}
 	placeOrder := PlaceOrder{}
-	strRequest := "/api/v1/order"
+	strRequest := "/order"
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
@@ -286,9 +426,14 @@ func (e *Bitmex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	errResponse := ErrorResponse{}
 	placeOrder := PlaceOrder{}
-	strRequest := "/api/v1/order"
+	strRequest := "/order"
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
@@ -324,7 +469,7 @@ func (e *Bitmex) OrderStatus(order *exchange.Order) error {
 
 	errResponse := ErrorResponse{}
 	orderStatus := []PlaceOrder{}
-	strRequest := "/api/v1/order"
+	strRequest := "/order"
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(order.Pair)
@@ -375,33 +520,39 @@ func (e *Bitmex) CancelAllOrder() error {
 	return nil
 }
 
+/*Map the error message of the API to the exchange errors, the payload attached*/
+func (e *Bitmex) apiError(op string, errResponse *ErrorResponse, payload string) error {
+	return exchange.NewApiError(e.GetName(), op, errResponse.Error.Name, errResponse.Error.Message, payload, errorMessages[errResponse.Error.Message])
+}
+
 /*************** Signature Http Request ***************/
+/*The full /api/v1 path is signed, the request expiring 5 s from now*/
+func (e *Bitmex) signer() exchange.Signer {
+	return &exchange.ExpiresSigner{
+		Key:      e.API_KEY,
+		Secret:   e.API_SECRET,
+		Lifetime: 5 * time.Second,
+		Now:      exchange.GetClock(e.GetName()).Now,
+	}
+}
+
 /*Method: GET and Signature is required  --reference Binance
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bitmex) ApiKeyGet(mapParams map[string]string, strRequestPath string) string {
 	strMethod := "GET"
-	timestamp := time.Now().Unix() + 5
 
-	var strRequestUrl string
-	if nil == mapParams {
-		strRequestUrl = strRequestPath
-	} else {
-		strParams := exchange.Map2UrlQuery(mapParams)
-		strRequestUrl = strRequestPath + "?" + strParams
+	signRequest := &exchange.SignRequest{Method: strMethod, Path: API_PATH + strRequestPath, Params: mapParams}
+	if err := e.signer().Sign(signRequest); err != nil {
+		return err.Error()
+	}
+	strUrl := API_URL + strRequestPath
+	if signRequest.Query != "" {
+		strUrl += "?" + signRequest.Query
 	}
 
-	strPayload := fmt.Sprintf("%s%s%d", strMethod, strRequestUrl, timestamp)
-
-	mapParams2Sign := make(map[string]string)
-	mapParams2Sign["api-expires"] = strconv.FormatInt(timestamp, 10)
-	mapParams2Sign["api-key"] = e.API_KEY
-	mapParams2Sign["api-signature"] = exchange.ComputeHmac256Base64(strPayload, e.API_SECRET)
-
-	strUrl := API_URL + strRequestUrl
-
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	// 构建Request, 并且按官方要求添加Http Header
 	request, err := http.NewRequest(strMethod, strUrl, nil)
@@ -409,9 +560,9 @@ func (e *Bitmex) ApiKeyGet(mapParams map[string]string, strRequestPath string) s
 		return err.Error()
 	}
 	request.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/39.0.2171.71 Safari/537.36")
-	request.Header.Set("api-expires", mapParams2Sign["api-expires"])
-	request.Header.Set("api-key", mapParams2Sign["api-key"])
-	request.Header.Set("api-signature", mapParams2Sign["api-signature"])
+	for key, value := range signRequest.Header {
+		request.Header.Set(key, value)
+	}
 
 	// 发出请求
 	response, err := httpClient.Do(request)
@@ -435,32 +586,31 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bitmex) ApiKeyPost(mapParams map[string]string, strRequestPath string) string {
 	strMethod := "POST"
-	timestamp := time.Now().Unix() + 5
 
-	jsonParams := ""
+	signRequest := &exchange.SignRequest{Method: strMethod, Path: API_PATH + strRequestPath}
 	if nil != mapParams {
 		bytesParams, _ := json.Marshal(mapParams)
-		jsonParams = string(bytesParams)
+		signRequest.Body = string(bytesParams)
+	}
+	if err := e.signer().Sign(signRequest); err != nil {
+		return err.Error()
 	}
 	strUrl := API_URL + strRequestPath
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	// 构建Request, 并且按官方要求添加Http Header
-	request, err := http.NewRequest(strMethod, strUrl, strings.NewReader(jsonParams))
+	request, err := http.NewRequest(strMethod, strUrl, strings.NewReader(signRequest.Body))
 	if nil != err {
 		return err.Error()
 	}
-	// log.Printf("Request: %s", request)
 	request.Header.Add("User-Agent", "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/39.0.2171.71 Safari/537.36")
 	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add("api-expires", strconv.FormatInt(timestamp, 10))
-	request.Header.Add("api-key", e.API_KEY)
-	strPayload := fmt.Sprintf("%s%s%d%s", strMethod, strRequestPath, timestamp, jsonParams)
-	request.Header.Add("api-signature", exchange.ComputeHmac256Base64(strPayload, e.API_SECRET))
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
 	// 发出请求
 	response, err := httpClient.Do(request)
-	// log.Printf("Response: %s", response)
 	if nil != err {
 		return err.Error()
 	}
@@ -468,7 +618,6 @@ func (e *Bitmex) ApiKeyPost(mapParams map[string]string, strRequestPath string)
 
 	// 解析响应内容
 	body, err := ioutil.ReadAll(response.Body)
-	// log.Printf("Body: %s", body)
 	if nil != err {
 		return err.Error()
 	}
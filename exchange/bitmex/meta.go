@@ -4,6 +4,10 @@ package bitmex
 // Distributed under the MIT software license, see the accompanying
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
+import (
+	"github.com/bitontop/gored/exchange"
+)
+
 const (
 	DEFAULT_ID           = 5
 	DEFAULT_TAKER_FEE    = 0.001
@@ -15,3 +19,10 @@ const (
 	DEFAULT_DEPOSIT      = true
 	DEFAULT_CONFIRMATION = 2
 )
+
+/*The messages of the errors BitMEX replies with, mapped to the exchange errors*/
+var errorMessages = map[string]error{
+	"Invalid API Key.":     exchange.ErrAuth,
+	"Signature not valid.": exchange.ErrAuth,
+	"This request has expired - `expires` is in the past.": exchange.ErrTimestamp,
+}
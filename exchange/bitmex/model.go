@@ -13,6 +13,24 @@ type ErrorResponse struct {
 	} `json:"error"`
 }
 
+type Margin struct {
+	Currency        string `json:"currency"`
+	WalletBalance   int64  `json:"walletBalance"`
+	AvailableMargin int64  `json:"availableMargin"`
+}
+
+type WithdrawResponse struct {
+	TransactID     string    `json:"transactID"`
+	Account        int64     `json:"account"`
+	Currency       string    `json:"currency"`
+	TransactType   string    `json:"transactType"`
+	Amount         int64     `json:"amount"`
+	Fee            int64     `json:"fee"`
+	TransactStatus string    `json:"transactStatus"`
+	Address        string    `json:"address"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
 type AccountBalances struct {
 	MakerCommission  int  `json:"makerCommission"`
 	TakerCommission  int  `json:"takerCommission"`
@@ -160,6 +160,7 @@ func (e *Bitpie) GetPairsData() error {
 			p = e.GetPairBySymbol(data.Name)
 		}
 		if p != nil {
+			minQty, _ := strconv.ParseFloat(data.OrderMinVol, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -168,6 +169,7 @@ func (e *Bitpie) GetPairsData() error {
 				TakerFee:    float64(data.TakerFeeRate),
 				LotSize:     math.Pow10(-1 * data.StockPrecision),
 				PriceFilter: math.Pow10(-1 * data.MoneyPrecision),
+				MinQty:      minQty,
 				Listed:      data.Enabled,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -343,6 +345,11 @@ func (e *Bitpie) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
 	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
@@ -380,6 +387,11 @@ func (e *Bitpie) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
 	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
@@ -492,12 +504,12 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bitpie) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
@@ -544,7 +556,7 @@ func (e *Bitpie) TestAuth() string {
 	// strUrl := "https://pieopen.getcai.com" + "/api/v1/open/third/party/login/query/" + "3ff92e9739dd91accaab394b16aea5d161887ca8b4899fc994b337328d889fdb"
 
 	// signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	// log.Printf("jsonParams: %+v\n strUrl: %v", jsonParams, strUrl)
 
@@ -153,6 +153,8 @@ func (e *Bitstamp) GetPairsData() error {
 			p = e.GetPairBySymbol(data.URLSymbol)
 		}
 		if p != nil {
+			// the minimum order is the amount in the counter coin, e.g. "10.0 USD"
+			minNotional, _ := strconv.ParseFloat(strings.Split(data.MinimumOrder, " ")[0], 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -161,6 +163,7 @@ func (e *Bitstamp) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * data.BaseDecimals),
 				PriceFilter: math.Pow10(-1 * data.CounterDecimals),
+				MinNotional: minNotional,
 				Listed:      true,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -278,12 +281,12 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bitstamp) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
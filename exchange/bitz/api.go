@@ -135,6 +135,8 @@ func (e *Bitz) GetPairsData() error {
 		if p != nil {
 			lotSize, _ := strconv.Atoi(data.NumberFloat)
 			priceFilter, _ := strconv.Atoi(data.PriceFloat)
+			minQty, _ := strconv.ParseFloat(data.MinTrade, 64)
+			maxQty, _ := strconv.ParseFloat(data.MaxTrade, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -143,6 +145,8 @@ func (e *Bitz) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(lotSize * -1),
 				PriceFilter: math.Pow10(priceFilter * -1),
+				MinQty:      minQty,
+				MaxQty:      maxQty,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -355,6 +359,11 @@ func (e *Bitz) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key, Secret Key or TradePassword are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/Trade/addEntrustSheet"
@@ -395,6 +404,11 @@ func (e *Bitz) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key, Secret Key or TradePassword are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/Trade/addEntrustSheet"
@@ -532,7 +546,7 @@ func (e *Bitz) ApiKeyPOST(mapParams map[string]string, strRequestPath string) st
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	request.Header.Add("Accept", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -50,14 +50,9 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestPath)*/
 func (e *Blocktrade) GetCoinsData() error {
-	coinsData := CoinsData{}
-
-	strRequestPath := "/api/v1/trading_assets"
-	strUrl := API_URL + strRequestPath
-
-	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
-		return fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	coinsData, err := e.getTradingAssets()
+	if err != nil {
+		return err
 	}
 
 	for _, data := range coinsData {
@@ -96,6 +91,20 @@ func (e *Blocktrade) GetCoinsData() error {
 	return nil
 }
 
+/*The trading assets, with the order value limits*/
+func (e *Blocktrade) getTradingAssets() (CoinsData, error) {
+	coinsData := CoinsData{}
+
+	strRequestPath := "/api/v1/trading_assets"
+	strUrl := API_URL + strRequestPath
+
+	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	}
+	return coinsData, nil
+}
+
 /* GetPairsData - Get Pairs Information (If API provide)
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -110,6 +119,15 @@ func (e *Blocktrade) GetPairsData() error {
 	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &pairsData); err != nil {
 		return fmt.Errorf("%s Get Pairs Json Unmarshal Err: %v %v", e.GetName(), err, jsonSymbolsReturn)
 	}
+	// the minimum order values are listed by the quote asset
+	assets, err := e.getTradingAssets()
+	if err != nil {
+		return err
+	}
+	minOrderValues := make(map[int]float64)
+	for _, data := range assets {
+		minOrderValues[data.ID], _ = strconv.ParseFloat(data.MinimalOrderValue, 64)
+	}
 
 	for _, data := range pairsData {
 		if data.Active {
@@ -144,6 +162,7 @@ func (e *Blocktrade) GetPairsData() error {
 					TakerFee:    DEFAULT_TAKER_FEE,
 					LotSize:     lotSize,
 					PriceFilter: priceFilter,
+					MinNotional: minOrderValues[data.QuoteAssetId],
 					Listed:      true,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -288,6 +307,11 @@ func (e *Blocktrade) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchan
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -326,6 +350,11 @@ func (e *Blocktrade) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchang
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -459,7 +488,7 @@ func (e *Blocktrade) ApiKeyGet(strRequestPath string, mapParams map[string]strin
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -494,7 +523,7 @@ func (e *Blocktrade) ApiKeyRequest(strMethod, strRequestPath string, mapParams m
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -139,6 +139,7 @@ func (e *Bw) GetPairsData() error {
 				p = e.GetPairBySymbol(data.Name)
 			}
 			if p != nil && data.State == 1 {
+				minQty, _ := strconv.ParseFloat(data.MinAmount, 64)
 				pairConstraint := &exchange.PairConstraint{
 					PairID:      p.ID,
 					Pair:        p,
@@ -147,6 +148,7 @@ func (e *Bw) GetPairsData() error {
 					TakerFee:    DEFAULT_TAKER_FEE,
 					LotSize:     math.Pow10(-1 * data.AmountDecimal),
 					PriceFilter: math.Pow10(-1 * data.PriceDecimal),
+					MinQty:      minQty,
 					Listed:      data.State == 1,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -285,19 +287,21 @@ func (e *Bw) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Order
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["quantity"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -326,19 +330,21 @@ func (e *Bw) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order,
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["quantity"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -462,7 +468,7 @@ func (e *Bw) ApiKeyGet(strRequestPath string, mapParams map[string]string) strin
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -497,7 +503,7 @@ func (e *Bw) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[strin
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
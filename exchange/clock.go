@@ -0,0 +1,175 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	CLOCK_SAMPLES         = 3 // server times read by a Sync, the one with the shortest round trip is kept
+	DEFAULT_SYNC_INTERVAL = 10 * time.Minute
+)
+
+// ServerTime reads the clock of the exchange server.
+type ServerTime func() (time.Time, error)
+
+// ClockStatus is the last estimate of the offset of the server clock.
+type ClockStatus struct {
+	ExName    ExchangeName
+	Offset    time.Duration // of the server clock from the local clock
+	RTT       time.Duration // round trip of the server time request
+	LastSync  time.Time
+	LastError error
+}
+
+// Clock is the server clock of one exchange as seen from here: the local clock
+// corrected by the offset estimated by Sync. The signed requests take their
+// timestamps from Now, so they stay within the receive window of the server
+// whatever the drift of the local clock.
+type Clock struct {
+	exName     ExchangeName
+	serverTime ServerTime
+
+	mutex  sync.RWMutex
+	status ClockStatus
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+var clocks = make(map[ExchangeName]*Clock)
+var clockMutex sync.Mutex
+
+// SetServerTime registers the server time endpoint of the exchange, the
+// adapters with timestamped signatures call it when created. The offset
+// estimated so far is kept.
+func SetServerTime(name ExchangeName, serverTime ServerTime) *Clock {
+	c := GetClock(name)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.serverTime = serverTime
+	return c
+}
+
+// GetClock returns the clock of the exchange. Until it is synced, or if the
+// exchange has no server time endpoint, it is the local clock.
+func GetClock(name ExchangeName) *Clock {
+	clockMutex.Lock()
+	defer clockMutex.Unlock()
+	c, ok := clocks[name]
+	if !ok {
+		c = &Clock{exName: name, status: ClockStatus{ExName: name}}
+		clocks[name] = c
+	}
+	return c
+}
+
+// Now is the local time corrected by the offset of the server clock.
+func (c *Clock) Now() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return time.Now().Add(c.status.Offset)
+}
+
+// Sync reads the server time CLOCK_SAMPLES times and estimates the offset
+// from the sample with the shortest round trip, the server time being taken
+// at its middle. The offset is kept when all the reads fail.
+func (c *Clock) Sync() error {
+	c.mutex.RLock()
+	serverTime := c.serverTime
+	c.mutex.RUnlock()
+	if serverTime == nil {
+		return fmt.Errorf("%s has no server time endpoint.", c.exName)
+	}
+
+	var offset, rtt time.Duration
+	var err error
+	synced := false
+	for i := 0; i < CLOCK_SAMPLES; i++ {
+		start := time.Now()
+		server, sampleErr := serverTime()
+		end := time.Now()
+		if sampleErr != nil {
+			err = sampleErr
+			continue
+		}
+		if sampleRTT := end.Sub(start); !synced || sampleRTT < rtt {
+			rtt = sampleRTT
+			offset = server.Sub(start.Add(sampleRTT / 2))
+			synced = true
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !synced {
+		c.status.LastError = fmt.Errorf("%s Sync Clock Err: %v", c.exName, err)
+		return c.status.LastError
+	}
+	c.status.Offset = offset
+	c.status.RTT = rtt
+	c.status.LastSync = time.Now()
+	c.status.LastError = nil
+	return nil
+}
+
+// Start syncs the clock now, before it returns, and then on every interval,
+// DEFAULT_SYNC_INTERVAL if 0. Calling Start on a started Clock does nothing.
+// The keyed adapters start it when created, whatever the data source.
+func (c *Clock) Start(interval time.Duration) {
+	if interval <= 0 {
+		interval = DEFAULT_SYNC_INTERVAL
+	}
+	c.mutex.Lock()
+	if c.stop != nil {
+		c.mutex.Unlock()
+		return
+	}
+	c.stop = make(chan struct{})
+	stop := c.stop
+	c.mutex.Unlock()
+
+	if err := c.Sync(); err != nil {
+		log.Printf("%v", err)
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if err := c.Sync(); err != nil {
+				log.Printf("%v", err)
+			}
+		}
+	}()
+}
+
+// Stop ends the timed syncs and waits for the running one to finish.
+func (c *Clock) Stop() {
+	c.mutex.Lock()
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+	c.mutex.Unlock()
+
+	c.wg.Wait()
+}
+
+// Status returns a copy of the last estimate.
+func (c *Clock) Status() ClockStatus {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.status
+}
@@ -159,6 +159,7 @@ func (e *Coinex) GetPairsData() error {
 		if p != nil {
 			makerFee, _ := strconv.ParseFloat(data.MakerFeeRate, 64)
 			takerFee, _ := strconv.ParseFloat(data.TakerFeeRate, 64)
+			minQty, _ := strconv.ParseFloat(data.MinAmount, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -167,6 +168,7 @@ func (e *Coinex) GetPairsData() error {
 				TakerFee:    takerFee,
 				LotSize:     math.Pow10(-1 * data.TradingDecimal),
 				PriceFilter: math.Pow10(-1 * data.PricingDecimal),
+				MinQty:      minQty,
 				Listed:      true,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -392,20 +394,22 @@ func (e *Coinex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 
 	strRequest := "/v1/order/limit"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["access_id"] = e.API_KEY
 	mapParams["market"] = e.GetSymbolByPair(pair)
 	mapParams["type"] = "sell"
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -434,20 +438,22 @@ func (e *Coinex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 
 	strRequest := "/v1/order/limit"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["access_id"] = e.API_KEY
 	mapParams["market"] = e.GetSymbolByPair(pair)
 	mapParams["type"] = "buy"
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -574,7 +580,7 @@ func (e *Coinex) ApiKeyRequest(strMethod string, strRequestPath string, mapParam
 	signature := fmt.Sprintf("%s&secret_key=%s", exchange.Map2UrlQuery(mapParams), e.API_SECRET)
 
 	// 构建Request, 并且按官方要求添加Http Header
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	request, err := http.NewRequest(strMethod, strRequestUrl, nil)
 	if nil != err {
 		return err.Error()
@@ -615,7 +621,7 @@ func (e *Coinex) ApiKeyPost(strRequestPath string, mapParams map[string]string)
 	signature := fmt.Sprintf("%s&secret_key=%s", exchange.Map2UrlQuery(mapParams), e.API_SECRET)
 
 	// 构建Request, 并且按官方要求添加Http Header
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	request, err := http.NewRequest("POST", strUrl, strings.NewReader(jsonParams))
 	if nil != err {
 		return err.Error()
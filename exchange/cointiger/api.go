@@ -177,6 +177,7 @@ func (e *Cointiger) GetPairsData() error {
 					TakerFee:    DEFAULT_TAKER_FEE,
 					LotSize:     math.Pow10(-1 * data.AmountPrecision),
 					PriceFilter: priceFilter, //math.Pow10(-1 * data.PricePrecision),  // orderbook precision
+					MinQty:      data.AmountMin,
 					Listed:      DEFAULT_LISTED,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -307,6 +308,11 @@ func (e *Cointiger) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchang
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/order"
@@ -349,6 +355,11 @@ func (e *Cointiger) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/order"
@@ -493,7 +504,7 @@ func (e *Cointiger) ApiKeyGet(strRequestPath string, mapParams map[string]interf
 	request.Header.Add("User-Agent", "Mozilla/5.0(Macintosh;U;IntelMacOSX10_6_8;en-us)AppleWebKit/534.50(KHTML,likeGecko)Version/5.1Safari/534.50")
 	request.Header.Add("Referer", "https://api.cointiger.com")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -529,7 +540,7 @@ func (e *Cointiger) ApiKeyRequest(strMethod, strRequestPath string, mapParams ma
 	query := exchange.Map2UrlQueryInterface(mapParams)
 	values, err := url.ParseQuery(query)
 
-	request, err := http.PostForm(strUrl, values)
+	request, err := exchange.NewHttpClient().PostForm(strUrl, values)
 	if nil != err {
 		return err.Error()
 	}
@@ -0,0 +1,296 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	cmap "github.com/orcaman/concurrent-map"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/pair"
+)
+
+// Constraints holds the coin and pair constraints of an exchange adapter.
+// Fetch fills a Stage by the fetch callbacks of the adapter and swaps it in once
+// they all succeeded, the readers keep the current constraints meanwhile.
+// The writes wait for a running Fetch and go to the swapped in constraints.
+// The changes, by a swap or a write, are published to the ExchangeManager
+// subscribers once the exchange is added. A nil Constraints reads as empty.
+type Constraints struct {
+	name   ExchangeName
+	parent *Constraints // the live constraints of a stage, for the symbol lookups
+
+	write sync.Mutex   // held by Fetch and the writes
+	mutex sync.RWMutex // guards the maps
+	coins cmap.ConcurrentMap
+	pairs cmap.ConcurrentMap
+}
+
+func NewConstraints(name ExchangeName) *Constraints {
+	return &Constraints{
+		name:  name,
+		coins: cmap.New(),
+		pairs: cmap.New(),
+	}
+}
+
+// Stage returns empty constraints for Fetch. Its symbol lookups fall back to c,
+// for the adapters resolving the symbols from the loaded data.
+func (c *Constraints) Stage() *Constraints {
+	stage := NewConstraints(c.name)
+	stage.parent = c
+	return stage
+}
+
+// Fetch runs the fetches, which fill the stage, and swaps the stage in when
+// they all succeeded and a pair is fetched. Constraints missing in the stage
+// are dropped.
+func (c *Constraints) Fetch(stage *Constraints, fetches ...func() error) error {
+	c.write.Lock()
+	for _, fetch := range fetches {
+		if err := fetch(); err != nil {
+			c.write.Unlock()
+			return err
+		}
+	}
+	coins, pairs := stage.maps()
+	if pairs.Count() == 0 {
+		c.write.Unlock()
+		return fmt.Errorf("%s Update Constraint: no pair is fetched.", c.name)
+	}
+	events := c.swap(coins, pairs)
+	c.write.Unlock()
+	c.publish(events)
+	return nil
+}
+
+// Load replaces the constraints by the loaded ones. A loaded pair without
+// limits keeps the limits of the current pair, fetched from the exchange API.
+func (c *Constraints) Load(coins, pairs cmap.ConcurrentMap) {
+	if coins == nil {
+		coins = cmap.New()
+	}
+	if pairs == nil {
+		pairs = cmap.New()
+	}
+	c.write.Lock()
+	c.keepLimits(pairs)
+	events := c.swap(coins, pairs)
+	c.write.Unlock()
+	c.publish(events)
+}
+
+// keepLimits sets the limits of the current pairs, or of the live pairs for a
+// stage, to the loaded pairs which have none.
+func (c *Constraints) keepLimits(pairs cmap.ConcurrentMap) {
+	current := c
+	if c.parent != nil {
+		current = c.parent
+	}
+	_, currentPairs := current.maps()
+	for key, tmp := range pairs.Items() {
+		loaded := tmp.(*PairConstraint)
+		if loaded.MinQty != 0 || loaded.MaxQty != 0 || loaded.MinNotional != 0 || loaded.MaxOrders != 0 {
+			continue
+		}
+		if tmp, ok := currentPairs.Get(key); ok {
+			fetched := tmp.(*PairConstraint)
+			update := *loaded
+			update.MinQty, update.MaxQty = fetched.MinQty, fetched.MaxQty
+			update.MinNotional, update.MaxOrders = fetched.MinNotional, fetched.MaxOrders
+			pairs.Set(key, &update)
+		}
+	}
+}
+
+// swap replaces the maps and returns the changes, none on the first load.
+func (c *Constraints) swap(coins, pairs cmap.ConcurrentMap) []*Event {
+	c.mutex.Lock()
+	old := c.snapshot(c.coins, c.pairs)
+	c.coins = coins
+	c.pairs = pairs
+	c.mutex.Unlock()
+
+	if len(old.Coins) == 0 && len(old.Pairs) == 0 {
+		return nil
+	}
+	return old.Events(c.snapshot(coins, pairs))
+}
+
+func (c *Constraints) snapshot(coins, pairs cmap.ConcurrentMap) *ConstraintSnapshot {
+	snapshot := newSnapshot(c.name)
+	for _, tmp := range coins.Items() {
+		snapshot.addCoin(tmp.(*CoinConstraint))
+	}
+	for _, tmp := range pairs.Items() {
+		snapshot.addPair(tmp.(*PairConstraint))
+	}
+	return snapshot
+}
+
+// publish sends the events unless c is a stage, its changes are published by the swap.
+func (c *Constraints) publish(events []*Event) {
+	if c.parent == nil {
+		publish(c.name, events)
+	}
+}
+
+func (c *Constraints) maps() (cmap.ConcurrentMap, cmap.ConcurrentMap) {
+	if c == nil {
+		return cmap.New(), cmap.New()
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.coins, c.pairs
+}
+
+/*************** Coins ***************/
+func (c *Constraints) GetCoin(coin *coin.Coin) *CoinConstraint {
+	coins, _ := c.maps()
+	if tmp, ok := coins.Get(fmt.Sprintf("%d", coin.ID)); ok {
+		return tmp.(*CoinConstraint)
+	}
+	return nil
+}
+
+func (c *Constraints) SetCoin(coinConstraint *CoinConstraint) {
+	c.write.Lock()
+	coins, _ := c.maps()
+	old, updated := newSnapshot(c.name), newSnapshot(c.name)
+	if tmp, ok := coins.Get(fmt.Sprintf("%d", coinConstraint.CoinID)); ok {
+		old.addCoin(tmp.(*CoinConstraint))
+	}
+	coins.Set(fmt.Sprintf("%d", coinConstraint.CoinID), coinConstraint)
+	updated.addCoin(coinConstraint)
+	c.write.Unlock()
+	c.publish(old.Events(updated))
+}
+
+func (c *Constraints) DeleteCoin(coin *coin.Coin) {
+	c.write.Lock()
+	defer c.write.Unlock()
+	coins, _ := c.maps()
+	coins.Remove(fmt.Sprintf("%d", coin.ID))
+}
+
+// Coins lists the coins ordered by ID.
+func (c *Constraints) Coins() []*coin.Coin {
+	coins, _ := c.maps()
+	coinList := []*coin.Coin{}
+	for _, id := range sortedIDs(coins) {
+		if cn := coin.GetCoinByID(id); cn != nil {
+			coinList = append(coinList, cn)
+		}
+	}
+	return coinList
+}
+
+func (c *Constraints) CoinBySymbol(symbol string) *coin.Coin {
+	coins, _ := c.maps()
+	for _, tmp := range coins.Items() {
+		if cc := tmp.(*CoinConstraint); cc.ExSymbol == symbol {
+			return cc.Coin
+		}
+	}
+	if c != nil && c.parent != nil {
+		return c.parent.CoinBySymbol(symbol)
+	}
+	return nil
+}
+
+/*************** Pairs ***************/
+func (c *Constraints) GetPair(pair *pair.Pair) *PairConstraint {
+	_, pairs := c.maps()
+	if tmp, ok := pairs.Get(fmt.Sprintf("%d", pair.ID)); ok {
+		return tmp.(*PairConstraint)
+	}
+	return nil
+}
+
+func (c *Constraints) SetPair(pairConstraint *PairConstraint) {
+	c.write.Lock()
+	_, pairs := c.maps()
+	old, updated := newSnapshot(c.name), newSnapshot(c.name)
+	if tmp, ok := pairs.Get(fmt.Sprintf("%d", pairConstraint.PairID)); ok {
+		old.addPair(tmp.(*PairConstraint))
+	}
+	pairs.Set(fmt.Sprintf("%d", pairConstraint.PairID), pairConstraint)
+	updated.addPair(pairConstraint)
+	c.write.Unlock()
+	c.publish(old.Events(updated))
+}
+
+func (c *Constraints) DeletePair(pair *pair.Pair) {
+	c.write.Lock()
+	_, pairs := c.maps()
+	old := newSnapshot(c.name)
+	if tmp, ok := pairs.Get(fmt.Sprintf("%d", pair.ID)); ok {
+		old.addPair(tmp.(*PairConstraint))
+	}
+	pairs.Remove(fmt.Sprintf("%d", pair.ID))
+	c.write.Unlock()
+	c.publish(old.Events(newSnapshot(c.name)))
+}
+
+func (c *Constraints) HasPair(pair *pair.Pair) bool {
+	_, pairs := c.maps()
+	return pairs.Has(fmt.Sprintf("%d", pair.ID))
+}
+
+// Pairs lists the pairs ordered by ID.
+func (c *Constraints) Pairs() []*pair.Pair {
+	_, pairs := c.maps()
+	pairList := []*pair.Pair{}
+	for _, id := range sortedIDs(pairs) {
+		if p := pair.GetPairByID(id); p != nil {
+			pairList = append(pairList, p)
+		}
+	}
+	return pairList
+}
+
+func (c *Constraints) PairBySymbol(symbol string) *pair.Pair {
+	_, pairs := c.maps()
+	for _, tmp := range pairs.Items() {
+		if pc := tmp.(*PairConstraint); pc.ExSymbol == symbol {
+			return pc.Pair
+		}
+	}
+	if c != nil && c.parent != nil {
+		return c.parent.PairBySymbol(symbol)
+	}
+	return nil
+}
+
+func sortedIDs(m cmap.ConcurrentMap) []int {
+	ids := []int{}
+	for _, key := range m.Keys() {
+		id, _ := strconv.Atoi(key)
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+/*************** Fees ***************/
+// MakerFee is the maker fee of the pair on the exchange, 0 if it is not listed.
+func MakerFee(e Exchange, p *pair.Pair) float64 {
+	if pairConstraint := e.GetPairConstraint(p); pairConstraint != nil {
+		return pairConstraint.MakerFee
+	}
+	return 0.0
+}
+
+// TakerFee is the taker fee of the pair on the exchange, 0 if it is not listed.
+func TakerFee(e Exchange, p *pair.Pair) float64 {
+	if pairConstraint := e.GetPairConstraint(p); pairConstraint != nil {
+		return pairConstraint.TakerFee
+	}
+	return 0.0
+}
@@ -0,0 +1,135 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type Permission string
+
+const (
+	READ     Permission = "Read"
+	TRADE    Permission = "Trade"
+	WITHDRAW Permission = "Withdraw"
+	MARGIN   Permission = "Margin"
+	FUTURES  Permission = "Futures"
+)
+
+// EXPIRE_WARNING is how long before the API key expires CheckCredentials warns.
+const EXPIRE_WARNING = 30 * 24 * time.Hour
+
+// Credentials are the rights of an API key. Permissions only lists the ones
+// known, as reported by the venue or found by ProbeCredentials.
+type Credentials struct {
+	ExName       ExchangeName
+	Permissions  map[Permission]bool
+	IPRestricted bool
+	IPs          []string // the IP whitelist, when the venue lists it
+	ExpireTS     int64    // unix seconds, 0 if the key does not expire or the expiry is unknown
+	Probed       bool     // found by ProbeCredentials, not reported by the venue
+}
+
+// CredentialsChecker is implemented by the adapters of the venues reporting the
+// permissions of the API key.
+type CredentialsChecker interface {
+	CheckCredentials() (*Credentials, error)
+}
+
+func NewCredentials(exName ExchangeName) *Credentials {
+	return &Credentials{
+		ExName:      exName,
+		Permissions: make(map[Permission]bool),
+	}
+}
+
+// Can is true if the key is known to have the permission.
+func (c *Credentials) Can(permission Permission) bool {
+	return c.Permissions[permission]
+}
+
+// Known is true if the key is known to have the permission or not.
+func (c *Credentials) Known(permission Permission) bool {
+	_, ok := c.Permissions[permission]
+	return ok
+}
+
+// ExpiresIn is the time left before the key expires, false if it does not.
+func (c *Credentials) ExpiresIn(now time.Time) (time.Duration, bool) {
+	if c.ExpireTS == 0 {
+		return 0, false
+	}
+	return time.Unix(c.ExpireTS, 0).Sub(now), true
+}
+
+// CheckCredentials gets the permissions of the API key of the exchange from the
+// venue, or probes them when it does not report them. Config.ExpireTS is the
+// expiry when the venue does not tell it. It warns when the key expires within
+// EXPIRE_WARNING, and fails with ErrAuth once it has expired.
+func CheckCredentials(e Exchange, config *Config) (*Credentials, error) {
+	var credentials *Credentials
+	if checker, ok := e.(CredentialsChecker); ok {
+		var err error
+		if credentials, err = checker.CheckCredentials(); err != nil {
+			return nil, err
+		}
+	} else {
+		credentials = ProbeCredentials(e)
+	}
+
+	if credentials.ExpireTS == 0 && config != nil {
+		credentials.ExpireTS = config.ExpireTS
+	}
+	if left, ok := credentials.ExpiresIn(time.Now()); ok {
+		if left <= 0 {
+			return credentials, fmt.Errorf("%s API Key expired at %v: %w", e.GetName(), time.Unix(credentials.ExpireTS, 0).UTC(), ErrAuth)
+		} else if left <= EXPIRE_WARNING {
+			log.Printf("%s API Key will be expired in %d days", e.GetName(), int64(left/(24*time.Hour)))
+		}
+	}
+	return credentials, nil
+}
+
+// ProbeCredentials finds the Read permission of the venues that do not report
+// the permissions, by the read-only calls only: the key can read if balances
+// are listed, and can not if the balances or the open orders are rejected with
+// ErrAuth. Other failures leave it unknown, as the adapters accept the
+// operations they do not implement. Trade, Withdraw, Margin and Futures are
+// not probed, no read-only call tells them.
+func ProbeCredentials(e Exchange) *Credentials {
+	credentials := NewCredentials(e.GetName())
+	credentials.Probed = true
+
+	operation := &AccountOperation{Type: BalanceList, Ex: e.GetName()}
+	err := probe(func() error { return e.DoAccoutOperation(operation) })
+	if err == nil && len(operation.BalanceList) > 0 {
+		credentials.Permissions[READ] = true
+		return credentials
+	} else if errors.Is(err, ErrAuth) {
+		credentials.Permissions[READ] = false
+		return credentials
+	}
+
+	if errors.Is(probe(func() error {
+		_, err := e.ListOrders()
+		return err
+	}), ErrAuth) {
+		credentials.Permissions[READ] = false
+	}
+	return credentials
+}
+
+// probe calls the adapter, a panic on the unexpected reply is a failure.
+func probe(call func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("probe panic: %v", r)
+		}
+	}()
+	return call()
+}
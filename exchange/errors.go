@@ -0,0 +1,70 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of the API errors, the adapters map their native error codes to
+// them. Check them with errors.Is, e.g. errors.Is(err, ErrInsufficientFunds).
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrOrderNotFound     = errors.New("order not found or not open")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrAuth              = errors.New("authentication failed")
+	ErrTimestamp         = errors.New("timestamp outside of the receive window")
+	ErrInvalidSymbol     = errors.New("invalid symbol")
+	ErrInvalidPrecision  = errors.New("invalid price or quantity precision")
+	ErrOrderLimit        = errors.New("order below the minimum or above the maximum")
+	ErrMaintenance       = errors.New("exchange under maintenance")
+)
+
+// ApiError is an error returned by the exchange API, with the native code and
+// message, and the raw payload. Err is the kind of the error, nil if the code
+// is not mapped.
+type ApiError struct {
+	ExName  ExchangeName
+	Op      string // the adapter method, e.g. "LimitBuy"
+	Code    string
+	Message string
+	Payload string
+	Err     error
+}
+
+func NewApiError(exName ExchangeName, op string, code interface{}, message, payload string, err error) *ApiError {
+	return &ApiError{
+		ExName:  exName,
+		Op:      op,
+		Code:    fmt.Sprintf("%v", code),
+		Message: message,
+		Payload: payload,
+		Err:     err,
+	}
+}
+
+func (err *ApiError) Error() string {
+	return fmt.Sprintf("%s %s failed:%v Message:%v", err.ExName, err.Op, err.Code, err.Message)
+}
+
+func (err *ApiError) Unwrap() error {
+	return err.Err
+}
+
+// Is maps the order rejected by ValidateOrder to the kinds of the API errors.
+func (err *OrderError) Is(target error) bool {
+	switch err.Type {
+	case INVALID_PAIR:
+		return target == ErrInvalidSymbol
+	case INVALID_PRICE:
+		return target == ErrInvalidPrecision
+	case MIN_QUANTITY, MAX_QUANTITY, MIN_NOTIONAL:
+		return target == ErrOrderLimit
+	case INSUFFICIENT_BALANCE:
+		return target == ErrInsufficientFunds
+	}
+	return false
+}
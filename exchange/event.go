@@ -0,0 +1,232 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/pair"
+)
+
+type EventType string
+
+const (
+	PAIR_LISTED       EventType = "PAIR_LISTED"
+	PAIR_DELISTED     EventType = "PAIR_DELISTED"
+	WITHDRAW_DISABLED EventType = "WITHDRAW_DISABLED"
+	WITHDRAW_ENABLED  EventType = "WITHDRAW_ENABLED"
+	DEPOSIT_DISABLED  EventType = "DEPOSIT_DISABLED"
+	DEPOSIT_ENABLED   EventType = "DEPOSIT_ENABLED"
+	TX_FEE_CHANGED    EventType = "TX_FEE_CHANGED"    // withdraw fee of a coin
+	TRADE_FEE_CHANGED EventType = "TRADE_FEE_CHANGED" // taker fee of a pair
+)
+
+// Event is a change of an exchange's constraints found by a data refresh.
+// Coin is set for the wallet and TX_FEE_CHANGED events, Pair for the others.
+// Old and New are only set for the fee changes.
+type Event struct {
+	Type   EventType
+	ExName ExchangeName
+	Coin   *coin.Coin
+	Pair   *pair.Pair
+	Old    float64
+	New    float64
+	Time   time.Time
+}
+
+func (ev *Event) String() string {
+	name := ""
+	if ev.Coin != nil {
+		name = ev.Coin.Code
+	} else if ev.Pair != nil {
+		name = ev.Pair.Name
+	}
+	switch ev.Type {
+	case TX_FEE_CHANGED, TRADE_FEE_CHANGED:
+		return fmt.Sprintf("%s %s %s: %v -> %v", ev.ExName, ev.Type, name, ev.Old, ev.New)
+	default:
+		return fmt.Sprintf("%s %s %s", ev.ExName, ev.Type, name)
+	}
+}
+
+type EventHandler func(event *Event)
+
+// Subscribe registers the handler for the events of the exchanges added to the
+// ExchangeManager and returns the id for Unsubscribe. Handlers are called in the
+// goroutine of the change, they should return quickly.
+func (e *ExchangeManager) Subscribe(handler EventHandler) int {
+	e.subscriberMutex.Lock()
+	defer e.subscriberMutex.Unlock()
+	e.subscriberID++
+	e.subscribers[e.subscriberID] = handler
+	return e.subscriberID
+}
+
+// SubscribeChan delivers the events to a channel with the buffer size.
+// Events are dropped when the buffer is full.
+func (e *ExchangeManager) SubscribeChan(size int) (<-chan *Event, int) {
+	events := make(chan *Event, size)
+	id := e.Subscribe(func(event *Event) {
+		select {
+		case events <- event:
+		default:
+			log.Printf("Event channel is full, drop event: %v", event)
+		}
+	})
+	return events, id
+}
+
+func (e *ExchangeManager) Unsubscribe(id int) {
+	e.subscriberMutex.Lock()
+	defer e.subscriberMutex.Unlock()
+	delete(e.subscribers, id)
+}
+
+// Publish sends the events to every subscriber. The handlers are called
+// without the lock, they may Subscribe or Unsubscribe.
+func (e *ExchangeManager) Publish(events []*Event) {
+	e.subscriberMutex.RLock()
+	handlers := make([]EventHandler, 0, len(e.subscribers))
+	for _, handler := range e.subscribers {
+		handlers = append(handlers, handler)
+	}
+	e.subscriberMutex.RUnlock()
+
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+// publish sends the changes of the exchange's constraints to the ExchangeManager
+// when the exchange is added to it.
+func publish(name ExchangeName, events []*Event) {
+	if len(events) == 0 || instance == nil || instance.Get(name) == nil {
+		return
+	}
+	instance.Publish(events)
+}
+
+// ConstraintSnapshot is a copy of an exchange's constraints, used to find the
+// changes made by a refresh.
+type ConstraintSnapshot struct {
+	ExName ExchangeName
+	Coins  map[int]CoinConstraint
+	Pairs  map[int]PairConstraint
+}
+
+func TakeSnapshot(ex Exchange) *ConstraintSnapshot {
+	snapshot := newSnapshot(ex.GetName())
+	for _, c := range ex.GetCoins() {
+		if cc := ex.GetCoinConstraint(c); cc != nil {
+			snapshot.addCoin(cc)
+		}
+	}
+	for _, p := range ex.GetPairs() {
+		if pc := ex.GetPairConstraint(p); pc != nil {
+			snapshot.addPair(pc)
+		}
+	}
+	return snapshot
+}
+
+func newSnapshot(name ExchangeName) *ConstraintSnapshot {
+	return &ConstraintSnapshot{
+		ExName: name,
+		Coins:  make(map[int]CoinConstraint),
+		Pairs:  make(map[int]PairConstraint),
+	}
+}
+
+func (s *ConstraintSnapshot) addCoin(cc *CoinConstraint) {
+	if c := coin.GetCoinByID(cc.CoinID); c != nil {
+		copied := *cc
+		copied.Coin = c
+		s.Coins[c.ID] = copied
+	}
+}
+
+func (s *ConstraintSnapshot) addPair(pc *PairConstraint) {
+	if p := pair.GetPairByID(pc.PairID); p != nil {
+		copied := *pc
+		copied.Pair = p
+		s.Pairs[p.ID] = copied
+	}
+}
+
+// Events lists the changes from the snapshot to the newer one, ordered by
+// coin ID then pair ID.
+func (s *ConstraintSnapshot) Events(newer *ConstraintSnapshot) []*Event {
+	events := []*Event{}
+	now := time.Now()
+
+	coinIDs := []int{}
+	for id := range newer.Coins {
+		if _, ok := s.Coins[id]; ok {
+			coinIDs = append(coinIDs, id)
+		}
+	}
+	sort.Ints(coinIDs)
+	for _, id := range coinIDs {
+		o, n := s.Coins[id], newer.Coins[id]
+		event := Event{ExName: newer.ExName, Coin: n.Coin, Time: now}
+		if o.Withdraw && !n.Withdraw {
+			events = append(events, event.with(WITHDRAW_DISABLED, 0, 0))
+		} else if !o.Withdraw && n.Withdraw {
+			events = append(events, event.with(WITHDRAW_ENABLED, 0, 0))
+		}
+		if o.Deposit && !n.Deposit {
+			events = append(events, event.with(DEPOSIT_DISABLED, 0, 0))
+		} else if !o.Deposit && n.Deposit {
+			events = append(events, event.with(DEPOSIT_ENABLED, 0, 0))
+		}
+		if o.TxFee != n.TxFee {
+			events = append(events, event.with(TX_FEE_CHANGED, o.TxFee, n.TxFee))
+		}
+	}
+
+	pairIDs := []int{}
+	for id := range s.Pairs {
+		pairIDs = append(pairIDs, id)
+	}
+	for id := range newer.Pairs {
+		if _, ok := s.Pairs[id]; !ok {
+			pairIDs = append(pairIDs, id)
+		}
+	}
+	sort.Ints(pairIDs)
+	for _, id := range pairIDs {
+		o, oldOK := s.Pairs[id]
+		n, newOK := newer.Pairs[id]
+		oldListed := oldOK && o.Listed
+		newListed := newOK && n.Listed
+		event := Event{ExName: newer.ExName, Pair: n.Pair, Time: now}
+		if !newOK {
+			event.Pair = o.Pair
+		}
+		switch {
+		case !oldListed && newListed:
+			events = append(events, event.with(PAIR_LISTED, 0, 0))
+		case oldListed && !newListed:
+			events = append(events, event.with(PAIR_DELISTED, 0, 0))
+		case oldListed && newListed && o.TakerFee != n.TakerFee:
+			events = append(events, event.with(TRADE_FEE_CHANGED, o.TakerFee, n.TakerFee))
+		}
+	}
+
+	return events
+}
+
+func (ev Event) with(eventType EventType, old, new float64) *Event {
+	ev.Type = eventType
+	ev.Old = old
+	ev.New = new
+	return &ev
+}
@@ -0,0 +1,47 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/pair"
+)
+
+// The formats below are the decimal boundary of the float64 models, see Order.
+
+// FormatRate rounds the rate to the nearest multiple of the pair's price filter
+// and formats it with the decimal places of the filter, e.g. 0.5 for 0.02000.
+// The float is converted exactly, without the artefacts of math.Log10 and
+// strconv rounding.
+func FormatRate(e Exchange, p *pair.Pair, rate float64) string {
+	return FormatToStep(rate, e.GetPriceFilter(p), decimal.Decimal.Round)
+}
+
+// FormatQuantity floors the quantity to a multiple of the pair's lot size, so
+// the order never needs more than the balance, and formats it with the decimal
+// places of the lot size.
+func FormatQuantity(e Exchange, p *pair.Pair, quantity float64) string {
+	return FormatToStep(quantity, e.GetLotSize(p), decimal.Decimal.Floor)
+}
+
+// FormatToStep snaps the value to a multiple of the step with Decimal.Floor,
+// Ceil or Round, and formats it with the decimal places of the step. A value
+// without a step is formatted as is.
+func FormatToStep(value, step float64, snap func(value, step decimal.Decimal) decimal.Decimal) string {
+	if step <= 0 {
+		return decimal.NewFromFloat(value).String()
+	}
+	stepDecimal := decimal.NewFromFloat(step)
+	return snap(decimal.NewFromFloat(value), stepDecimal).StringFixed(stepDecimal.Places())
+}
+
+// SnapToStep snaps the value to a multiple of the step like FormatToStep, for
+// the float64 models. A value without a step is returned as is.
+func SnapToStep(value, step float64, snap func(value, step decimal.Decimal) decimal.Decimal) float64 {
+	if step <= 0 {
+		return value
+	}
+	return snap(decimal.NewFromFloat(value), decimal.NewFromFloat(step)).Float64()
+}
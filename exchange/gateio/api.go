@@ -170,6 +170,8 @@ func (e *Gateio) GetPairsData() error {
 					TakerFee:    data.Fee / 100,
 					LotSize:     DEFAULT_LOT_SIZE,
 					PriceFilter: priceFilter,
+					MinQty:      data.MinAmountA,
+					MinNotional: data.MinAmountB,
 					Listed:      DEFAULT_LISTED,
 				}
 				e.SetPairConstraint(pairConstraint)
@@ -295,6 +297,11 @@ func (e *Gateio) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api2/1/private/sell"
 
@@ -329,6 +336,11 @@ func (e *Gateio) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api2/1/private/buy"
 
@@ -439,7 +451,7 @@ func (e *Gateio) ApiKeyPost(strRequestPath string, mapParams map[string]string)
 
 	strUrl := Private_URL + strRequestPath
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest(strMethod, strUrl, strings.NewReader(payload))
 	if nil != err {
@@ -159,6 +159,7 @@ func (e *Gemini) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     data.MinOrderIncre,
 				PriceFilter: data.MinPriceIncre,
+				MinQty:      data.MinOrderSize,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -293,6 +294,11 @@ func (e *Gemini) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
+
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
 	sellorder := PlaceOrder{}
 	strRequest := "/v1/order/new"
 
@@ -326,6 +332,11 @@ func (e *Gemini) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
+
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
 	buyorder := PlaceOrder{}
 	strRequest := "/v1/order/new"
 
@@ -424,7 +435,7 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Gemini) ApiKeyRequest(strMethod string, strRequestPath string, mapParams map[string]interface{}) string {
-	mapParams["nonce"] = /* fmt.Sprintf("%d",  */ time.Now().UnixNano() //)
+	mapParams["nonce"] = exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next()
 
 	strUrl := API_URL + strRequestPath
 
@@ -448,7 +459,7 @@ func (e *Gemini) ApiKeyRequest(strMethod string, strRequestPath string, mapParam
 	request.Header.Add("X-GEMINI-SIGNATURE", signature)
 	request.Header.Add("Cache-Control", "no-cache")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -413,6 +413,11 @@ func (e *Hitbtc) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	errResponse := ErrResponse{}
 	strRequest := "/api/2/order"
@@ -450,6 +455,11 @@ func (e *Hitbtc) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	errResponse := ErrResponse{}
 	strRequest := "/api/2/order"
@@ -573,7 +583,7 @@ func (e *Hitbtc) ApiKeyRequest(strMethod string, mapParams map[string]string, st
 	request.Header.Add("Accept", "application/json")
 	request.SetBasicAuth(e.API_KEY, e.API_SECRET)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 func HttpGetRequest(strUrl string, mapParams map[string]string) string {
-	httpClient := &http.Client{}
+	httpClient := NewHttpClient()
 
 	var strRequestUrl string
 	if nil == mapParams {
@@ -54,7 +54,7 @@ func HttpGetRequest(strUrl string, mapParams map[string]string) string {
 }
 
 func HttpPostRequest(strUrl string, mapParams map[string]string) string {
-	httpClient := &http.Client{}
+	httpClient := NewHttpClient()
 
 	jsonParams := ""
 	if nil != mapParams {
@@ -216,7 +216,7 @@ func Map2UrlQueryInterface(mapParams map[string]interface{}) string {
 }
 
 func GetExternalIP() string {
-	httpClient := &http.Client{}
+	httpClient := NewHttpClient()
 
 	strRequestUrl := "http://myexternalip.com/raw"
 
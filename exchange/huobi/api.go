@@ -151,6 +151,9 @@ func (e *Huobi) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(data.AmountPrecision * -1),
 				PriceFilter: math.Pow10(data.PricePrecision * -1),
+				MinQty:      data.MinOrderAmt,
+				MaxQty:      data.MaxOrderAmt,
+				MinNotional: data.MinOrderValue,
 				Listed:      data.State == "online",
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -349,6 +352,146 @@ func (e *Huobi) UpdateAllBalances() {
 	}
 }
 
+/*Get the account's fee rates of the pairs, 10 symbols per request*/
+func (e *Huobi) UpdateFees() error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
+	}
+
+	pairs := e.GetPairs()
+	for i := 0; i < len(pairs); i += 10 {
+		end := i + 10
+		if end > len(pairs) {
+			end = len(pairs)
+		}
+		symbols := []string{}
+		for _, p := range pairs[i:end] {
+			symbols = append(symbols, e.GetSymbolByPair(p))
+		}
+
+		jsonResponse := &JsonResponse{}
+		feeRates := FeeRates{}
+		strRequest := "/v2/reference/transact-fee-rate"
+
+		mapParams := make(map[string]string)
+		mapParams["symbols"] = strings.Join(symbols, ",")
+
+		jsonFeeReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
+		if err := json.Unmarshal([]byte(jsonFeeReturn), &jsonResponse); err != nil {
+			return fmt.Errorf("%s UpdateFees Json Unmarshal Err: %v %v", e.GetName(), err, jsonFeeReturn)
+		} else if jsonResponse.Code != 200 {
+			return fmt.Errorf("%s UpdateFees Failed: %v %v", e.GetName(), jsonResponse.Code, jsonResponse.Message)
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &feeRates); err != nil {
+			return fmt.Errorf("%s UpdateFees Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		}
+
+		for _, rate := range feeRates {
+			p := e.GetPairBySymbol(rate.Symbol)
+			if p == nil || e.GetPairConstraint(p) == nil {
+				continue
+			}
+			makerFee, err := strconv.ParseFloat(rate.ActualMakerRate, 64)
+			if err != nil {
+				makerFee, _ = strconv.ParseFloat(rate.MakerFeeRate, 64)
+			}
+			takerFee, err := strconv.ParseFloat(rate.ActualTakerRate, 64)
+			if err != nil {
+				takerFee, _ = strconv.ParseFloat(rate.TakerFeeRate, 64)
+			}
+			update := *e.GetPairConstraint(p)
+			update.MakerFee = makerFee
+			update.TakerFee = takerFee
+			e.SetPairConstraint(&update)
+		}
+	}
+	return nil
+}
+
+/*The permissions of the API key, listed by the uid of the account*/
+func (e *Huobi) CheckCredentials() (*exchange.Credentials, error) {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	var uid int64
+	strRequest := "/v2/user/uid"
+
+	jsonUidReturn := e.ApiKeyRequest("GET", make(map[string]string), strRequest)
+	if err := json.Unmarshal([]byte(jsonUidReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Json Unmarshal Err: %v %v", e.GetName(), err, jsonUidReturn)
+	} else if jsonResponse.Code != 200 {
+		return nil, e.apiErrorV2("CheckCredentials", jsonResponse, jsonUidReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &uid); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Uid Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	jsonResponse = &JsonResponse{}
+	apiKeys := ApiKeys{}
+	strRequest = "/v2/user/api-key"
+
+	mapParams := make(map[string]string)
+	mapParams["uid"] = strconv.FormatInt(uid, 10)
+	mapParams["accessKey"] = e.API_KEY
+
+	jsonKeyReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
+	if err := json.Unmarshal([]byte(jsonKeyReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Json Unmarshal Err: %v %v", e.GetName(), err, jsonKeyReturn)
+	} else if jsonResponse.Code != 200 {
+		return nil, e.apiErrorV2("CheckCredentials", jsonResponse, jsonKeyReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &apiKeys); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	for _, apiKey := range apiKeys {
+		if apiKey.AccessKey != e.API_KEY {
+			continue
+		}
+		credentials := exchange.NewCredentials(e.GetName())
+		permissions := "," + apiKey.Permission + ","
+		credentials.Permissions[exchange.READ] = strings.Contains(permissions, ",readOnly,")
+		credentials.Permissions[exchange.TRADE] = strings.Contains(permissions, ",trade,")
+		credentials.Permissions[exchange.WITHDRAW] = strings.Contains(permissions, ",withdraw,")
+		if apiKey.IPAddresses != "" {
+			credentials.IPRestricted = true
+			credentials.IPs = strings.Split(apiKey.IPAddresses, ",")
+		}
+		// the key expires at the end of its last valid day, UTC
+		if apiKey.Status == "expired" {
+			credentials.ExpireTS = time.Now().Unix()
+		} else if apiKey.ValidDays >= 0 {
+			now := time.Now().UTC()
+			endOfDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+			credentials.ExpireTS = endOfDay.AddDate(0, 0, apiKey.ValidDays).Unix()
+		}
+		return credentials, nil
+	}
+	return nil, fmt.Errorf("%s CheckCredentials Failed: API Key not listed %v", e.GetName(), jsonKeyReturn)
+}
+
+/*Get the server time, the timestamps of the signed requests are on its clock*/
+func (e *Huobi) ServerTime() (time.Time, error) {
+	jsonResponse := &JsonResponse{}
+	var serverTime int64
+
+	strUrl := API_URL + "/v1/common/timestamp"
+
+	jsonTimeReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonTimeReturn), &jsonResponse); err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Json Unmarshal Err: %v %v", e.GetName(), err, jsonTimeReturn)
+	} else if jsonResponse.Status != "ok" {
+		return time.Time{}, fmt.Errorf("%s ServerTime Failed: %v", e.GetName(), jsonTimeReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &serverTime); err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	return time.Unix(0, serverTime*int64(time.Millisecond)), nil
+}
+
 func (e *Huobi) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		log.Printf("%s API Key or Secret Key are nil.", e.GetName())
@@ -389,6 +532,14 @@ func (e *Huobi) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	if rate != 0 {
+		var err error
+		rate, quantity, err = exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if e.Account_ID == "" {
 		e.Account_ID = e.GetAccounts()
 		if e.Account_ID == "" {
@@ -400,14 +551,11 @@ func (e *Huobi) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	placeOrder := ""
 	strRequest := "/v1/order/orders/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["account-id"] = e.Account_ID
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
 	if rate != 0 {
-		mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+		mapParams["price"] = exchange.FormatRate(e, pair, rate)
 		mapParams["type"] = "sell-limit"
 	} else {
 		mapParams["type"] = "sell-market"
@@ -418,7 +566,7 @@ func (e *Huobi) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
 		return nil, fmt.Errorf("%s LimitSell Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if jsonResponse.Status != "ok" {
-		return nil, fmt.Errorf("%s LimitSell Failed: %v", e.GetName(), jsonResponse)
+		return nil, e.apiError("LimitSell", jsonResponse, jsonPlaceReturn)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitSell Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -442,6 +590,14 @@ func (e *Huobi) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	if rate != 0 {
+		var err error
+		rate, quantity, err = exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if e.Account_ID == "" {
 		e.Account_ID = e.GetAccounts()
 		if e.Account_ID == "" {
@@ -453,14 +609,11 @@ func (e *Huobi) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	placeOrder := ""
 	strRequest := "/v1/order/orders/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["account-id"] = e.Account_ID
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["amount"] = exchange.FormatQuantity(e, pair, quantity)
 	if rate != 0 {
-		mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+		mapParams["price"] = exchange.FormatRate(e, pair, rate)
 		mapParams["type"] = "buy-limit"
 	} else {
 		mapParams["type"] = "buy-market"
@@ -471,7 +624,7 @@ func (e *Huobi) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
 		return nil, fmt.Errorf("%s LimitBuy Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if jsonResponse.Status != "ok" {
-		return nil, fmt.Errorf("%s LimitBuy Failed: %v", e.GetName(), jsonResponse)
+		return nil, e.apiError("LimitBuy", jsonResponse, jsonPlaceReturn)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitBuy Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -513,7 +666,7 @@ func (e *Huobi) OrderStatus(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonOrderStatus), &jsonResponse); err != nil {
 		return fmt.Errorf("%s OrderStatus Json Unmarshal Err: %v %v", e.GetName(), err, jsonOrderStatus)
 	} else if jsonResponse.Status != "ok" {
-		return fmt.Errorf("%s OrderStatus Failed: %v", e.GetName(), jsonResponse)
+		return e.apiError("OrderStatus", jsonResponse, jsonOrderStatus)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &orderStatus); err != nil {
 		return fmt.Errorf("%s OrderStatus Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -575,7 +728,7 @@ func (e *Huobi) CancelOrder(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonCancelOrder), &jsonResponse); err != nil {
 		return fmt.Errorf("%s CancelOrder Json Unmarshal Err: %v %v", e.GetName(), err, jsonCancelOrder)
 	} else if jsonResponse.Status != "ok" {
-		return fmt.Errorf("%s CancelOrder Failed: %v", e.GetName(), jsonResponse)
+		return e.apiError("CancelOrder", jsonResponse, jsonCancelOrder)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &cancelOrder); err != nil {
 		return fmt.Errorf("%s CancelOrder Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -591,27 +744,41 @@ func (e *Huobi) CancelAllOrder() error {
 	return nil
 }
 
+/*Map the err-code of the API to the exchange errors, the payload attached
+The signature of an expired Timestamp is rejected as api-signature-not-valid*/
+func (e *Huobi) apiError(op string, jsonResponse *JsonResponse, payload string) error {
+	err := errorCodes[jsonResponse.ErrCode]
+	if err == exchange.ErrAuth && strings.Contains(strings.ToLower(jsonResponse.ErrMsg), "timestamp") {
+		err = exchange.ErrTimestamp
+	}
+	return exchange.NewApiError(e.GetName(), op, jsonResponse.ErrCode, jsonResponse.ErrMsg, payload, err)
+}
+
+/*The v2 endpoints reply the code and message, but the signature rejected with the err-code*/
+func (e *Huobi) apiErrorV2(op string, jsonResponse *JsonResponse, payload string) error {
+	if jsonResponse.ErrCode != "" {
+		return e.apiError(op, jsonResponse, payload)
+	}
+	code := strconv.Itoa(jsonResponse.Code)
+	return exchange.NewApiError(e.GetName(), op, code, jsonResponse.Message, payload, errorCodes[code])
+}
+
 /*************** Signature Http Request ***************/
 /*Method: API Request and Signature is required
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Huobi) ApiKeyRequest(strMethod string, mapParams map[string]string, strRequestPath string) string {
-
-	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05")
 	strUrl := API_URL + strRequestPath
 
-	mapParams["AccessKeyId"] = e.API_KEY
-	mapParams["SignatureMethod"] = "HmacSHA256"
-	mapParams["SignatureVersion"] = "2"
-	mapParams["Timestamp"] = timestamp
-
-	hostName := "api.huobi.pro"
-	mapParams["Signature"] = CreateSign(mapParams, strMethod, hostName, strRequestPath, e.API_SECRET)
+	signer := &exchange.CanonicalSigner{Key: e.API_KEY, Secret: e.API_SECRET, Now: exchange.GetClock(e.GetName()).Now}
+	signRequest := &exchange.SignRequest{Method: strMethod, Host: "api.huobi.pro", Path: strRequestPath, Params: mapParams}
+	if err := signer.Sign(signRequest); err != nil {
+		return err.Error()
+	}
 	// log.Printf("====mapParams: %+v", mapParams)
 	var strRequestUrl string
-	strParams := MapSortByKey(mapParams)
-	strRequestUrl = strUrl + "?" + strParams
+	strRequestUrl = strUrl + "?" + signRequest.Query
 
 	if strMethod == "POST" {
 		return exchange.HttpPostRequest(strRequestUrl, mapParams)
@@ -625,7 +792,7 @@ func (e *Huobi) ApiKeyRequest(strMethod string, mapParams map[string]string, str
 	request.Header.Add("User-Agent", "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/39.0.2171.71 Safari/537.36")
 
 	// 发出请求
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
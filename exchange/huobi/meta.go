@@ -4,6 +4,10 @@ package huobi
 // Distributed under the MIT software license, see the accompanying
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
+import (
+	"github.com/bitontop/gored/exchange"
+)
+
 const (
 	DEFAULT_ID           = 11
 	DEFAULT_TAKER_FEE    = 0.002
@@ -12,3 +16,35 @@ const (
 	DEFAULT_PRICE_FILTER = 0.00000001
 	DEFAULT_TXFEE        = 0.005
 )
+
+// errorCodes maps the Huobi err-code to the exchange errors
+var errorCodes = map[string]error{
+	"account-frozen-balance-insufficient-error": exchange.ErrInsufficientFunds,
+	"account-balance-insufficient-error":        exchange.ErrInsufficientFunds,
+	"insufficient-balance":                      exchange.ErrInsufficientFunds,
+	"order-accountbalance-error":                exchange.ErrInsufficientFunds,
+	"base-record-invalid":                       exchange.ErrOrderNotFound,
+	"order-orderstate-error":                    exchange.ErrOrderNotFound, // the order is closed
+	"order-queryorder-invalid":                  exchange.ErrOrderNotFound,
+	"api-signature-not-valid":                   exchange.ErrAuth,
+	"api-signature-check-failed":                exchange.ErrAuth,
+	"login-required":                            exchange.ErrAuth,
+	"base-symbol-error":                         exchange.ErrInvalidSymbol,
+	"base-symbol-trade-disabled":                exchange.ErrInvalidSymbol,
+	"order-amount-precision-error":              exchange.ErrInvalidPrecision,
+	"order-price-precision-error":               exchange.ErrInvalidPrecision,
+	"order-orderamount-precision-error":         exchange.ErrInvalidPrecision,
+	"order-orderprice-precision-error":          exchange.ErrInvalidPrecision,
+	"order-limitorder-amount-min-error":         exchange.ErrOrderLimit,
+	"order-limitorder-amount-max-error":         exchange.ErrOrderLimit,
+	"order-value-min-error":                     exchange.ErrOrderLimit,
+	"too-many-request":                          exchange.ErrRateLimited,
+	"api-request-limit":                         exchange.ErrRateLimited,
+	"system-maintenance":                        exchange.ErrMaintenance,
+	"base-system-in-maintenance":                exchange.ErrMaintenance,
+	"login-timestamp-expired":                   exchange.ErrTimestamp,
+	"invalid-timestamp":                         exchange.ErrTimestamp,
+	"1002":                                      exchange.ErrAuth, // the code of the v2 endpoints, unauthorized
+	"1003":                                      exchange.ErrAuth, // invalid signature
+	"429":                                       exchange.ErrRateLimited,
+}
@@ -15,6 +15,7 @@ type JsonResponse struct {
 	Tick    json.RawMessage `json:"tick"`
 	ErrCode string          `json:"err-code"`
 	ErrMsg  string          `json:"err-msg"`
+	Message string          `json:"message"`
 }
 
 type CoinsData []struct {
@@ -75,13 +76,16 @@ type CoinsData []struct {
 // }
 
 type PairsData []struct {
-	BaseCurrency    string `json:"base-currency"`
-	QuoteCurrency   string `json:"quote-currency"`
-	PricePrecision  int    `json:"price-precision"`
-	AmountPrecision int    `json:"amount-precision"`
-	SymbolPartition string `json:"symbol-partition"`
-	Symbol          string `json:"symbol"`
-	State           string `json:"state"`
+	BaseCurrency    string  `json:"base-currency"`
+	QuoteCurrency   string  `json:"quote-currency"`
+	PricePrecision  int     `json:"price-precision"`
+	AmountPrecision int     `json:"amount-precision"`
+	MinOrderAmt     float64 `json:"min-order-amt"`
+	MaxOrderAmt     float64 `json:"max-order-amt"`
+	MinOrderValue   float64 `json:"min-order-value"`
+	SymbolPartition string  `json:"symbol-partition"`
+	Symbol          string  `json:"symbol"`
+	State           string  `json:"state"`
 }
 
 type OrderBook struct {
@@ -99,6 +103,25 @@ type AccountsReturn []struct {
 	SubType string `json:"sub-type"`
 }
 
+type FeeRates []struct {
+	Symbol          string `json:"symbol"`
+	MakerFeeRate    string `json:"makerFeeRate"`
+	TakerFeeRate    string `json:"takerFeeRate"`
+	ActualMakerRate string `json:"actualMakerRate"` // after the HT deduction, if enabled
+	ActualTakerRate string `json:"actualTakerRate"`
+}
+
+type ApiKeys []struct {
+	AccessKey   string `json:"accessKey"`
+	Note        string `json:"note"`
+	Permission  string `json:"permission"`  // readOnly,trade,withdraw
+	IPAddresses string `json:"ipAddresses"` // comma separated
+	ValidDays   int    `json:"validDays"`   // the days left, -1 if permanent
+	Status      string `json:"status"`      // normal or expired
+	CreateTime  int64  `json:"createTime"`
+	UpdateTime  int64  `json:"updateTime"`
+}
+
 type AccountBalances struct {
 	ID    int    `json:"id"`
 	Type  string `json:"type"`
@@ -231,6 +231,11 @@ func (e *Idex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/order"
@@ -275,6 +280,11 @@ func (e *Idex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/order"
@@ -422,7 +432,7 @@ func (e *Idex) ApiKeyPOST(strRequestPath string, mapParams map[string]interface{
 	strRequestUrl := API_URL + strRequestPath
 
 	mapParams["address"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().Unix())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Second).Next())
 	// signature := SoliditySha3(mapParams, e.API_SECRET)
 	// log.Printf("signature: %d %s", len(signature), signature)
 	// log.Printf("v: %d %d", signature[64], int(signature[64]))
@@ -445,7 +455,7 @@ func (e *Idex) ApiKeyPOST(strRequestPath string, mapParams map[string]interface{
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	request.Header.Add("Accept", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -5,10 +5,6 @@ package kraken
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"crypto/sha512"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -140,6 +136,7 @@ func (e *Kraken) GetPairsData() error {
 				p = e.GetPairBySymbol(key)
 			}
 			if p != nil {
+				minQty, _ := strconv.ParseFloat(data.OrderMin, 64)
 				pairConstraint := &exchange.PairConstraint{
 					PairID:      p.ID,
 					Pair:        p,
@@ -147,6 +144,7 @@ func (e *Kraken) GetPairsData() error {
 					ExID:        data.Wsname,
 					LotSize:     math.Pow10(-1 * data.LotDecimals),
 					PriceFilter: math.Pow10(-1 * data.PairDecimals),
+					MinQty:      minQty,
 					Listed:      DEFAULT_LISTED,
 				}
 				if len(data.FeesMaker) >= 1 {
@@ -358,19 +356,21 @@ func (e *Kraken) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/0/private/AddOrder"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	params := url.Values{
 		"pair":      {e.GetSymbolByPair(pair)},
 		"type":      {"sell"},
 		"ordertype": {"limit"},
-		"price":     {strconv.FormatFloat(rate, 'f', priceFilter, 64)},
-		"volume":    {strconv.FormatFloat(quantity, 'f', lotSize, 64)},
+		"price":     {exchange.FormatRate(e, pair, rate)},
+		"volume":    {exchange.FormatQuantity(e, pair, quantity)},
 	}
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequestPath, params, &PlaceOrder{})
@@ -400,19 +400,21 @@ func (e *Kraken) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/0/private/AddOrder"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	params := url.Values{
 		"pair":      {e.GetSymbolByPair(pair)},
 		"type":      {"buy"},
 		"ordertype": {"limit"},
-		"price":     {strconv.FormatFloat(rate, 'f', priceFilter, 64)},
-		"volume":    {strconv.FormatFloat(quantity, 'f', lotSize, 64)},
+		"price":     {exchange.FormatRate(e, pair, rate)},
+		"volume":    {exchange.FormatQuantity(e, pair, quantity)},
 	}
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequestPath, params, &PlaceOrder{})
@@ -518,15 +520,27 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Kraken) ApiKeyPost(strRequestPath string, values url.Values, typ interface{}) string {
-	/* if e.Two_Factor != "" {
-		mapParams["otp"] = e.Two_Factor
-	} */
+	if e.Two_Factor != "" {
+		otp, err := exchange.TwoFactorCode(e.Two_Factor)
+		if err != nil {
+			return fmt.Sprintf("%s %v", e.GetName(), err)
+		}
+		values.Set("otp", otp)
+	}
 	strUrl := API_URL + strRequestPath
-	httpClient := &http.Client{}
-	non := fmt.Sprintf("%d", time.Now().UnixNano())
-	values.Set("nonce", non)
-	secret, _ := base64.StdEncoding.DecodeString(e.API_SECRET)
-	signature := createSignature(strRequestPath, values, secret)
+	httpClient := exchange.NewHttpClient()
+	signer := &exchange.NonceSigner{
+		Key:    e.API_KEY,
+		Secret: e.API_SECRET,
+		Nonce:  exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next,
+	}
+	signRequest := &exchange.SignRequest{Method: "POST", Path: strRequestPath, Params: make(map[string]string)}
+	for key := range values {
+		signRequest.Params[key] = values.Get(key)
+	}
+	if err := signer.Sign(signRequest); err != nil {
+		return err.Error()
+	}
 
 	/* jsonParams := ""
 	if nil != mapParams {
@@ -535,18 +549,13 @@ func (e *Kraken) ApiKeyPost(strRequestPath string, values url.Values, typ interf
 	}
 	jsonParams = exchange.Map2UrlQuery(mapParams) */
 
-	headers := map[string]string{
-		"API-Key":  e.API_KEY,
-		"API-Sign": signature,
-	}
-
-	request, err := http.NewRequest("POST", strUrl, strings.NewReader(values.Encode()))
+	request, err := http.NewRequest("POST", strUrl, strings.NewReader(signRequest.Query))
 	if nil != err {
 		return err.Error()
 	}
 
 	request.Header.Add("User-Agent", "Kraken GO API Agent (https://github.com/beldur/kraken-go-api-client)")
-	for key, value := range headers {
+	for key, value := range signRequest.Header {
 		request.Header.Add(key, value)
 	}
 
@@ -572,24 +581,3 @@ func (e *Kraken) ApiKeyPost(strRequestPath string, values url.Values, typ interf
 
 	return string(body)
 }
-
-//Signature加密
-func getSha256(input []byte) []byte {
-	sha := sha256.New()
-	sha.Write(input)
-	return sha.Sum(nil)
-}
-
-// getHMacSha512 creates a hmac hash with sha512
-func getHMacSha512(message, secret []byte) []byte {
-	mac := hmac.New(sha512.New, secret)
-	mac.Write(message)
-	return mac.Sum(nil)
-}
-
-func createSignature(urlPath string, values url.Values, secret []byte) string {
-	// See https://www.kraken.com/help/api#general-usage for more information
-	shaSum := getSha256([]byte(values.Get("nonce") + values.Encode()))
-	macSum := getHMacSha512(append([]byte(urlPath), shaSum...), secret)
-	return base64.StdEncoding.EncodeToString(macSum)
-}
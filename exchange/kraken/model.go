@@ -38,6 +38,7 @@ type PairsData struct {
 	FeeVolumeCurrency string      `json:"fee_volume_currency"`
 	MarginCall        int         `json:"margin_call"`
 	MarginStop        int         `json:"margin_stop"`
+	OrderMin          string      `json:"ordermin"`
 }
 
 type OrderBook struct {
@@ -138,6 +138,9 @@ func (e *Kucoin) GetPairsData() error {
 		if p != nil {
 			lotSize, _ := strconv.ParseFloat(data.BaseIncrement, 64)
 			priceFilter, _ := strconv.ParseFloat(data.PriceIncrement, 64)
+			minQty, _ := strconv.ParseFloat(data.BaseMinSize, 64)
+			maxQty, _ := strconv.ParseFloat(data.BaseMaxSize, 64)
+			minNotional, _ := strconv.ParseFloat(data.QuoteMinSize, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -146,6 +149,9 @@ func (e *Kucoin) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     lotSize,
 				PriceFilter: priceFilter,
+				MinQty:      minQty,
+				MaxQty:      maxQty,
+				MinNotional: minNotional,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -486,6 +492,60 @@ func (e *Kucoin) UpdateAllBalances() {
 	}
 }
 
+/*The permissions of the API key, Kucoin keys do not expire*/
+func (e *Kucoin) CheckCredentials() (*exchange.Credentials, error) {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	apiKey := ApiKey{}
+	strRequest := "/api/v1/user/api-key"
+
+	jsonKeyReturn := e.ApiKeyRequest("GET", strRequest, nil)
+	if err := json.Unmarshal([]byte(jsonKeyReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Json Unmarshal Err: %v %v", e.GetName(), err, jsonKeyReturn)
+	} else if jsonResponse.Code != "200000" {
+		return nil, e.apiError("CheckCredentials", jsonResponse, jsonKeyReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &apiKey); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	credentials := exchange.NewCredentials(e.GetName())
+	permissions := "," + apiKey.Permission + ","
+	credentials.Permissions[exchange.READ] = strings.Contains(permissions, ",General,")
+	credentials.Permissions[exchange.TRADE] = strings.Contains(permissions, ",Spot,") || strings.Contains(permissions, ",Trade,")
+	credentials.Permissions[exchange.WITHDRAW] = strings.Contains(permissions, ",Withdrawal,")
+	credentials.Permissions[exchange.MARGIN] = strings.Contains(permissions, ",Margin,")
+	credentials.Permissions[exchange.FUTURES] = strings.Contains(permissions, ",Futures,")
+	if apiKey.IPWhitelist != "" {
+		credentials.IPRestricted = true
+		credentials.IPs = strings.Split(apiKey.IPWhitelist, ",")
+	}
+	return credentials, nil
+}
+
+/*Get the server time, the timestamps of the signed requests are on its clock*/
+func (e *Kucoin) ServerTime() (time.Time, error) {
+	jsonResponse := &JsonResponse{}
+	var serverTime int64
+
+	strUrl := API_URL + "/api/v1/timestamp"
+
+	jsonTimeReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonTimeReturn), &jsonResponse); err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Json Unmarshal Err: %v %v", e.GetName(), err, jsonTimeReturn)
+	} else if jsonResponse.Code != "200000" {
+		return time.Time{}, fmt.Errorf("%s ServerTime Failed: %v %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &serverTime); err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	return time.Unix(0, serverTime*int64(time.Millisecond)), nil
+}
+
 /* Withdraw(coin *coin.Coin, quantity float64, addr, tag string) */
 func (e *Kucoin) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
@@ -528,26 +588,28 @@ func (e *Kucoin) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := OrderDetail{}
 	strRequest := "/api/v1/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["clientOid"] = fmt.Sprintf("%v", time.Now().UnixNano()) //Unique order id selected by you to identify your order
 	mapParams["side"] = "sell"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["type"] = "limit"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["size"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
 		return nil, fmt.Errorf("%s LimitSell Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if jsonResponse.Code != "200000" {
-		return nil, fmt.Errorf("%s LimitSell Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return nil, e.apiError("LimitSell", jsonResponse, jsonPlaceReturn)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitSell Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -571,26 +633,28 @@ func (e *Kucoin) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := OrderDetail{}
 	strRequest := "/api/v1/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["clientOid"] = fmt.Sprintf("%v", time.Now().UnixNano()) //Unique order id selected by you to identify your order
 	mapParams["side"] = "buy"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["type"] = "limit"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
+	mapParams["size"] = exchange.FormatQuantity(e, pair, quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
 		return nil, fmt.Errorf("%s LimitBuy Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if jsonResponse.Code != "200000" {
-		return nil, fmt.Errorf("%s LimitBuy Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return nil, e.apiError("LimitBuy", jsonResponse, jsonPlaceReturn)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitBuy Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -622,7 +686,7 @@ func (e *Kucoin) OrderStatus(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonOrderStatus), &jsonResponse); err != nil {
 		return fmt.Errorf("%s OrderStatus Json Unmarshal Err: %v %v", e.GetName(), err, jsonOrderStatus)
 	} else if jsonResponse.Code != "200000" {
-		return fmt.Errorf("%s OrderStatus Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return e.apiError("OrderStatus", jsonResponse, jsonOrderStatus)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &orderStatus); err != nil {
 		return fmt.Errorf("%s OrderStatus Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -669,7 +733,7 @@ func (e *Kucoin) CancelOrder(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonCancelOrder), &jsonResponse); err != nil {
 		return fmt.Errorf("%s CancelOrder Json Unmarshal Err: %v %v", e.GetName(), err, jsonCancelOrder)
 	} else if jsonResponse.Code != "200000" {
-		return fmt.Errorf("%s CancelOrder Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return e.apiError("CancelOrder", jsonResponse, jsonCancelOrder)
 	}
 	if err := json.Unmarshal(jsonResponse.Data, &cancelOrder); err != nil {
 		return fmt.Errorf("%s CancelOrder Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
@@ -685,45 +749,61 @@ func (e *Kucoin) CancelAllOrder() error {
 	return nil
 }
 
+/*Map the error code of the API to the exchange errors, the payload attached*/
+func (e *Kucoin) apiError(op string, jsonResponse *JsonResponse, payload string) error {
+	err := errorCodes[jsonResponse.Code]
+	if jsonResponse.Code == "400100" {
+		msg := strings.ToLower(jsonResponse.Msg)
+		switch {
+		case strings.Contains(msg, "not exist") || strings.Contains(msg, "not_exist"):
+			err = exchange.ErrOrderNotFound
+		case strings.Contains(msg, "increment"):
+			err = exchange.ErrInvalidPrecision
+		case strings.Contains(msg, "minimum") || strings.Contains(msg, "maximum"):
+			err = exchange.ErrOrderLimit
+		}
+	}
+	return exchange.NewApiError(e.GetName(), op, jsonResponse.Code, jsonResponse.Msg, payload, err)
+}
+
 /*************** Signature Http Request ***************/
 /*Method: API Request and Signature is required
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Kucoin) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[string]string) string {
-	nonce := time.Now().UnixNano() / int64(time.Millisecond) //Millisecond无误
 	strRequestUrl := API_URL + strRequestPath
 
-	httpClient := &http.Client{}
-	var err error
-	request := &http.Request{}
-	signature := fmt.Sprintf("%v", nonce) + strMethod + strRequestPath
-	jsonParams := ""
-
+	signer := &exchange.PassphraseSigner{
+		Key:          e.API_KEY,
+		Secret:       e.API_SECRET,
+		Passphrase:   e.Passphrase,
+		HeaderPrefix: "KC-API-",
+		Now:          exchange.GetClock(e.GetName()).Now,
+	}
+	signRequest := &exchange.SignRequest{Method: strMethod, Path: strRequestPath}
 	if strMethod == "GET" || strMethod == "DELETE" {
-		if nil != mapParams {
-			payload := exchange.Map2UrlQuery(mapParams)
-			strRequestUrl = API_URL + strRequestPath + "?" + payload
-			signature = signature + "?" + payload
-		}
-		request, err = http.NewRequest(strMethod, strRequestUrl, nil)
-	} else {
-		if nil != mapParams {
-			bytesParams, _ := json.Marshal(mapParams)
-			jsonParams = string(bytesParams)
-			signature = signature + jsonParams
-		}
-		request, err = http.NewRequest(strMethod, strRequestUrl, strings.NewReader(jsonParams))
+		signRequest.Params = mapParams
+	} else if nil != mapParams {
+		bytesParams, _ := json.Marshal(mapParams)
+		signRequest.Body = string(bytesParams)
+	}
+	if err := signer.Sign(signRequest); err != nil {
+		return err.Error()
+	}
+	if signRequest.Query != "" {
+		strRequestUrl += "?" + signRequest.Query
 	}
 
+	httpClient := exchange.NewHttpClient()
+	request, err := http.NewRequest(strMethod, strRequestUrl, strings.NewReader(signRequest.Body))
 	if nil != err {
 		return err.Error()
 	}
 	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add("KC-API-KEY", e.API_KEY)
-	request.Header.Add("KC-API-SIGN", exchange.ComputeHmac256Base64(signature, e.API_SECRET))
-	request.Header.Add("KC-API-TIMESTAMP", fmt.Sprintf("%v", nonce))
-	request.Header.Add("KC-API-PASSPHRASE", e.Passphrase)
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
 	response, err := httpClient.Do(request)
 	if nil != err {
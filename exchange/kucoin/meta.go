@@ -4,6 +4,10 @@ package kucoin
 // Distributed under the MIT software license, see the accompanying
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
+import (
+	"github.com/bitontop/gored/exchange"
+)
+
 const (
 	DEFAULT_ID           = 6
 	DEFAULT_TAKER_FEE    = 0.001
@@ -13,3 +17,20 @@ const (
 	DEFAULT_CONFIRMATION = 1001
 	DEFAULT_LISTED       = true
 )
+
+// errorCodes maps the Kucoin error codes to the exchange errors, the
+// parameter errors 400100 are refined by the message in apiError.
+var errorCodes = map[string]error{
+	"400001": exchange.ErrAuth, // KC-API headers missing
+	"400002": exchange.ErrTimestamp,
+	"400003": exchange.ErrAuth, // KC-API-KEY not exists
+	"400004": exchange.ErrAuth, // KC-API-PASSPHRASE error
+	"400005": exchange.ErrAuth, // signature error
+	"400006": exchange.ErrAuth, // the IP is not in the whitelist
+	"400007": exchange.ErrAuth, // access denied
+	"411100": exchange.ErrAuth, // user frozen
+	"429000": exchange.ErrRateLimited,
+	"200004": exchange.ErrInsufficientFunds,
+	"900001": exchange.ErrInvalidSymbol,
+	"503000": exchange.ErrMaintenance,
+}
@@ -47,6 +47,16 @@ type OrderBook struct {
 	Bids     [][]string `json:"bids"`
 }
 
+type ApiKey struct {
+	Remark      string `json:"remark"`
+	ApiKey      string `json:"apiKey"`
+	ApiVersion  int    `json:"apiVersion"`
+	Permission  string `json:"permission"`  // e.g. General,Spot,Margin,Futures,Withdrawal
+	IPWhitelist string `json:"ipWhitelist"` // comma separated
+	CreatedAt   int64  `json:"createdAt"`
+	IsMaster    bool   `json:"isMaster"`
+}
+
 type AccountBalance []struct {
 	Balance   string `json:"balance"`
 	Available string `json:"available"`
@@ -18,7 +18,6 @@ import (
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
 
-	"strconv"
 )
 
 const (
@@ -150,6 +149,7 @@ func (e *Latoken) GetPairsData() error {
 				TakerFee:    data.TakerFee,
 				LotSize:     math.Pow10(-1 * data.AmountPrecision),
 				PriceFilter: math.Pow10(-1 * data.PricePrecision),
+				MinQty:      data.MinQty,
 				Listed:      true,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -241,17 +241,19 @@ func (e *Latoken) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v1/Order/new"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
 	mapParams["Side"] = "sell"
-	mapParams["Amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["Price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["Amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["Price"] = exchange.FormatRate(e, pair, rate)
 	mapParams["OrderType"] = "limit"
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
@@ -277,18 +279,20 @@ func (e *Latoken) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{} // TestBuy{} //
 	strRequest := "/api/v1/Order/new"
 	// strRequest := "/api/v1/Order/test-order" // test buy api
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]string)
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
 	mapParams["Side"] = "buy"
-	mapParams["Amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["Price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["Amount"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["Price"] = exchange.FormatRate(e, pair, rate)
 	mapParams["OrderType"] = "limit"
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
@@ -379,12 +383,12 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Latoken) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
@@ -427,7 +431,7 @@ func (e *Latoken) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[
 	request.Header.Add("X-LA-SIGNATURE", signature)
 	request.Header.Add("X-LA-HASHTYPE", "HMAC-SHA256") //HMAC-SHA384, default HMAC-SHA256
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
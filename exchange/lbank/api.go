@@ -136,6 +136,7 @@ func (e *Lbank) GetPairsData() error {
 		if p != nil {
 			lotsize, _ := strconv.Atoi(data.QuantityAccuracy)
 			ticksize, _ := strconv.Atoi(data.PriceAccuracy)
+			minQty, _ := strconv.ParseFloat(data.MinTranQua, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -144,6 +145,7 @@ func (e *Lbank) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(lotsize * -1),
 				PriceFilter: math.Pow10(ticksize * -1),
+				MinQty:      minQty,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -322,6 +324,11 @@ func (e *Lbank) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/create_order.do"
 
@@ -356,6 +363,11 @@ func (e *Lbank) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/create_order.do"
 
@@ -469,7 +481,7 @@ func (e *Lbank) ApiKeyPost(strRequestPath string, mapParams map[string]string) s
 	mapParams["api_key"] = e.API_KEY
 	mapParams["sign"] = ComputeMD5(mapParams, e.API_SECRET)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	payload := exchange.Map2UrlQuery(mapParams)
 	strUrl := fmt.Sprintf("%s%s?%s", API_URL, strRequestPath, payload)
 
@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -51,14 +50,9 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *Liquid) GetCoinsData() error {
-
-	coinsdata := CoinsData{}
-	strRequestUrl := "/currencies"
-	strUrl := API_URL + strRequestUrl
-
-	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsdata); err != nil {
-		return fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	coinsdata, err := e.getCurrencies()
+	if err != nil {
+		return err
 	}
 
 	for _, data := range coinsdata {
@@ -93,6 +87,19 @@ func (e *Liquid) GetCoinsData() error {
 	return nil
 }
 
+/*The currencies, with the minimum order quantities*/
+func (e *Liquid) getCurrencies() (CoinsData, error) {
+	coinsdata := CoinsData{}
+	strRequestUrl := "/currencies"
+	strUrl := API_URL + strRequestUrl
+
+	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsdata); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	}
+	return coinsdata, nil
+}
+
 /* GetPairsData - Get Pairs Information (If API provide)
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -107,6 +114,15 @@ func (e *Liquid) GetPairsData() error {
 	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &pairsData); err != nil {
 		return fmt.Errorf("%s Get Pairs Json Unmarshal Err: %v %v", e.GetName(), err, jsonSymbolsReturn)
 	}
+	// the minimum order quantities are listed by currency
+	currencies, err := e.getCurrencies()
+	if err != nil {
+		return err
+	}
+	minQtys := make(map[string]float64)
+	for _, data := range currencies {
+		minQtys[data.Currency], _ = strconv.ParseFloat(fmt.Sprintf("%v", data.MinimumOrderQuantity), 64)
+	}
 
 	for _, data := range pairsData {
 		p := &pair.Pair{}
@@ -132,6 +148,7 @@ func (e *Liquid) GetPairsData() error {
 				TakerFee:    takerfee,
 				LotSize:     DEFAULT_LOT_SIZE,
 				PriceFilter: DEFAULT_PRICE_FILTER,
+				MinQty:      minQtys[data.BaseCurrency],
 				Listed:      !data.Disabled,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -305,18 +322,20 @@ func (e *Liquid) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/orders/"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]interface{})
 	mapParams["order_type"] = "limit"
 	mapParams["product_id"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "sell"
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["quantity"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -344,18 +363,20 @@ func (e *Liquid) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/orders/"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
-
 	mapParams := make(map[string]interface{})
 	mapParams["order_type"] = "limit"
 	mapParams["product_id"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "buy"
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["quantity"] = exchange.FormatQuantity(e, pair, quantity)
+	mapParams["price"] = exchange.FormatRate(e, pair, rate)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -493,7 +514,7 @@ func (e *Liquid) ApiKeyRequest(strMethod string, mapParams map[string]interface{
 	// final signature
 	fullSignature := header64 + "." + payload64 + "." + signature
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	request, err := http.NewRequest(strMethod, strUrl, strings.NewReader(jsonParams))
 
 	if nil != err {
@@ -11,7 +11,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/bitontop/gored/coin"
 	"github.com/bitontop/gored/pair"
@@ -65,14 +64,16 @@ type Exchange interface {
 
 	/***** Exchange Constraint *****/
 	GetConstraintFetchMethod(pair *pair.Pair) *ConstrainFetchMethod
-	UpdateConstraint()
+	UpdateConstraint() error
 	/***** Coin Constraint *****/
 	GetTxFee(coin *coin.Coin) float64
 	CanWithdraw(coin *coin.Coin) bool
 	CanDeposit(coin *coin.Coin) bool
 	GetConfirmation(coin *coin.Coin) int
 	/***** Pair Constraint *****/
-	GetFee(pair *pair.Pair) float64
+	GetFee(pair *pair.Pair) float64 // the taker fee
+	GetMakerFee(pair *pair.Pair) float64
+	GetTakerFee(pair *pair.Pair) float64
 	GetLotSize(pair *pair.Pair) float64
 	GetPriceFilter(pair *pair.Pair) float64
 
@@ -81,6 +82,9 @@ type Exchange interface {
 }
 
 type ExchangeManager struct {
+	subscriberMutex sync.RWMutex
+	subscribers     map[int]EventHandler
+	subscriberID    int
 }
 
 var instance *ExchangeManager
@@ -93,7 +97,9 @@ var supportList = make([]ExchangeName, 0)
 func CreateExchangeManager() *ExchangeManager {
 	once.Do(func() {
 		if instance == nil {
-			instance = &ExchangeManager{}
+			instance = &ExchangeManager{
+				subscribers: make(map[int]EventHandler),
+			}
 
 			if exMap == nil {
 				exMap = cmap.New()
@@ -120,7 +126,7 @@ func (e *ExchangeManager) Add(exchange Exchange) {
 
 	id := fmt.Sprintf("%d", exchange.GetID())
 	if exIDMap.Has(id) {
-		log.Fatal("%s ID: %d is exist. Please check.", exchange.GetName(), exchange.GetID())
+		log.Fatalf("%s ID: %d is exist. Please check.", exchange.GetName(), exchange.GetID())
 	} else {
 		exIDMap.Set(id, exchange)
 	}
@@ -193,23 +199,19 @@ func (e *ExchangeManager) SubsetPairs(e1, e2 Exchange) []*pair.Pair {
 	return pairs
 }
 
-func (e *ExchangeManager) UpdateExData(conf *Update) {
-	switch conf.Method {
-	case API_TIGGER:
-		break
-	case TIME_TIGGER:
-		for {
-			for _, exName := range conf.ExNames {
-				eInstance := e.Get(exName)
-				if eInstance != nil {
-					if err := eInstance.InitData(); err != nil {
-						log.Printf("Updating %s Data is failed.", exName)
-					} else {
-						log.Printf("%s Data Updated. Coin: %d   Pair: %d", exName, len(eInstance.GetCoins()), len(eInstance.GetPairs()))
-					}
-				}
-			}
-			time.Sleep(conf.Time)
+// UpdateExData starts refreshing the data of conf.ExNames. TIME_TIGGER refreshes
+// every conf.Time, API_TIGGER only refreshes on Scheduler.Refresh.
+// The returned Scheduler is already started, Stop it to end the updates.
+func (e *ExchangeManager) UpdateExData(conf *Update) *Scheduler {
+	scheduler := e.CreateScheduler(conf.Parallel)
+	for _, exName := range conf.ExNames {
+		switch conf.Method {
+		case API_TIGGER:
+			scheduler.Schedule(exName, 0)
+		case TIME_TIGGER:
+			scheduler.Schedule(exName, conf.Time)
 		}
 	}
+	scheduler.Start()
+	return scheduler
 }
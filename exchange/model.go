@@ -12,9 +12,10 @@ import (
 )
 
 type Update struct {
-	ExNames []ExchangeName
-	Method  UpdateMethod
-	Time    time.Duration
+	ExNames  []ExchangeName
+	Method   UpdateMethod
+	Time     time.Duration
+	Parallel int // exchanges updated at the same time, DEFAULT_PARALLEL if 0
 }
 
 type Config struct {
@@ -31,6 +32,10 @@ type Config struct {
 	UserID        string
 }
 
+// PairConstraint is the trading rules of a pair. The data/*.json snapshots
+// predate MinQty, MaxQty, MinNotional and MaxOrders: a pair loaded without
+// them keeps the limits fetched from the exchange API, see Constraints.Load.
+// Gemini has no snapshot, it reads min_order_size from its constraint.json.
 type PairConstraint struct {
 	PairID      int
 	Pair        *pair.Pair //the code on excahnge with the same chain, eg: BCH, BCC on different exchange, but they are the same chain
@@ -40,6 +45,10 @@ type PairConstraint struct {
 	TakerFee    float64
 	LotSize     float64 // the decimal place for this coin on exchange for the pairs, eg:  BTC: 0.00001    NEO:1   LTC: 0.001 ETH:0.01
 	PriceFilter float64
+	MinQty      float64 // the minimum order quantity, 0 if none, the LotSize applies
+	MaxQty      float64 // the maximum order quantity, 0 if none
+	MinNotional float64 // the minimum order amount in the base coin, 0 if none
+	MaxOrders   int     // the maximum open orders on the pair, 0 if none
 	Listed      bool
 	Issue       string //the issue for the pair if have any problem
 }
@@ -85,6 +94,9 @@ const (
 	Other     OrderStatus = "Other"
 )
 
+// The rates and quantities of an Order are float64, as the Maker levels, the
+// balances and the constraints. The values sent to the venues are formatted
+// exactly to the pair's steps by FormatRate and FormatQuantity.
 type Order struct {
 	Pair          *pair.Pair
 	OrderID       string
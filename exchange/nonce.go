@@ -0,0 +1,169 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// NonceStore keeps the last nonce of each credential set across restarts.
+type NonceStore interface {
+	Load(key string) (int64, error)
+	Save(key string, nonce int64) error
+}
+
+// Nonce is the nonce of one credential set: the time since the epoch in its
+// unit, raised above the last one given so it is strictly increasing however
+// many goroutines sign with the key at the same time.
+type Nonce struct {
+	key  string
+	unit time.Duration
+
+	mutex sync.Mutex
+	last  int64
+	store NonceStore
+}
+
+var nonces = make(map[string]*Nonce)
+var nonceStore NonceStore
+var nonceMutex sync.Mutex
+
+// GetNonce returns the nonce of the API key on the exchange. The unit is the
+// one the adapter always used, since the venue rejects any nonce below the
+// last one it saw; the first unit asked for the key is kept.
+func GetNonce(name ExchangeName, apiKey string, unit time.Duration) *Nonce {
+	nonceMutex.Lock()
+	defer nonceMutex.Unlock()
+	key := nonceKey(name, apiKey)
+	n, ok := nonces[key]
+	if !ok {
+		if unit <= 0 {
+			unit = time.Nanosecond
+		}
+		n = &Nonce{key: key, unit: unit}
+		n.setStore(nonceStore)
+		nonces[key] = n
+	}
+	return n
+}
+
+// SetNonceStore persists the nonces in the store, nil stops persisting them.
+// The nonces continue from the last ones saved if those are ahead.
+func SetNonceStore(store NonceStore) {
+	nonceMutex.Lock()
+	defer nonceMutex.Unlock()
+	nonceStore = store
+	for _, n := range nonces {
+		n.setStore(store)
+	}
+}
+
+// the key hashes the API key, it is not written in the clear to the store
+func nonceKey(name ExchangeName, apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return fmt.Sprintf("%s:%s", name, hex.EncodeToString(sum[:8]))
+}
+
+func (n *Nonce) setStore(store NonceStore) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.store = store
+	if store == nil {
+		return
+	}
+	if last, err := store.Load(n.key); err != nil {
+		log.Printf("Load Nonce %s Err: %v", n.key, err)
+	} else if last > n.last {
+		n.last = last
+	}
+}
+
+// Next returns a nonce above every nonce given before, and saves it to the
+// NonceStore if set.
+func (n *Nonce) Next() int64 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	next := time.Now().UnixNano() / int64(n.unit)
+	if next <= n.last {
+		next = n.last + 1
+	}
+	n.last = next
+	if n.store != nil {
+		if err := n.store.Save(n.key, next); err != nil {
+			log.Printf("Save Nonce %s Err: %v", n.key, err)
+		}
+	}
+	return next
+}
+
+// FileNonceStore keeps the nonces in a JSON file, rewritten on every Save.
+type FileNonceStore struct {
+	Path string
+
+	mutex  sync.Mutex
+	nonces map[string]int64
+}
+
+func NewFileNonceStore(path string) *FileNonceStore {
+	return &FileNonceStore{Path: path}
+}
+
+func (s *FileNonceStore) Load(key string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.read(); err != nil {
+		return 0, err
+	}
+	return s.nonces[key], nil
+}
+
+func (s *FileNonceStore) Save(key string, nonce int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.read(); err != nil {
+		return err
+	}
+	s.nonces[key] = nonce
+
+	data, err := json.Marshal(s.nonces)
+	if err != nil {
+		return err
+	}
+	// written aside then renamed, a crash never leaves half a file
+	tmp := s.Path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+// read loads the file once, a missing file is an empty store.
+func (s *FileNonceStore) read() error {
+	if s.nonces != nil {
+		return nil
+	}
+	nonces := make(map[string]int64)
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if err := json.Unmarshal(data, &nonces); err != nil {
+		return fmt.Errorf("Nonce File %s Unmarshal Err: %v", s.Path, err)
+	}
+	s.nonces = nonces
+	return nil
+}
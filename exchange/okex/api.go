@@ -12,6 +12,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bitontop/gored/coin"
@@ -177,6 +178,7 @@ func (e *Okex) GetPairsData() error {
 		}
 
 		if p != nil {
+			minQty, _ := strconv.ParseFloat(data.MinSize, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -185,6 +187,7 @@ func (e *Okex) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     lotSize,
 				PriceFilter: priceFilter,
+				MinQty:      minQty,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -447,6 +450,91 @@ func (e *Okex) getBalance(operation *exchange.AccountOperation) error {
 	return operation.Error
 }
 
+/*Get the account's fee tier, the same for all the pairs*/
+func (e *Okex) UpdateFees() error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	tradeFee := TradeFee{}
+	strRequest := "/api/spot/v3/trade_fee"
+
+	jsonFeeReturn := e.ApiKeyRequest("GET", nil, strRequest)
+	if err := json.Unmarshal([]byte(jsonFeeReturn), &tradeFee); err != nil {
+		return fmt.Errorf("%s UpdateFees Json Unmarshal Err: %v %v", e.GetName(), err, jsonFeeReturn)
+	} else if tradeFee.Maker == "" || tradeFee.Taker == "" {
+		errorJson := ErrorMsg{}
+		json.Unmarshal([]byte(jsonFeeReturn), &errorJson)
+		return fmt.Errorf("%s UpdateFees Err: Code: %v Msg: %v", e.GetName(), errorJson.Code, errorJson.Msg)
+	}
+
+	makerFee, err := strconv.ParseFloat(tradeFee.Maker, 64)
+	if err != nil {
+		return fmt.Errorf("%s Convert maker fee to Float64 Err: %v %v", e.GetName(), err, tradeFee.Maker)
+	}
+	takerFee, err := strconv.ParseFloat(tradeFee.Taker, 64)
+	if err != nil {
+		return fmt.Errorf("%s Convert taker fee to Float64 Err: %v %v", e.GetName(), err, tradeFee.Taker)
+	}
+	for _, p := range e.GetPairs() {
+		if pairConstraint := e.GetPairConstraint(p); pairConstraint != nil {
+			update := *pairConstraint
+			update.MakerFee = makerFee
+			update.TakerFee = takerFee
+			e.SetPairConstraint(&update)
+		}
+	}
+	return nil
+}
+
+/*The permissions of the API key, OKEx keys do not expire*/
+func (e *Okex) CheckCredentials() (*exchange.Credentials, error) {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	accountConfig := AccountConfig{}
+	strRequest := "/api/v5/account/config"
+
+	jsonConfigReturn := e.ApiKeyRequest("GET", nil, strRequest)
+	if err := json.Unmarshal([]byte(jsonConfigReturn), &accountConfig); err != nil {
+		return nil, fmt.Errorf("%s CheckCredentials Json Unmarshal Err: %v %v", e.GetName(), err, jsonConfigReturn)
+	} else if accountConfig.Code != "0" || len(accountConfig.Data) == 0 {
+		code, _ := strconv.Atoi(accountConfig.Code)
+		return nil, exchange.NewApiError(e.GetName(), "CheckCredentials", accountConfig.Code, accountConfig.Msg, jsonConfigReturn, errorCodes[code])
+	}
+
+	config := accountConfig.Data[0]
+	credentials := exchange.NewCredentials(e.GetName())
+	permissions := "," + config.Perm + ","
+	credentials.Permissions[exchange.READ] = strings.Contains(permissions, ",read_only,")
+	credentials.Permissions[exchange.TRADE] = strings.Contains(permissions, ",trade,")
+	credentials.Permissions[exchange.WITHDRAW] = strings.Contains(permissions, ",withdraw,")
+	if config.IP != "" {
+		credentials.IPRestricted = true
+		credentials.IPs = strings.Split(config.IP, ",")
+	}
+	return credentials, nil
+}
+
+/*Get the server time, the timestamps of the signed requests are on its clock*/
+func (e *Okex) ServerTime() (time.Time, error) {
+	serverTime := ServerTime{}
+
+	strUrl := API_URL + "/api/general/v3/time"
+
+	jsonTimeReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonTimeReturn), &serverTime); err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Json Unmarshal Err: %v %v", e.GetName(), err, jsonTimeReturn)
+	}
+	iso, err := time.Parse(time.RFC3339Nano, serverTime.Iso)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s ServerTime Parse Err: %v %v", e.GetName(), err, jsonTimeReturn)
+	}
+
+	return iso, nil
+}
+
 func (e *Okex) UpdateAllBalances() {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
 		log.Printf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
@@ -546,6 +634,11 @@ func (e *Okex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/spot/v3/orders"
 
@@ -560,7 +653,7 @@ func (e *Okex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitSell Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if !placeOrder.Result {
-		return nil, fmt.Errorf("%s LimitSell Failed: %v %v", e.GetName(), placeOrder.Code, placeOrder.Message)
+		return nil, exchange.NewApiError(e.GetName(), "LimitSell", placeOrder.Code, placeOrder.Message, jsonPlaceReturn, errorCodes[placeOrder.Code])
 	}
 
 	order := &exchange.Order{
@@ -581,6 +674,11 @@ func (e *Okex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/spot/v3/orders"
 
@@ -595,7 +693,7 @@ func (e *Okex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
 		return nil, fmt.Errorf("%s LimitBuy Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
 	} else if !placeOrder.Result {
-		return nil, fmt.Errorf("%s LimitBuy Failed: %v %v", e.GetName(), placeOrder.Code, placeOrder.Message)
+		return nil, exchange.NewApiError(e.GetName(), "LimitBuy", placeOrder.Code, placeOrder.Message, jsonPlaceReturn, errorCodes[placeOrder.Code])
 	}
 
 	order := &exchange.Order{
@@ -628,7 +726,7 @@ func (e *Okex) OrderStatus(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonOrderStatus), &orderStatus); err != nil {
 		return fmt.Errorf("%s OrderStatus Json Unmarshal Err: %v %v", e.GetName(), err, jsonOrderStatus)
 	} else if orderStatus.Code != 0 {
-		return fmt.Errorf("%s OrderStatus Failed: %v %v", e.GetName(), orderStatus.Code, orderStatus.Message)
+		return exchange.NewApiError(e.GetName(), "OrderStatus", orderStatus.Code, orderStatus.Message, jsonOrderStatus, errorCodes[orderStatus.Code])
 	}
 
 	order.StatusMessage = jsonOrderStatus
@@ -670,7 +768,7 @@ func (e *Okex) CancelOrder(order *exchange.Order) error {
 	if err := json.Unmarshal([]byte(jsonCancelOrder), &cancelOrder); err != nil {
 		return fmt.Errorf("%s CancelOrder Json Unmarshal Err: %v %v", e.GetName(), err, jsonCancelOrder)
 	} else if !cancelOrder.Result {
-		return fmt.Errorf("%s CancelOrder Failed: %v %v", e.GetName(), cancelOrder.Code, cancelOrder.Message)
+		return exchange.NewApiError(e.GetName(), "CancelOrder", cancelOrder.Code, cancelOrder.Message, jsonCancelOrder, errorCodes[cancelOrder.Code])
 	}
 
 	order.Status = exchange.Canceling
@@ -689,36 +787,37 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Okex) ApiKeyRequest(method string, mapParams map[string]interface{}, strRequestPath string) string {
-	TimeStamp := IsoTime()
-
-	jsonParams := ""
+	signer := &exchange.PassphraseSigner{
+		Key:          e.API_KEY,
+		Secret:       e.API_SECRET,
+		Passphrase:   e.Passphrase,
+		HeaderPrefix: "OK-ACCESS-",
+		Timestamp:    exchange.IsoTimestamp,
+		Now:          exchange.GetClock(e.GetName()).Now,
+	}
+	signRequest := &exchange.SignRequest{Method: method, Path: strRequestPath}
 	var bytesParams []byte
 	if mapParams != nil {
 		bytesParams, _ = json.Marshal(mapParams)
-		jsonParams = string(bytesParams)
+		if method != "GET" {
+			signRequest.Body = string(bytesParams)
+		}
 	}
-
-	var strMessage string
-	if method == "GET" {
-		strMessage = TimeStamp + method + strRequestPath
-	} else {
-		strMessage = TimeStamp + method + strRequestPath + jsonParams
+	if err := signer.Sign(signRequest); err != nil {
+		return err.Error()
 	}
-
-	signature := exchange.ComputeHmac256Base64(strMessage, e.API_SECRET)
 	strUrl := API_URL + strRequestPath
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	request, err := http.NewRequest(method, strUrl, bytes.NewReader(bytesParams))
 	if nil != err {
 		return err.Error()
 	}
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json; charset=UTF-8")
-	request.Header.Add("OK-ACCESS-KEY", e.API_KEY)
-	request.Header.Add("OK-ACCESS-SIGN", signature)
-	request.Header.Add("OK-ACCESS-TIMESTAMP", TimeStamp)
-	request.Header.Add("OK-ACCESS-PASSPHRASE", e.Passphrase)
+	for key, value := range signRequest.Header {
+		request.Header.Add(key, value)
+	}
 
 	response, err := httpClient.Do(request)
 	if nil != err {
@@ -734,10 +833,7 @@ func (e *Okex) ApiKeyRequest(method string, mapParams map[string]interface{}, st
 	return string(body)
 }
 
+// IsoTime is the time on the OKEx server clock, as in OK-ACCESS-TIMESTAMP.
 func IsoTime() string {
-	utcTime := time.Now().UTC()
-	iso := utcTime.String()
-	isoBytes := []byte(iso)
-	iso = string(isoBytes[:10]) + "T" + string(isoBytes[11:23]) + "Z"
-	return iso
+	return exchange.IsoTimestamp(exchange.GetClock(exchange.OKEX).Now())
 }
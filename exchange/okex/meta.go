@@ -4,6 +4,10 @@ package okex
 // Distributed under the MIT software license, see the accompanying
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
+import (
+	"github.com/bitontop/gored/exchange"
+)
+
 const (
 	DEFAULT_ID           = 13
 	DEFAULT_TAKER_FEE    = 0.0015
@@ -13,3 +17,29 @@ const (
 	DEFAULT_CONFIRMATION = 2
 	DEFAULT_LISTED       = true
 )
+
+// errorCodes maps the OKEx v3 error codes, and the v5 ones of the account
+// config, to the exchange errors
+var errorCodes = map[int]error{
+	30001: exchange.ErrAuth, // OK-ACCESS-KEY header is required
+	30002: exchange.ErrAuth, // OK-ACCESS-SIGN header is required
+	30003: exchange.ErrAuth, // OK-ACCESS-TIMESTAMP header is required
+	30004: exchange.ErrAuth, // OK-ACCESS-PASSPHRASE header is required
+	30005: exchange.ErrTimestamp,
+	30006: exchange.ErrAuth, // invalid OK-ACCESS-KEY
+	30008: exchange.ErrTimestamp,
+	30010: exchange.ErrAuth, // API validation failed
+	30012: exchange.ErrAuth, // invalid authorization
+	30013: exchange.ErrAuth, // invalid sign
+	30014: exchange.ErrRateLimited,
+	30015: exchange.ErrAuth, // invalid OK-ACCESS-PASSPHRASE
+	30026: exchange.ErrRateLimited,
+	33014: exchange.ErrOrderNotFound,
+	33017: exchange.ErrInsufficientFunds,
+	33026: exchange.ErrOrderNotFound, // the order is completed
+	33027: exchange.ErrOrderNotFound, // the order is cancelled or cancelling
+	50102: exchange.ErrTimestamp,     // the v5 codes, of the account config
+	50105: exchange.ErrAuth,          // invalid OK-ACCESS-PASSPHRASE
+	50111: exchange.ErrAuth,          // invalid OK-ACCESS-KEY
+	50113: exchange.ErrAuth,          // invalid sign
+}
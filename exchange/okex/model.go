@@ -13,6 +13,32 @@ type ErrorMsg struct {
 	Msg  string `json:"message"`
 }
 
+// AccountConfig is the reply of the v5 account config, the only OKEx endpoint
+// listing the permissions of the API key, signed as v3.
+type AccountConfig struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		Uid    string `json:"uid"`
+		AcctLv string `json:"acctLv"` // 1 for the simple spot account
+		Label  string `json:"label"`
+		Perm   string `json:"perm"` // read_only,trade,withdraw
+		IP     string `json:"ip"`   // comma separated
+	} `json:"data"`
+}
+
+type TradeFee struct {
+	Category  string `json:"category"`
+	Maker     string `json:"maker"`
+	Taker     string `json:"taker"`
+	Timestamp string `json:"timestamp"`
+}
+
+type ServerTime struct {
+	Iso   string `json:"iso"`
+	Epoch string `json:"epoch"`
+}
+
 type CoinsData []struct {
 	CanDeposit    string `json:"can_deposit"`
 	CanWithdraw   string `json:"can_withdraw"`
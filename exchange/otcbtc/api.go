@@ -163,6 +163,8 @@ func (e *Otcbtc) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     data.TradingRule.MinAmount,
 				PriceFilter: data.TradingRule.MinPrice,
+				MinQty:      data.TradingRule.MinAmount,
+				MinNotional: data.MinimalTotalVolume,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -293,6 +295,11 @@ func (e *Otcbtc) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	errResponse := &ErrorResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v2/orders"
@@ -330,6 +337,11 @@ func (e *Otcbtc) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	errResponse := &ErrorResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v2/orders"
@@ -442,7 +454,7 @@ func (e *Otcbtc) ApiKeyGET(mapParams map[string]string, strRequestPath string) s
 	strUrl := API_URL + strRequestPath
 
 	mapParams["access_key"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond))
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Millisecond).Next())
 	payload := fmt.Sprintf("%s|%s|%s", strMethod, strRequestPath, exchange.Map2UrlQuery(mapParams))
 
 	mapParams["signature"] = exchange.ComputeHmac256NoDecode(payload, e.API_SECRET)
@@ -455,12 +467,12 @@ func (e *Otcbtc) ApiKeyPost(strRequestPath string, mapParams map[string]string)
 	strUrl := API_URL + strRequestPath
 
 	mapParams["access_key"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond))
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Millisecond).Next())
 	payload := fmt.Sprintf("%s|%s|%s", strMethod, strRequestPath, exchange.Map2UrlQuery(mapParams))
 
 	mapParams["signature"] = exchange.ComputeHmac256NoDecode(payload, e.API_SECRET)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("POST", strUrl, strings.NewReader(exchange.Map2UrlQuery(mapParams)))
 	if err != nil {
@@ -0,0 +1,210 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const DEFAULT_PARALLEL = 4
+
+// RefreshStatus is the refresh state of one exchange in the Scheduler.
+type RefreshStatus struct {
+	ExName      ExchangeName
+	Interval    time.Duration // 0 means only refreshed on demand
+	LastRefresh time.Time
+	LastSuccess time.Time
+	LastError   error
+	ErrorCount  int // failed refreshes since the Scheduler is created
+	Running     bool
+}
+
+// Scheduler refreshes the data of the exchanges by UpdateConstraint, each exchange on
+// its own interval or on demand, at most Parallel exchanges at the same time.
+// UpdateConstraint swaps in the new constraints only when the whole refresh succeeded,
+// the swap publishes the changes to the ExchangeManager subscribers.
+type Scheduler struct {
+	// OnRefresh is called after each successful refresh, in its goroutine.
+	// Set it before Start.
+	OnRefresh func(name ExchangeName)
+
+	exMan    *ExchangeManager
+	parallel chan struct{}
+
+	mutex    sync.Mutex
+	statuses map[ExchangeName]*RefreshStatus
+	pending  map[ExchangeName]bool
+	stop     chan struct{}
+	stopped  bool // set by Stop, no refresh is started afterwards
+	wg       sync.WaitGroup
+}
+
+func (e *ExchangeManager) CreateScheduler(parallel int) *Scheduler {
+	if parallel <= 0 {
+		parallel = DEFAULT_PARALLEL
+	}
+	return &Scheduler{
+		exMan:    e,
+		parallel: make(chan struct{}, parallel),
+		statuses: make(map[ExchangeName]*RefreshStatus),
+		pending:  make(map[ExchangeName]bool),
+	}
+}
+
+// Schedule sets the refresh interval of the exchange, 0 refreshes it only on demand.
+// The new interval takes effect on the next Start.
+func (s *Scheduler) Schedule(name ExchangeName, interval time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if status, ok := s.statuses[name]; ok {
+		status.Interval = interval
+	} else {
+		s.statuses[name] = &RefreshStatus{ExName: name, Interval: interval}
+	}
+}
+
+// Start begins the timed refreshes. Calling Start on a started or stopped
+// Scheduler does nothing.
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stop != nil || s.stopped {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	for name, status := range s.statuses {
+		if status.Interval > 0 {
+			s.wg.Add(1)
+			go s.tick(name, status.Interval, s.stop)
+		}
+	}
+}
+
+// Stop ends the timed refreshes and waits for the running ones to finish.
+// The Scheduler refuses any refresh afterwards.
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	s.stopped = true
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Scheduler) tick(name ExchangeName, interval time.Duration, stop chan struct{}) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.Refresh(name)
+		}
+	}
+}
+
+// Refresh queues an on-demand refresh of the exchanges and returns at once.
+// An exchange already queued or running is not queued again, nothing is
+// queued once the Scheduler is stopped.
+func (s *Scheduler) Refresh(names ...ExchangeName) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, name := range names {
+		if s.stopped || s.pending[name] {
+			continue
+		}
+		s.pending[name] = true
+
+		s.wg.Add(1)
+		go func(name ExchangeName) {
+			defer s.wg.Done()
+			s.run(name, true)
+		}(name)
+	}
+}
+
+// RefreshNow refreshes the exchange and waits for the result.
+// It fails once the Scheduler is stopped.
+func (s *Scheduler) RefreshNow(name ExchangeName) error {
+	s.mutex.Lock()
+	if s.stopped {
+		s.mutex.Unlock()
+		return fmt.Errorf("%s Refresh: the Scheduler is stopped.", name)
+	}
+	s.wg.Add(1)
+	s.mutex.Unlock()
+	defer s.wg.Done()
+
+	return s.run(name, false)
+}
+
+// run refreshes the exchange and records the result, queued is true when the
+// refresh was queued by Refresh and the exchange is pending.
+func (s *Scheduler) run(name ExchangeName, queued bool) error {
+	s.parallel <- struct{}{}
+	defer func() { <-s.parallel }()
+
+	s.mutex.Lock()
+	status, ok := s.statuses[name]
+	if !ok {
+		status = &RefreshStatus{ExName: name}
+		s.statuses[name] = status
+	}
+	status.Running = true
+	s.mutex.Unlock()
+
+	err := s.refresh(name)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if queued {
+		delete(s.pending, name)
+	}
+	status.Running = false
+	status.LastRefresh = time.Now()
+	status.LastError = err
+	if err != nil {
+		status.ErrorCount++
+	} else {
+		status.LastSuccess = status.LastRefresh
+	}
+	return err
+}
+
+func (s *Scheduler) refresh(name ExchangeName) error {
+	eInstance := s.exMan.Get(name)
+	if eInstance == nil {
+		return fmt.Errorf("%s is not added to ExchangeManager.", name)
+	}
+
+	if err := eInstance.UpdateConstraint(); err != nil {
+		log.Printf("Updating %s Data is failed: %v", name, err)
+		return err
+	}
+	log.Printf("%s Data Updated. Coin: %d   Pair: %d", name, len(eInstance.GetCoins()), len(eInstance.GetPairs()))
+	if s.OnRefresh != nil {
+		s.OnRefresh(name)
+	}
+	return nil
+}
+
+// Status returns a copy of the refresh state of the exchange.
+func (s *Scheduler) Status(name ExchangeName) RefreshStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if status, ok := s.statuses[name]; ok {
+		return *status
+	}
+	return RefreshStatus{ExName: name}
+}
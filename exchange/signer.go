@@ -0,0 +1,255 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SignRequest is a private request to authenticate. The adapter fills the
+// method, path and parameters, the Signer adds the key, the timestamp or nonce
+// and the signature, and the adapter sends the Query and the Header it gets.
+type SignRequest struct {
+	Method string
+	Host   string // signed by Huobi
+	Path   string // with the leading "/"
+	// Query is the encoded query or form, sent in this order. When Params is
+	// set, the Signer adds its parameters to Params and encodes them sorted
+	// into Query instead.
+	Query  string
+	Params map[string]string
+	Body   string // the JSON body
+	Header map[string]string
+}
+
+// Signer authenticates the private requests of one signature scheme. Binance,
+// Huobi, OKEx, Kucoin, Kraken, BitMart and BitMEX sign by a Signer, the other
+// adapters keep their own signing in ApiKeyGet or ApiKeyRequest.
+type Signer interface {
+	Sign(request *SignRequest) error
+}
+
+func (r *SignRequest) setHeader(key, value string) {
+	if r.Header == nil {
+		r.Header = make(map[string]string)
+	}
+	r.Header[key] = value
+}
+
+func now(clock func() time.Time) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock()
+}
+
+// HmacQuerySigner appends recvWindow and the timestamp in ms to the query, then
+// the hex HMAC-SHA256 of the query and the body, as Binance.
+type HmacQuerySigner struct {
+	Key        string
+	Secret     string
+	KeyHeader  string // e.g. X-MBX-APIKEY
+	RecvWindow int64  // ms, not sent if 0
+	Now        func() time.Time
+}
+
+func (s *HmacQuerySigner) Sign(r *SignRequest) error {
+	if r.Params != nil {
+		r.Query = Map2UrlQuery(r.Params)
+	}
+	if s.RecvWindow > 0 {
+		r.Query = appendQuery(r.Query, fmt.Sprintf("recvWindow=%d", s.RecvWindow))
+	}
+	r.Query = appendQuery(r.Query, fmt.Sprintf("timestamp=%d", now(s.Now).UnixNano()/int64(time.Millisecond)))
+	signature := ComputeHmac256NoDecode(r.Query+r.Body, s.Secret)
+	r.Query = appendQuery(r.Query, "signature="+signature)
+	r.setHeader(s.KeyHeader, s.Key)
+	return nil
+}
+
+func appendQuery(query, param string) string {
+	if query == "" {
+		return param
+	}
+	return query + "&" + param
+}
+
+// CanonicalSigner adds the key, the signature version 2 and the UTC timestamp
+// to the parameters, and signs the method, host, path and sorted parameters
+// lines with base64 HMAC-SHA256, as Huobi.
+type CanonicalSigner struct {
+	Key    string
+	Secret string
+	Now    func() time.Time
+}
+
+func (s *CanonicalSigner) Sign(r *SignRequest) error {
+	if r.Params == nil {
+		r.Params = make(map[string]string)
+	}
+	r.Params["AccessKeyId"] = s.Key
+	r.Params["SignatureMethod"] = "HmacSHA256"
+	r.Params["SignatureVersion"] = "2"
+	r.Params["Timestamp"] = now(s.Now).UTC().Format("2006-01-02T15:04:05")
+	delete(r.Params, "Signature")
+
+	payload := r.Method + "\n" + r.Host + "\n" + r.Path + "\n" + Map2UrlQueryUrl(r.Params)
+	r.Params["Signature"] = ComputeHmac256Base64(payload, s.Secret)
+	r.Query = Map2UrlQueryUrl(r.Params)
+	return nil
+}
+
+// PassphraseSigner signs the timestamp, method, path with the query and the
+// body with base64 HMAC-SHA256, and sends them in the headers with the key and
+// the passphrase, as OKEx (OK-ACCESS-) and Kucoin (KC-API-).
+type PassphraseSigner struct {
+	Key          string
+	Secret       string
+	Passphrase   string
+	HeaderPrefix string // the headers are <prefix>KEY, SIGN, TIMESTAMP and PASSPHRASE
+	// Timestamp formats the time, the ms since the epoch if nil
+	Timestamp func(t time.Time) string
+	Now       func() time.Time
+}
+
+// IsoTimestamp is the timestamp of OKEx, e.g. 2019-03-08T10:59:25.789Z.
+func IsoTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func (s *PassphraseSigner) Sign(r *SignRequest) error {
+	if r.Params != nil {
+		r.Query = Map2UrlQuery(r.Params)
+	}
+	t := now(s.Now)
+	timestamp := fmt.Sprintf("%d", t.UnixNano()/int64(time.Millisecond))
+	if s.Timestamp != nil {
+		timestamp = s.Timestamp(t)
+	}
+	path := r.Path
+	if r.Query != "" {
+		path += "?" + r.Query
+	}
+
+	r.setHeader(s.HeaderPrefix+"KEY", s.Key)
+	r.setHeader(s.HeaderPrefix+"SIGN", ComputeHmac256Base64(timestamp+r.Method+path+r.Body, s.Secret))
+	r.setHeader(s.HeaderPrefix+"TIMESTAMP", timestamp)
+	r.setHeader(s.HeaderPrefix+"PASSPHRASE", s.Passphrase)
+	return nil
+}
+
+// NonceSigner adds the nonce to the parameters, and signs the path and the
+// SHA256 of the nonce and the form with HMAC-SHA512 keyed by the base64 decoded
+// secret, as Kraken.
+type NonceSigner struct {
+	Key    string
+	Secret string // base64
+	Nonce  func() int64
+}
+
+func (s *NonceSigner) Sign(r *SignRequest) error {
+	secret, err := base64.StdEncoding.DecodeString(s.Secret)
+	if err != nil {
+		return fmt.Errorf("Secret Key Decode Err: %v", err)
+	}
+	if r.Params == nil {
+		r.Params = make(map[string]string)
+	}
+	nonce := fmt.Sprintf("%d", s.Nonce())
+	r.Params["nonce"] = nonce
+	r.Query = Map2UrlQueryUrl(r.Params)
+
+	shaSum := sha256.Sum256([]byte(nonce + r.Query))
+	mac := hmac.New(sha512.New, secret)
+	mac.Write(append([]byte(r.Path), shaSum[:]...))
+	macSum := mac.Sum(nil)
+	r.setHeader("API-Key", s.Key)
+	r.setHeader("API-Sign", base64.StdEncoding.EncodeToString(macSum))
+	return nil
+}
+
+// BearerSigner sends the access token got with the client credentials, kept
+// until it expires, the timestamp in ms and, but for GET, the hex HMAC-SHA256
+// of the query, the sorted and escaped parameters, as BitMart.
+type BearerSigner struct {
+	Key    string
+	Secret string
+	Memo   string
+	// Token gets a new access token for the key and the ClientSecret, and its lifetime
+	Token func(key, clientSecret string) (string, time.Duration, error)
+	Now   func() time.Time
+
+	mutex   sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// ClientSecret is the hex HMAC-SHA256 of key:secret:memo, exchanged for the token.
+func (s *BearerSigner) ClientSecret() string {
+	return ComputeHmac256NoDecode(s.Key+":"+s.Secret+":"+s.Memo, s.Secret)
+}
+
+func (s *BearerSigner) accessToken() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.token != "" && now(s.Now).Before(s.expires) {
+		return s.token, nil
+	}
+	token, lifetime, err := s.Token(s.Key, s.ClientSecret())
+	if err != nil {
+		return "", err
+	}
+	// renewed a minute early, not to send a token expiring on the way
+	s.token, s.expires = token, now(s.Now).Add(lifetime-time.Minute)
+	return token, nil
+}
+
+func (s *BearerSigner) Sign(r *SignRequest) error {
+	token, err := s.accessToken()
+	if err != nil {
+		return err
+	}
+	if r.Params != nil {
+		r.Query = Map2UrlQueryUrl(r.Params)
+		if r.Method != "GET" {
+			r.setHeader("X-BM-SIGNATURE", ComputeHmac256NoDecode(r.Query, s.Secret))
+		}
+	}
+	r.setHeader("X-BM-TIMESTAMP", fmt.Sprintf("%d", now(s.Now).UnixNano()/int64(time.Millisecond)))
+	r.setHeader("X-BM-AUTHORIZATION", "Bearer "+token)
+	return nil
+}
+
+// ExpiresSigner sends the unix time the request expires, Lifetime from now, and
+// the hex HMAC-SHA256 of the method, the path with the query, the expiry and the
+// body, as BitMEX.
+type ExpiresSigner struct {
+	Key      string
+	Secret   string
+	Lifetime time.Duration
+	Now      func() time.Time
+}
+
+func (s *ExpiresSigner) Sign(r *SignRequest) error {
+	if r.Params != nil {
+		r.Query = Map2UrlQuery(r.Params)
+	}
+	path := r.Path
+	if r.Query != "" {
+		path += "?" + r.Query
+	}
+	expires := fmt.Sprintf("%d", now(s.Now).Add(s.Lifetime).Unix())
+
+	r.setHeader("api-expires", expires)
+	r.setHeader("api-key", s.Key)
+	r.setHeader("api-signature", ComputeHmac256NoDecode(r.Method+path+expires+r.Body, s.Secret))
+	return nil
+}
@@ -139,6 +139,7 @@ func (e *Stex) GetPairsData() error {
 		if p != nil && !data.Delisted {
 			makerFee, _ := strconv.ParseFloat(data.BuyFeePercent, 64)
 			takerFee, _ := strconv.ParseFloat(data.SellFeePercent, 64)
+			minNotional, _ := strconv.ParseFloat(data.MinOrderAmount, 64) // in the market coin
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -148,6 +149,7 @@ func (e *Stex) GetPairsData() error {
 				TakerFee:    takerFee / 100,
 				LotSize:     math.Pow10(data.CurrencyPrecision * -1),
 				PriceFilter: math.Pow10(data.MarketPrecision * -1),
+				MinNotional: minNotional,
 				Listed:      !data.Delisted,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -441,6 +443,11 @@ func (e *Stex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	jsonResponse := JsonResponseV3{}
 
@@ -479,6 +486,11 @@ func (e *Stex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	jsonResponse := JsonResponseV3{}
 
@@ -604,7 +616,7 @@ func (e *Stex) ApiKeyGet(mapParams map[string]string, strRequestPath string) str
 
 	// log.Printf("request: %+v", request)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -622,7 +634,7 @@ func (e *Stex) ApiKeyGet(mapParams map[string]string, strRequestPath string) str
 }
 
 func (e *Stex) ApiKeyRequest(method string, mapParams map[string]interface{}, strRequestPath string) string {
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	var bytesParams []byte
 	if mapParams != nil {
@@ -51,18 +51,9 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestPath)*/
 func (e *Switcheo) GetCoinsData() error {
-	coinsData := CoinsData{}
-
-	strRequestPath := "/v2/exchange/tokens"
-	strUrl := API_URL + strRequestPath
-
-	mapParams := make(map[string]string)
-	mapParams["show_listing_details"] = "1"
-	mapParams["show_inactive"] = "1"
-
-	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, mapParams)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
-		return fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	coinsData, err := e.getTokens()
+	if err != nil {
+		return err
 	}
 
 	for _, data := range coinsData {
@@ -111,6 +102,24 @@ func (e *Switcheo) GetCoinsData() error {
 	return nil
 }
 
+/*The tokens, with the minimum order quantities*/
+func (e *Switcheo) getTokens() (CoinsData, error) {
+	coinsData := CoinsData{}
+
+	strRequestPath := "/v2/exchange/tokens"
+	strUrl := API_URL + strRequestPath
+
+	mapParams := make(map[string]string)
+	mapParams["show_listing_details"] = "1"
+	mapParams["show_inactive"] = "1"
+
+	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, mapParams)
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	}
+	return coinsData, nil
+}
+
 /* GetPairsData - Get Pairs Information (If API provide)
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -128,6 +137,16 @@ func (e *Switcheo) GetPairsData() error {
 	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &pairsData); err != nil {
 		return fmt.Errorf("%s Get Pairs Json Unmarshal Err: %v %v", e.GetName(), err, jsonSymbolsReturn)
 	}
+	// the minimum order quantities are listed by token, in its smallest unit
+	tokens, err := e.getTokens()
+	if err != nil {
+		return err
+	}
+	minQtys := make(map[string]float64)
+	for _, data := range tokens {
+		minQuantity, _ := strconv.ParseFloat(data.MinimumQuantity, 64)
+		minQtys[data.Symbol] = minQuantity * math.Pow10(-1*data.Decimals)
+	}
 
 	for _, data := range pairsData {
 		p := &pair.Pair{}
@@ -151,6 +170,7 @@ func (e *Switcheo) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     DEFAULT_LOT_SIZE,
 				PriceFilter: priceFilter,
+				MinQty:      minQtys[data.BaseAssetSymbol],
 				Listed:      true,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -297,6 +317,11 @@ func (e *Switcheo) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -335,6 +360,11 @@ func (e *Switcheo) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -468,7 +498,7 @@ func (e *Switcheo) ApiKeyGet(strRequestPath string, mapParams map[string]string)
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -503,7 +533,7 @@ func (e *Switcheo) ApiKeyRequest(strMethod, strRequestPath string, mapParams map
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
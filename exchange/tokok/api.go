@@ -145,6 +145,8 @@ func (e *Tokok) GetPairsData() error {
 			p = e.GetPairBySymbol(data.Symbol)
 		}
 		if p != nil {
+			minQty, _ := strconv.ParseFloat(data.MinOrderAmount, 64)
+			maxQty, _ := strconv.ParseFloat(data.MaxOrderAmount, 64)
 			pairConstraint := &exchange.PairConstraint{
 				PairID:      p.ID,
 				Pair:        p,
@@ -153,6 +155,8 @@ func (e *Tokok) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     math.Pow10(-1 * data.BaseAssetPrecision),
 				PriceFilter: math.Pow10(-1 * data.QuoteAssetPrecision),
+				MinQty:      minQty,
+				MaxQty:      maxQty,
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -271,6 +275,11 @@ func (e *Tokok) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := JsonResponse{}
 	placeOrder := ""
 	strRequest := "/trade"
@@ -314,6 +323,11 @@ func (e *Tokok) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := JsonResponse{}
 	placeOrder := ""
 	strRequest := "/trade"
@@ -464,7 +478,7 @@ func (e *Tokok) ApiKeyRequest(strMethod string, mapParams map[string]interface{}
 	// log.Printf("====mapParams: %+v", mapParams)
 	// log.Printf("====createSign: %v", createSign)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -518,7 +532,7 @@ func (e *Tokok) ApiKeyRequest(strMethod string, mapParams map[string]interface{}
 	log.Printf("====mapParams: %+v", mapParams)
 	log.Printf("====createSign: %v", createSign)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -535,12 +549,12 @@ func (e *Tokok) ApiKeyRequest(strMethod string, mapParams map[string]interface{}
 
 func (e *Tokok) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
@@ -0,0 +1,53 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TOTP_PERIOD = 30 * time.Second
+	TOTP_DIGITS = 6
+)
+
+// TOTP is the RFC 6238 code at the time of the base32 seed given when the two
+// factor authentication was set up, the one of the authenticator apps: 6 digits
+// for 30 s, with HMAC-SHA1.
+func TOTP(seed string, t time.Time) (string, error) {
+	seed = strings.ToUpper(strings.Replace(strings.TrimRight(seed, "="), " ", "", -1))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(seed)
+	if err != nil {
+		return "", fmt.Errorf("Two Factor Seed Decode Err: %v", err)
+	} else if len(key) == 0 {
+		return "", fmt.Errorf("Two Factor Seed is nil")
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(TOTP_PERIOD/time.Second)))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// the dynamic truncation of RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < TOTP_DIGITS; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTP_DIGITS, code%modulo), nil
+}
+
+// TwoFactorCode is the current TOTP code of the seed in Config.Two_Factor.
+func TwoFactorCode(seed string) (string, error) {
+	return TOTP(seed, time.Now())
+}
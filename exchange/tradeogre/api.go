@@ -286,6 +286,11 @@ func (e *Tradeogre) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchang
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/order/sell"
 
@@ -319,6 +324,11 @@ func (e *Tradeogre) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/order/buy"
 
@@ -445,7 +455,7 @@ func (e *Tradeogre) ApiKeyRequest(strMethod, strRequestPath string, mapParams ma
 		return exchange.HttpGetRequest(strUrl, mapParams)
 	}
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	req, err := http.NewRequest(strMethod, strUrl, strings.NewReader(exchange.Map2UrlQuery(mapParams)))
 	if err != nil {
 		return err.Error()
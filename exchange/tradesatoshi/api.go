@@ -55,20 +55,9 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *TradeSatoshi) GetCoinsData() error {
-	jsonResponse := &JsonResponse{}
-	coinsData := CoinsData{}
-
-	strRequestUrl := "/public/getcurrencies"
-	strUrl := API_URL + strRequestUrl
-
-	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
-		return fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
-	} else if !jsonResponse.Success {
-		return fmt.Errorf("%s Get Coins Failed: %v", e.GetName(), jsonResponse.Message)
-	}
-	if err := json.Unmarshal(jsonResponse.Result, &coinsData); err != nil {
-		return fmt.Errorf("%s Get Coins Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+	coinsData, err := e.getCurrencies()
+	if err != nil {
+		return err
 	}
 
 	for _, data := range coinsData {
@@ -104,6 +93,26 @@ func (e *TradeSatoshi) GetCoinsData() error {
 	return nil
 }
 
+/*The currencies, with the minimum trades in the base coin*/
+func (e *TradeSatoshi) getCurrencies() (CoinsData, error) {
+	jsonResponse := &JsonResponse{}
+	coinsData := CoinsData{}
+
+	strRequestUrl := "/public/getcurrencies"
+	strUrl := API_URL + strRequestUrl
+
+	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	} else if !jsonResponse.Success {
+		return nil, fmt.Errorf("%s Get Coins Failed: %v", e.GetName(), jsonResponse.Message)
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &coinsData); err != nil {
+		return nil, fmt.Errorf("%s Get Coins Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+	}
+	return coinsData, nil
+}
+
 /* GetPairsData - Get Pairs Information (If API provide)
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -124,6 +133,15 @@ func (e *TradeSatoshi) GetPairsData() error {
 	if err := json.Unmarshal(jsonResponse.Result, &pairsData); err != nil {
 		return fmt.Errorf("%s Get Pairs Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
 	}
+	// the minimum trades are listed by the base coin
+	currencies, err := e.getCurrencies()
+	if err != nil {
+		return err
+	}
+	minBaseTrades := make(map[string]float64)
+	for _, data := range currencies {
+		minBaseTrades[data.Currency] = data.MinBaseTrade
+	}
 
 	for _, data := range pairsData {
 		coinStrs := strings.Split(data.Market, "_")
@@ -147,6 +165,7 @@ func (e *TradeSatoshi) GetPairsData() error {
 				TakerFee:    DEFAULT_TAKER_FEE,
 				LotSize:     DEFAULT_LOT_SIZE,
 				PriceFilter: DEFAULT_PRICE_FILTER,
+				MinNotional: minBaseTrades[coinStrs[1]],
 				Listed:      DEFAULT_LISTED,
 			}
 			e.SetPairConstraint(pairConstraint)
@@ -334,6 +353,11 @@ func (e *TradeSatoshi) LimitSell(pair *pair.Pair, quantity, rate float64) (*exch
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Sell", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/private/submitorder"
@@ -372,6 +396,11 @@ func (e *TradeSatoshi) LimitBuy(pair *pair.Pair, quantity, rate float64) (*excha
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	rate, quantity, err := exchange.ValidateOrder(e, pair, "Buy", rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/private/submitorder"
@@ -511,7 +540,7 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *TradeSatoshi) ApiKeyPost(strRequestPath string, mapParams map[string]interface{}) string {
 	strUrl := API_URL + strRequestPath
-	NONCE := time.Now().UnixNano()
+	NONCE := exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next()
 
 	var bytesParams []byte
 	if nil != mapParams {
@@ -528,7 +557,7 @@ func (e *TradeSatoshi) ApiKeyPost(strRequestPath string, mapParams map[string]in
 	request.Header.Add("Content-Type", "application/json; charset=utf-8")
 	request.Header.Add("Authorization", authorization)
 
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
@@ -545,12 +574,12 @@ func (e *TradeSatoshi) ApiKeyPost(strRequestPath string, mapParams map[string]in
 
 func (e *TradeSatoshi) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
 	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
+	mapParams["nonce"] = fmt.Sprintf("%d", exchange.GetNonce(e.GetName(), e.API_KEY, time.Nanosecond).Next())
 
 	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 
 	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+	httpClient := exchange.NewHttpClient()
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
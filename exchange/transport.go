@@ -0,0 +1,35 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"net/http"
+	"sync"
+)
+
+var httpTransport http.RoundTripper
+var transportMutex sync.RWMutex
+
+// SetHttpTransport sets the transport of every HTTP request made by the
+// adapters, e.g. to record or replay them in tests. nil restores the default.
+func SetHttpTransport(transport http.RoundTripper) {
+	transportMutex.Lock()
+	defer transportMutex.Unlock()
+	httpTransport = transport
+}
+
+func GetHttpTransport() http.RoundTripper {
+	transportMutex.RLock()
+	defer transportMutex.RUnlock()
+	if httpTransport == nil {
+		return http.DefaultTransport
+	}
+	return httpTransport
+}
+
+// NewHttpClient returns a client on the transport set by SetHttpTransport.
+func NewHttpClient() *http.Client {
+	return &http.Client{Transport: GetHttpTransport()}
+}
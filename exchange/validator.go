@@ -0,0 +1,168 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/pair"
+)
+
+type Rounding string
+
+const (
+	FLOOR Rounding = "FLOOR"
+	CEIL  Rounding = "CEIL"
+)
+
+type OrderErrorType string
+
+const (
+	INVALID_PAIR         OrderErrorType = "INVALID_PAIR"
+	INVALID_SIDE         OrderErrorType = "INVALID_SIDE"
+	INVALID_PRICE        OrderErrorType = "INVALID_PRICE"
+	INVALID_QUANTITY     OrderErrorType = "INVALID_QUANTITY"
+	MIN_QUANTITY         OrderErrorType = "MIN_QUANTITY"
+	MAX_QUANTITY         OrderErrorType = "MAX_QUANTITY"
+	MIN_NOTIONAL         OrderErrorType = "MIN_NOTIONAL"
+	INSUFFICIENT_BALANCE OrderErrorType = "INSUFFICIENT_BALANCE"
+)
+
+// OrderError is an order rejected before it is sent. Rate and Quantity are
+// after the rounding, Limit is the minimum or the balance the order missed.
+type OrderError struct {
+	ExName   ExchangeName
+	Pair     *pair.Pair
+	Side     string
+	Type     OrderErrorType
+	Rate     float64
+	Quantity float64
+	Limit    float64
+}
+
+func (err *OrderError) Error() string {
+	pairName := ""
+	if err.Pair != nil {
+		pairName = err.Pair.Name
+	}
+	switch err.Type {
+	case MIN_QUANTITY:
+		return fmt.Sprintf("%s %s %s order rejected: %s quantity %v is below the minimum %v", err.ExName, err.Side, pairName, err.Type, err.Quantity, err.Limit)
+	case MAX_QUANTITY:
+		return fmt.Sprintf("%s %s %s order rejected: %s quantity %v is above the maximum %v", err.ExName, err.Side, pairName, err.Type, err.Quantity, err.Limit)
+	case MIN_NOTIONAL:
+		return fmt.Sprintf("%s %s %s order rejected: %s amount %v is below the minimum %v", err.ExName, err.Side, pairName, err.Type, err.Rate*err.Quantity, err.Limit)
+	case INSUFFICIENT_BALANCE:
+		return fmt.Sprintf("%s %s %s order rejected: %s balance %v for %v @ %v", err.ExName, err.Side, pairName, err.Type, err.Limit, err.Quantity, err.Rate)
+	}
+	return fmt.Sprintf("%s %s %s order rejected: %s %v @ %v", err.ExName, err.Side, pairName, err.Type, err.Quantity, err.Rate)
+}
+
+// OrderRequest is a limit order to validate. The rate is snapped to the
+// pair's PriceFilter, by default down for a buy and up for a sell so it is
+// never worse than asked, and the quantity to its LotSize, by default down so
+// it never needs more than asked.
+type OrderRequest struct {
+	Pair             *pair.Pair
+	Side             string // "Buy" or "Sell"
+	Rate             float64
+	Quantity         float64
+	PriceRounding    Rounding
+	QuantityRounding Rounding
+	SkipBalance      bool // skip the check of GetBalance, e.g. before UpdateAllBalances
+}
+
+// ValidateOrder validates the limit order with the default rounding, and
+// returns the rate and quantity to send. Every LimitBuy and LimitSell calls it
+// before the signed request.
+func ValidateOrder(e Exchange, p *pair.Pair, side string, rate, quantity float64) (float64, float64, error) {
+	request := &OrderRequest{Pair: p, Side: side, Rate: rate, Quantity: quantity}
+	return request.Validate(e)
+}
+
+// Validate snaps the rate and quantity to the pair constraint, then checks the
+// minimum and maximum quantity, the minimum notional and the available
+// balance, unless the balances were never fetched. The error is an *OrderError.
+func (r *OrderRequest) Validate(e Exchange) (float64, float64, error) {
+	reject := func(errType OrderErrorType, rate, quantity, limit float64) (float64, float64, error) {
+		return 0, 0, &OrderError{ExName: e.GetName(), Pair: r.Pair, Side: r.Side, Type: errType, Rate: rate, Quantity: quantity, Limit: limit}
+	}
+
+	var pc *PairConstraint
+	if r.Pair != nil {
+		pc = e.GetPairConstraint(r.Pair)
+	}
+	if pc == nil {
+		return reject(INVALID_PAIR, r.Rate, r.Quantity, 0)
+	}
+	priceRounding, quantityRounding := r.PriceRounding, r.QuantityRounding
+	switch r.Side {
+	case "Buy":
+		if priceRounding == "" {
+			priceRounding = FLOOR
+		}
+	case "Sell":
+		if priceRounding == "" {
+			priceRounding = CEIL
+		}
+	default:
+		return reject(INVALID_SIDE, r.Rate, r.Quantity, 0)
+	}
+	if quantityRounding == "" {
+		quantityRounding = FLOOR
+	}
+	if !(r.Rate > 0) || math.IsInf(r.Rate, 0) {
+		return reject(INVALID_PRICE, r.Rate, r.Quantity, 0)
+	}
+	if !(r.Quantity > 0) || math.IsInf(r.Quantity, 0) {
+		return reject(INVALID_QUANTITY, r.Rate, r.Quantity, 0)
+	}
+
+	rate := snap(r.Rate, pc.PriceFilter, priceRounding)
+	quantity := snap(r.Quantity, pc.LotSize, quantityRounding)
+	if rate <= 0 {
+		return reject(INVALID_PRICE, rate, quantity, pc.PriceFilter)
+	}
+	minQty := math.Max(pc.MinQty, pc.LotSize)
+	if quantity <= 0 || quantity < minQty {
+		return reject(MIN_QUANTITY, rate, quantity, minQty)
+	}
+	if pc.MaxQty > 0 && quantity > pc.MaxQty {
+		return reject(MAX_QUANTITY, rate, quantity, pc.MaxQty)
+	}
+	amount := decimal.NewFromFloat(rate).Mul(decimal.NewFromFloat(quantity)).Float64()
+	if amount < pc.MinNotional {
+		return reject(MIN_NOTIONAL, rate, quantity, pc.MinNotional)
+	}
+
+	skipBalance := r.SkipBalance
+	if balances := getBalances(e.GetName()); balances != nil && !balances.Loaded() {
+		skipBalance = true
+	}
+	if !skipBalance {
+		balance, need := e.GetBalance(r.Pair.Target), quantity
+		if r.Side == "Buy" {
+			balance, need = e.GetBalance(r.Pair.Base), amount
+		}
+		if balance < need {
+			return reject(INSUFFICIENT_BALANCE, rate, quantity, balance)
+		}
+	}
+	return rate, quantity, nil
+}
+
+// snap rounds the value to a multiple of the step, exactly.
+func snap(value, step float64, rounding Rounding) float64 {
+	if step <= 0 {
+		return value
+	}
+	d, stepDecimal := decimal.NewFromFloat(value), decimal.NewFromFloat(step)
+	if rounding == CEIL {
+		return d.Ceil(stepDecimal).Float64()
+	}
+	return d.Floor(stepDecimal).Float64()
+}
@@ -70,6 +70,7 @@ import (
 	"github.com/bitontop/gored/exchange/txbit"
 	"github.com/bitontop/gored/exchange/virgocx"
 	"github.com/bitontop/gored/exchange/zebitex"
+	"github.com/bitontop/gored/microservice"
 	"github.com/bitontop/gored/pair"
 	"github.com/bitontop/gored/test/conf"
 	"github.com/bitontop/gored/utils"
@@ -81,13 +82,32 @@ func main() {
 
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
-		/* case "export":
-		Init(exchange.EXCHANGE_API, "")
-		utils.ConvertBaseDataToJson("./data")
-		for _, ex := range exMan.GetExchanges() {
-			utils.ConvertExchangeDataToJson("./data", ex)
-		}
-		break */
+		case "export":
+			Init(exchange.EXCHANGE_API, "./data")
+			for _, ex := range exMan.GetExchanges() {
+				if err := utils.ConvertExchangeDataToJson("./data", ex); err != nil {
+					log.Printf("%v", err)
+				} else {
+					log.Printf("%s Data Exported. Coin: %d   Pair: %d", ex.GetName(), len(ex.GetCoins()), len(ex.GetPairs()))
+				}
+			}
+			if err := utils.ConvertBaseDataToJson("./data"); err != nil {
+				log.Printf("%v", err)
+			}
+			break
+		case "diff":
+			Init(exchange.EXCHANGE_API, "./data")
+			for _, ex := range exMan.GetExchanges() {
+				changes, err := utils.DiffExchange("./data", ex)
+				if err != nil {
+					log.Printf("%v", err)
+					continue
+				}
+				for _, change := range changes {
+					log.Printf("%v", change)
+				}
+			}
+			break
 		case "json":
 			Init(exchange.JSON_FILE, "./data")
 			for _, ex := range exMan.GetExchanges() {
@@ -107,6 +127,11 @@ func main() {
 				Time:    10 * time.Second,
 			}
 			exMan.UpdateExData(updateConfig)
+			select {}
+		case "serve":
+			Init(exchange.EXCHANGE_API, "./data")
+			server := microservice.CreateServer(":8080", 10*time.Minute)
+			log.Fatal(server.Run())
 			break
 		case "test":
 			base := coin.Coin{
@@ -131,8 +156,16 @@ func main() {
 func Init(source exchange.DataSource, sourceURI string) {
 	coin.Init()
 	pair.Init()
-	if source == exchange.JSON_FILE {
+	switch source {
+	case exchange.EXCHANGE_API:
+		// load the existing data first, so the coin and pair IDs stay the same
+		if sourceURI != "" {
+			utils.GetCommonDataFromJSON(sourceURI)
+		}
+	case exchange.JSON_FILE:
 		utils.GetCommonDataFromJSON(sourceURI)
+	case exchange.MICROSERVICE_API:
+		utils.GetCommonDataFromMicroservice(sourceURI)
 	}
 	config := &exchange.Config{}
 	config.Source = source
@@ -0,0 +1,174 @@
+package microservice
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
+)
+
+// Server serves the common data and the constraints of every exchange
+// registered in the ExchangeManager, in the same format as the data/*.json files:
+//
+//	GET /common.json
+//	GET /<EXCHANGE NAME>.json
+//
+// Responses carry an ETag, requests with a matching If-None-Match get 304.
+type Server struct {
+	Addr     string
+	Interval time.Duration // how often the data is refreshed from the exchange APIs
+
+	exMan      *exchange.ExchangeManager
+	mutex      sync.RWMutex
+	payloads   map[string]*payload
+	httpServer *http.Server
+	scheduler  *exchange.Scheduler
+	closed     bool // set by Shutdown
+}
+
+type payload struct {
+	Data    []byte
+	ETag    string
+	Updated time.Time
+}
+
+func CreateServer(addr string, interval time.Duration) *Server {
+	return &Server{
+		Addr:     addr,
+		Interval: interval,
+		exMan:    exchange.CreateExchangeManager(),
+		payloads: make(map[string]*payload),
+	}
+}
+
+// Run serves the current data and refreshes it every Interval by an
+// exchange.Scheduler, until Shutdown. It returns nil once shut down.
+func (s *Server) Run() error {
+	s.Build()
+
+	httpServer := &http.Server{Addr: s.Addr, Handler: s}
+	scheduler := s.exMan.CreateScheduler(0)
+	scheduler.OnRefresh = s.buildExchange
+	if s.Interval > 0 {
+		for _, ex := range s.exMan.GetExchanges() {
+			scheduler.Schedule(ex.GetName(), s.Interval)
+		}
+	}
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.httpServer, s.scheduler = httpServer, scheduler
+	s.mutex.Unlock()
+	scheduler.Start()
+
+	log.Printf("Data microservice listening on %s", s.Addr)
+	err := httpServer.ListenAndServe()
+	scheduler.Stop()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the refreshes, waiting for the running ones, then shuts the
+// http server down gracefully like http.Server.Shutdown.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mutex.Lock()
+	s.closed = true
+	httpServer, scheduler := s.httpServer, s.scheduler
+	s.mutex.Unlock()
+
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+	if httpServer == nil {
+		return nil
+	}
+	return httpServer.Shutdown(ctx)
+}
+
+// Refresh updates the constraints of every exchange from the exchange APIs
+// and rebuilds the served data.
+func (s *Server) Refresh() {
+	for _, ex := range s.exMan.GetExchanges() {
+		if err := ex.UpdateConstraint(); err != nil {
+			log.Printf("%v", err)
+		}
+		log.Printf("%s Data Updated. Coin: %d   Pair: %d", ex.GetName(), len(ex.GetCoins()), len(ex.GetPairs()))
+	}
+	s.Build()
+}
+
+// Build rebuilds the served data from the current state without calling the exchange APIs.
+func (s *Server) Build() {
+	for _, ex := range s.exMan.GetExchanges() {
+		s.setPayload(fmt.Sprintf("/%s.json", ex.GetName()), utils.GetJsonData(ex))
+	}
+	s.setPayload("/common.json", utils.GetCommonData())
+}
+
+// buildExchange rebuilds the served data of a refreshed exchange.
+func (s *Server) buildExchange(name exchange.ExchangeName) {
+	if ex := s.exMan.Get(name); ex != nil {
+		s.setPayload(fmt.Sprintf("/%s.json", name), utils.GetJsonData(ex))
+	}
+	s.setPayload("/common.json", utils.GetCommonData())
+}
+
+func (s *Server) setPayload(path string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("%s Json Marshal Err: %v", path, err)
+		return
+	}
+	etag := fmt.Sprintf("\"%x\"", sha1.Sum(data))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if old, ok := s.payloads[path]; ok && old.ETag == etag {
+		return
+	}
+	s.payloads[path] = &payload{
+		Data:    data,
+		ETag:    etag,
+		Updated: time.Now(),
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mutex.RLock()
+	p, ok := s.payloads[r.URL.Path]
+	s.mutex.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("ETag", p.ETag)
+	w.Header().Set("Last-Modified", p.Updated.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") == p.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(p.Data)
+}
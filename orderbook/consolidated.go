@@ -0,0 +1,199 @@
+package orderbook
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+const DEFAULT_PARALLEL = 8
+
+// Options of Consolidate, the zero value merges every book as it is.
+type Options struct {
+	FeeAdjusted   bool          // bids less the taker fee, asks plus the taker fee
+	WalletEnabled bool          // skip exchanges with deposit or withdraw disabled for either coin of the pair
+	MaxAge        time.Duration // skip books older than MaxAge by Maker.AfterTimestamp, 0 keeps all
+	Parallel      int           // order books fetched at the same time, DEFAULT_PARALLEL if 0
+}
+
+// Venue is the part of a level from one exchange. Rate is the exchange's own
+// rate, before any fee adjustment.
+type Venue struct {
+	ExName   exchange.ExchangeName
+	Rate     float64
+	Quantity float64
+}
+
+// Level is one price level of a consolidated book.
+type Level struct {
+	Rate     float64
+	Quantity float64
+	Venues   []Venue
+}
+
+// Book is the order book of a pair merged across exchanges. Bids are highest
+// first and asks lowest first. Skipped lists the exchanges left out and why.
+type Book struct {
+	Pair      *pair.Pair
+	Bids      []Level
+	Asks      []Level
+	Exchanges []exchange.ExchangeName
+	Skipped   map[exchange.ExchangeName]error
+	Timestamp time.Time
+}
+
+// Consolidate merges the books of the pair on every exchange of the
+// ExchangeManager that has it.
+func Consolidate(p *pair.Pair, options *Options) *Book {
+	return ConsolidateExchanges(p, exchange.CreateExchangeManager().GetExchanges(), options)
+}
+
+// ConsolidateExchanges is Consolidate limited to the given exchanges.
+func ConsolidateExchanges(p *pair.Pair, exchanges []exchange.Exchange, options *Options) *Book {
+	if options == nil {
+		options = &Options{}
+	}
+	book := &Book{
+		Pair:      p,
+		Skipped:   make(map[exchange.ExchangeName]error),
+		Timestamp: time.Now(),
+	}
+
+	candidates := []exchange.Exchange{}
+	for _, ex := range exchanges {
+		if !ex.HasPair(p) {
+			continue
+		}
+		if options.WalletEnabled && !walletEnabled(ex, p) {
+			book.Skipped[ex.GetName()] = fmt.Errorf("%s deposit or withdraw disabled for %s", ex.GetName(), p.Name)
+			continue
+		}
+		candidates = append(candidates, ex)
+	}
+
+	makers := fetchOrderBooks(p, candidates, options.Parallel)
+	cutoff := float64(book.Timestamp.Add(-options.MaxAge).UnixNano() / 1e6)
+	bids := make(map[float64]*Level)
+	asks := make(map[float64]*Level)
+	for i, ex := range candidates {
+		maker := makers[i]
+		if maker.err != nil {
+			book.Skipped[ex.GetName()] = maker.err
+			continue
+		}
+		if options.MaxAge > 0 && maker.AfterTimestamp < cutoff {
+			book.Skipped[ex.GetName()] = fmt.Errorf("%s %s order book is older than %v", ex.GetName(), p.Name, options.MaxAge)
+			continue
+		}
+
+		pairConstraint := ex.GetPairConstraint(p)
+		for _, order := range SortedBids(maker.Maker) {
+			rate := order.Rate
+			if options.FeeAdjusted && pairConstraint != nil {
+				rate = EffectiveSellRate(rate, pairConstraint)
+			}
+			addVenue(bids, rate, Venue{ex.GetName(), order.Rate, order.Quantity})
+		}
+		for _, order := range SortedAsks(maker.Maker) {
+			rate := order.Rate
+			if options.FeeAdjusted && pairConstraint != nil {
+				rate = EffectiveBuyRate(rate, pairConstraint)
+			}
+			addVenue(asks, rate, Venue{ex.GetName(), order.Rate, order.Quantity})
+		}
+		book.Exchanges = append(book.Exchanges, ex.GetName())
+	}
+
+	book.Bids = sortLevels(bids, false)
+	book.Asks = sortLevels(asks, true)
+	return book
+}
+
+// Maker flattens the book into an exchange.Maker so the analytics of the
+// package apply to it.
+func (b *Book) Maker() *exchange.Maker {
+	maker := &exchange.Maker{
+		Timestamp: float64(b.Timestamp.UnixNano() / 1e6),
+		Bids:      make([]exchange.Order, 0, len(b.Bids)),
+		Asks:      make([]exchange.Order, 0, len(b.Asks)),
+	}
+	for _, level := range b.Bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Pair: b.Pair, Rate: level.Rate, Quantity: level.Quantity})
+	}
+	for _, level := range b.Asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Pair: b.Pair, Rate: level.Rate, Quantity: level.Quantity})
+	}
+	return maker
+}
+
+type makerResult struct {
+	*exchange.Maker
+	err error
+}
+
+func fetchOrderBooks(p *pair.Pair, exchanges []exchange.Exchange, parallel int) []makerResult {
+	if parallel <= 0 {
+		parallel = DEFAULT_PARALLEL
+	}
+	semaphore := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	results := make([]makerResult, len(exchanges))
+	for i, ex := range exchanges {
+		wg.Add(1)
+		go func(i int, ex exchange.Exchange) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			maker, err := ex.OrderBook(p)
+			if err == nil && maker == nil {
+				err = fmt.Errorf("%s %s OrderBook is empty", ex.GetName(), p.Name)
+			}
+			if err != nil {
+				log.Printf("%s %s OrderBook Err: %v", ex.GetName(), p.Name, err)
+			}
+			results[i] = makerResult{maker, err}
+		}(i, ex)
+	}
+	wg.Wait()
+	return results
+}
+
+func walletEnabled(ex exchange.Exchange, p *pair.Pair) bool {
+	return ex.CanDeposit(p.Base) && ex.CanWithdraw(p.Base) &&
+		ex.CanDeposit(p.Target) && ex.CanWithdraw(p.Target)
+}
+
+func addVenue(levels map[float64]*Level, rate float64, venue Venue) {
+	level, ok := levels[rate]
+	if !ok {
+		level = &Level{Rate: rate}
+		levels[rate] = level
+	}
+	level.Quantity += venue.Quantity
+	level.Venues = append(level.Venues, venue)
+}
+
+func sortLevels(levels map[float64]*Level, ascending bool) []Level {
+	sorted := make([]Level, 0, len(levels))
+	for _, level := range levels {
+		sorted = append(sorted, *level)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if ascending {
+			return sorted[i].Rate < sorted[j].Rate
+		}
+		return sorted[i].Rate > sorted[j].Rate
+	})
+	return sorted
+}
@@ -0,0 +1,233 @@
+package orderbook
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// Quantities are in the target coin and amounts in the base coin, the same as
+// exchange.Order: buying a quantity of ETH on BTC|ETH spends an amount of BTC.
+// The functions do not expect the book to be sorted, the adapters differ.
+
+// Fill is the result of taking liquidity from one side of a book.
+type Fill struct {
+	Quantity  float64 // target coin filled
+	Amount    float64 // base coin spent or received, fee excluded
+	VWAP      float64 // Amount / Quantity
+	BestRate  float64 // rate of the first level taken
+	WorstRate float64 // rate of the last level taken
+}
+
+// Slippage is how far the VWAP is from the best rate, relative to the best rate.
+func (f *Fill) Slippage() float64 {
+	if f.BestRate == 0 {
+		return 0
+	}
+	return math.Abs(f.VWAP-f.BestRate) / f.BestRate
+}
+
+// SortedBids returns a copy of the bids, highest rate first, empty levels dropped.
+func SortedBids(maker *exchange.Maker) []exchange.Order {
+	return sortOrders(maker.Bids, false)
+}
+
+// SortedAsks returns a copy of the asks, lowest rate first, empty levels dropped.
+func SortedAsks(maker *exchange.Maker) []exchange.Order {
+	return sortOrders(maker.Asks, true)
+}
+
+func sortOrders(orders []exchange.Order, ascending bool) []exchange.Order {
+	sorted := make([]exchange.Order, 0, len(orders))
+	for _, order := range orders {
+		if order.Rate > 0 && order.Quantity > 0 {
+			sorted = append(sorted, order)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ascending {
+			return sorted[i].Rate < sorted[j].Rate
+		}
+		return sorted[i].Rate > sorted[j].Rate
+	})
+	return sorted
+}
+
+func BestBid(maker *exchange.Maker) (float64, error) {
+	bids := SortedBids(maker)
+	if len(bids) == 0 {
+		return 0, fmt.Errorf("Order book has no bids")
+	}
+	return bids[0].Rate, nil
+}
+
+func BestAsk(maker *exchange.Maker) (float64, error) {
+	asks := SortedAsks(maker)
+	if len(asks) == 0 {
+		return 0, fmt.Errorf("Order book has no asks")
+	}
+	return asks[0].Rate, nil
+}
+
+// Mid is the average of the best bid and the best ask.
+func Mid(maker *exchange.Maker) (float64, error) {
+	bid, err := BestBid(maker)
+	if err != nil {
+		return 0, err
+	}
+	ask, err := BestAsk(maker)
+	if err != nil {
+		return 0, err
+	}
+	return (bid + ask) / 2, nil
+}
+
+// Spread is the best ask less the best bid.
+func Spread(maker *exchange.Maker) (float64, error) {
+	bid, err := BestBid(maker)
+	if err != nil {
+		return 0, err
+	}
+	ask, err := BestAsk(maker)
+	if err != nil {
+		return 0, err
+	}
+	return ask - bid, nil
+}
+
+// SpreadBps is the spread in basis points of the mid.
+func SpreadBps(maker *exchange.Maker) (float64, error) {
+	spread, err := Spread(maker)
+	if err != nil {
+		return 0, err
+	}
+	mid, _ := Mid(maker)
+	return spread / mid * 10000, nil
+}
+
+// Buy takes quantity from the asks. If the asks are not deep enough the
+// partial fill is returned with an error.
+func Buy(maker *exchange.Maker, quantity float64) (*Fill, error) {
+	return fill(SortedAsks(maker), quantity, false)
+}
+
+// Sell takes quantity from the bids. If the bids are not deep enough the
+// partial fill is returned with an error.
+func Sell(maker *exchange.Maker, quantity float64) (*Fill, error) {
+	return fill(SortedBids(maker), quantity, false)
+}
+
+// BuyAmount spends amount of the base coin on the asks.
+func BuyAmount(maker *exchange.Maker, amount float64) (*Fill, error) {
+	return fill(SortedAsks(maker), amount, true)
+}
+
+// SellAmount sells into the bids until amount of the base coin is received.
+func SellAmount(maker *exchange.Maker, amount float64) (*Fill, error) {
+	return fill(SortedBids(maker), amount, true)
+}
+
+// fill walks the sorted orders until size is filled, size is a quantity or
+// an amount if byAmount.
+func fill(orders []exchange.Order, size float64, byAmount bool) (*Fill, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("Fill size must be positive: %v", size)
+	}
+
+	f := &Fill{}
+	left := size
+	for _, order := range orders {
+		if left <= size*1e-12 {
+			break
+		}
+		if f.BestRate == 0 {
+			f.BestRate = order.Rate
+		}
+		quantity := order.Quantity
+		if byAmount {
+			quantity = math.Min(quantity, left/order.Rate)
+			left -= quantity * order.Rate
+		} else {
+			quantity = math.Min(quantity, left)
+			left -= quantity
+		}
+		f.Quantity += quantity
+		f.Amount += quantity * order.Rate
+		f.WorstRate = order.Rate
+	}
+	if f.Quantity > 0 {
+		f.VWAP = f.Amount / f.Quantity
+	}
+
+	if left > size*1e-12 {
+		return f, fmt.Errorf("Order book is not deep enough, %v of %v filled", size-left, size)
+	}
+	return f, nil
+}
+
+// Impact is how far the VWAP of the fill is from the mid, relative to the mid.
+// It is the cost of taking the fill compared to trading at the mid.
+func Impact(maker *exchange.Maker, f *Fill) (float64, error) {
+	mid, err := Mid(maker)
+	if err != nil {
+		return 0, err
+	}
+	return math.Abs(f.VWAP-mid) / mid, nil
+}
+
+// Depth is the quantity of the bids and of the asks with a rate within
+// percent of the mid, e.g. 1 for 1%.
+func Depth(maker *exchange.Maker, percent float64) (bidQuantity, askQuantity float64, err error) {
+	mid, err := Mid(maker)
+	if err != nil {
+		return 0, 0, err
+	}
+	bidQuantity, _ = SellQuantity(maker, mid*(1-percent/100))
+	askQuantity, _ = BuyQuantity(maker, mid*(1+percent/100))
+	return bidQuantity, askQuantity, nil
+}
+
+// DepthBps is Depth with the range in basis points.
+func DepthBps(maker *exchange.Maker, bps float64) (bidQuantity, askQuantity float64, err error) {
+	return Depth(maker, bps/100)
+}
+
+// BuyQuantity is the quantity and amount of the asks at or below the limit rate.
+func BuyQuantity(maker *exchange.Maker, limit float64) (quantity, amount float64) {
+	for _, order := range SortedAsks(maker) {
+		if order.Rate > limit {
+			break
+		}
+		quantity += order.Quantity
+		amount += order.Quantity * order.Rate
+	}
+	return quantity, amount
+}
+
+// SellQuantity is the quantity and amount of the bids at or above the limit rate.
+func SellQuantity(maker *exchange.Maker, limit float64) (quantity, amount float64) {
+	for _, order := range SortedBids(maker) {
+		if order.Rate < limit {
+			break
+		}
+		quantity += order.Quantity
+		amount += order.Quantity * order.Rate
+	}
+	return quantity, amount
+}
+
+// EffectiveBuyRate is the rate of a taker buy with the pair's fee included.
+func EffectiveBuyRate(rate float64, pairConstraint *exchange.PairConstraint) float64 {
+	return rate * (1 + pairConstraint.TakerFee)
+}
+
+// EffectiveSellRate is the rate of a taker sell with the pair's fee deducted.
+func EffectiveSellRate(rate float64, pairConstraint *exchange.PairConstraint) float64 {
+	return rate * (1 - pairConstraint.TakerFee)
+}
@@ -0,0 +1,249 @@
+package paper
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+)
+
+// Account is the simulated balances and orders of an exchange, shared by the
+// paper Exchange and the backtest Venue. It locks the balance of the placed
+// orders, settles their fills and keeps the quantity taken from the book
+// levels, the matching is left to its user. It is not safe for concurrent use.
+type Account struct {
+	name     exchange.ExchangeName
+	balances map[int]float64 // available, by coin ID
+	locked   map[int]float64 // reserved by the open orders
+	orders   []*Order
+	taken    map[string]*taken // by takenKey
+}
+
+// Order is a simulated order with the balance it still locks.
+type Order struct {
+	exchange.Order
+	Reserved float64
+}
+
+// taken is the quantity filled from a book level of the given quantity.
+type taken struct {
+	level    float64
+	quantity float64
+}
+
+func takenKey(p *pair.Pair, side string, rate float64) string {
+	return fmt.Sprintf("%d/%s/%v", p.ID, side, rate)
+}
+
+// NewAccount returns an empty account, name prefixes its errors.
+func NewAccount(name exchange.ExchangeName) *Account {
+	return &Account{
+		name:     name,
+		balances: make(map[int]float64),
+		locked:   make(map[int]float64),
+		taken:    make(map[string]*taken),
+	}
+}
+
+func (a *Account) SetBalance(c *coin.Coin, balance float64) {
+	a.balances[c.ID] = balance
+}
+
+// Credit adds the quantity to the available balance, a negative one debits it.
+func (a *Account) Credit(c *coin.Coin, quantity float64) {
+	a.balances[c.ID] += quantity
+}
+
+// Balance is the available balance, the part locked by open orders excluded.
+func (a *Account) Balance(c *coin.Coin) float64 {
+	return a.balances[c.ID]
+}
+
+// Locked is the balance locked by open orders.
+func (a *Account) Locked(c *coin.Coin) float64 {
+	return a.locked[c.ID]
+}
+
+// Holdings are the available and locked balances by coin ID.
+func (a *Account) Holdings() map[int]float64 {
+	holdings := make(map[int]float64)
+	for id, balance := range a.balances {
+		holdings[id] += balance
+	}
+	for id, locked := range a.locked {
+		holdings[id] += locked
+	}
+	return holdings
+}
+
+// Place adds an open order which locks reserve, of the base coin for a buy and
+// of the target coin for a sell.
+func (a *Account) Place(p *pair.Pair, side string, quantity, rate, reserve float64) (*Order, error) {
+	c := p.Target
+	if side == "Buy" {
+		c = p.Base
+	}
+	if a.balances[c.ID] < reserve {
+		return nil, fmt.Errorf("%s %s Insufficient Balance: %s %v available, %v required", a.name, side, c.Code, a.balances[c.ID], reserve)
+	}
+	a.balances[c.ID] -= reserve
+	a.locked[c.ID] += reserve
+
+	order := &Order{
+		Order: exchange.Order{
+			Pair:     p,
+			OrderID:  strconv.Itoa(len(a.orders) + 1),
+			Rate:     rate,
+			Quantity: quantity,
+			Side:     side,
+			Status:   exchange.New,
+		},
+		Reserved: reserve,
+	}
+	a.orders = append(a.orders, order)
+	return order, nil
+}
+
+// Cross takes the order's quantity from the book levels it crosses, less what
+// is already taken from them, and calls fill with the quantity taken from each
+// level and its rate. The quantity taken from a level is not offered again
+// until the level's quantity changes or ResetTaken is called.
+func (a *Account) Cross(order *Order, maker *exchange.Maker, fill func(quantity, rate float64)) {
+	p := order.Pair
+	levels := orderbook.SortedAsks(maker)
+	if order.Side == "Sell" {
+		levels = orderbook.SortedBids(maker)
+	}
+	left := order.Quantity - order.DealQuantity
+	for _, level := range levels {
+		if left <= 0 || !Open(order) {
+			break
+		}
+		if (order.Side == "Buy" && level.Rate > order.Rate) || (order.Side == "Sell" && level.Rate < order.Rate) {
+			break
+		}
+
+		available := level.Quantity
+		key := takenKey(p, order.Side, level.Rate)
+		if t, ok := a.taken[key]; ok && t.level == level.Quantity {
+			available -= t.quantity
+		} else {
+			a.taken[key] = &taken{level: level.Quantity}
+		}
+		if available <= 0 {
+			continue
+		}
+
+		quantity := math.Min(left, available)
+		a.taken[key].quantity += quantity
+		left -= quantity
+		fill(quantity, level.Rate)
+	}
+}
+
+// ResetTaken offers the whole quantity of the pair's book levels again, for a
+// new book.
+func (a *Account) ResetTaken(p *pair.Pair) {
+	prefix := fmt.Sprintf("%d/", p.ID)
+	for key := range a.taken {
+		if strings.HasPrefix(key, prefix) {
+			delete(a.taken, key)
+		}
+	}
+}
+
+// Fill settles quantity of the order at the rate with the fee, in the base coin,
+// and returns the fee amount. A buy unlocks its reserve in proportion.
+func (a *Account) Fill(order *Order, quantity, rate, fee float64) float64 {
+	p := order.Pair
+	amount := quantity * rate
+	feeAmount := amount * fee
+	if order.Side == "Buy" {
+		release := math.Min(order.Reserved, order.Reserved*quantity/(order.Quantity-order.DealQuantity))
+		order.Reserved -= release
+		a.locked[p.Base.ID] -= release
+		a.balances[p.Base.ID] += release - amount - feeAmount
+		a.balances[p.Target.ID] += quantity
+	} else {
+		order.Reserved -= quantity
+		a.locked[p.Target.ID] -= quantity
+		a.balances[p.Base.ID] += amount - feeAmount
+	}
+
+	order.DealRate = (order.DealRate*order.DealQuantity + amount) / (order.DealQuantity + quantity)
+	order.DealQuantity += quantity
+	if order.DealQuantity >= order.Quantity*(1-1e-12) {
+		order.Status = exchange.Filled
+		a.unlock(order)
+	} else {
+		order.Status = exchange.Partial
+	}
+	return feeAmount
+}
+
+// unlock returns what is still reserved by the order to the available balance.
+func (a *Account) unlock(order *Order) {
+	c := order.Pair.Target
+	if order.Side == "Buy" {
+		c = order.Pair.Base
+	}
+	a.locked[c.ID] -= order.Reserved
+	a.balances[c.ID] += order.Reserved
+	order.Reserved = 0
+}
+
+func Open(order *Order) bool {
+	return order.Status == exchange.New || order.Status == exchange.Partial
+}
+
+func (a *Account) Find(orderID string) *Order {
+	for _, o := range a.orders {
+		if o.OrderID == orderID {
+			return o
+		}
+	}
+	return nil
+}
+
+// OpenOrders returns the open orders, ordered by placement.
+func (a *Account) OpenOrders() []*Order {
+	orders := []*Order{}
+	for _, o := range a.orders {
+		if Open(o) {
+			orders = append(orders, o)
+		}
+	}
+	return orders
+}
+
+// Cancel cancels the open order and unlocks its reserve.
+func (a *Account) Cancel(orderID string) (*Order, error) {
+	o := a.Find(orderID)
+	if o == nil {
+		return nil, fmt.Errorf("%s CancelOrder Order Not Found: %v", a.name, orderID)
+	}
+	if !Open(o) {
+		return nil, fmt.Errorf("%s CancelOrder Order %v is %v", a.name, orderID, o.Status)
+	}
+	o.Status = exchange.Cancelled
+	o.Canceled = true
+	a.unlock(o)
+	return o, nil
+}
+
+func (a *Account) CancelAll() {
+	for _, o := range a.OpenOrders() {
+		o.Status = exchange.Cancelled
+		o.Canceled = true
+		a.unlock(o)
+	}
+}
@@ -0,0 +1,192 @@
+package paper
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Exchange is a simulated exchange.Exchange for paper trading. The market data
+// and constraints come from the wrapped exchange, which may be a live adapter
+// or a replayed one, while the balances and orders are kept in an Account.
+//
+// Orders are matched against the wrapped exchange's OrderBook when placed and
+// again on every OrderStatus and ListOrders, filling at the book's rates and
+// charging GetFee. The quantity taken from a level is not offered again until
+// the level's quantity changes in the book.
+type Exchange struct {
+	exchange.Exchange
+
+	mutex   sync.Mutex
+	account *Account
+}
+
+func CreateExchange(ex exchange.Exchange) *Exchange {
+	return &Exchange{
+		Exchange: ex,
+		account:  NewAccount(ex.GetName()),
+	}
+}
+
+func (e *Exchange) SetBalance(c *coin.Coin, balance float64) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.account.SetBalance(c, balance)
+}
+
+// GetBalance is the available balance, the part locked by open orders excluded.
+func (e *Exchange) GetBalance(c *coin.Coin) float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.account.Balance(c)
+}
+
+// GetLockedBalance is the balance locked by open orders.
+func (e *Exchange) GetLockedBalance(c *coin.Coin) float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.account.Locked(c)
+}
+
+func (e *Exchange) UpdateAllBalances() {
+}
+
+func (e *Exchange) Withdraw(c *coin.Coin, quantity float64, addr, tag string) bool {
+	return false
+}
+
+func (e *Exchange) LimitBuy(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return e.placeOrder(p, "Buy", quantity, rate)
+}
+
+func (e *Exchange) LimitSell(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return e.placeOrder(p, "Sell", quantity, rate)
+}
+
+// placeOrder locks the balance for the order, the base coin with the fee for a
+// buy and the target coin for a sell, then matches it against the book.
+func (e *Exchange) placeOrder(p *pair.Pair, side string, quantity, rate float64) (*exchange.Order, error) {
+	if !e.HasPair(p) {
+		return nil, fmt.Errorf("%s %s Invalid Pair: %v", e.GetName(), side, p.Name)
+	}
+	rate, quantity, err := exchange.ValidateOrder(e, p, side, rate, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	reserve := quantity
+	if side == "Buy" {
+		reserve = quantity * rate * (1 + e.GetFee(p))
+	}
+	e.mutex.Lock()
+	order, err := e.account.Place(p, side, quantity, rate, reserve)
+	e.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// the order is placed even if the book is not available, it is matched later
+	if err := e.match(order); err != nil {
+		log.Printf("%s %s match order %v Err: %v", e.GetName(), side, order.OrderID, err)
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	copied := order.Order
+	return &copied, nil
+}
+
+// match fills the open order against the current book of its pair.
+func (e *Exchange) match(order *Order) error {
+	maker, err := e.Exchange.OrderBook(order.Pair)
+	if err != nil {
+		return err
+	} else if maker == nil {
+		return fmt.Errorf("%s OrderBook of %s is empty", e.GetName(), order.Pair.Name)
+	}
+	fee := e.GetFee(order.Pair)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.account.Cross(order, maker, func(quantity, rate float64) {
+		e.account.Fill(order, quantity, rate, fee)
+	})
+	return nil
+}
+
+func (e *Exchange) find(orderID string) *Order {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.account.Find(orderID)
+}
+
+func (e *Exchange) OrderStatus(order *exchange.Order) error {
+	o := e.find(order.OrderID)
+	if o == nil {
+		return fmt.Errorf("%s OrderStatus Order Not Found: %v", e.GetName(), order.OrderID)
+	}
+	if err := e.match(o); err != nil {
+		return err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	order.Status = o.Status
+	order.DealRate = o.DealRate
+	order.DealQuantity = o.DealQuantity
+	return nil
+}
+
+// ListOrders returns the open orders, ordered by placement.
+func (e *Exchange) ListOrders() ([]*exchange.Order, error) {
+	e.mutex.Lock()
+	pending := e.account.OpenOrders()
+	e.mutex.Unlock()
+
+	orders := []*exchange.Order{}
+	for _, o := range pending {
+		if err := e.match(o); err != nil {
+			return nil, err
+		}
+		e.mutex.Lock()
+		if Open(o) {
+			copied := o.Order
+			orders = append(orders, &copied)
+		}
+		e.mutex.Unlock()
+	}
+	return orders, nil
+}
+
+func (e *Exchange) CancelOrder(order *exchange.Order) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	o, err := e.account.Cancel(order.OrderID)
+	if err != nil {
+		return err
+	}
+	order.Status = o.Status
+	order.Canceled = true
+	order.DealRate = o.DealRate
+	order.DealQuantity = o.DealQuantity
+	return nil
+}
+
+func (e *Exchange) CancelAllOrder() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.account.CancelAll()
+	return nil
+}
+
+func (e *Exchange) DoAccoutOperation(operation *exchange.AccountOperation) error {
+	return fmt.Errorf("%s Operation type invalid for paper trading: %v", e.GetName(), operation.Type)
+}
@@ -0,0 +1,91 @@
+package recorder
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+// appendLines appends the values as one gzip member of JSON lines.
+func appendLines(path string, values []interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(file)
+	encoder := json.NewEncoder(zw)
+	for _, value := range values {
+		if err = encoder.Encode(value); err != nil {
+			break
+		}
+	}
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// gzipHeader starts every member: the magic bytes and the deflate method.
+var gzipHeader = []byte{0x1f, 0x8b, 0x08}
+
+// readLines calls parse with each JSON line of the file. A member cut short,
+// e.g. by a crash while writing, is dropped and reading resumes at the next
+// member header, the members appended after a restart are kept.
+func readLines(path string, parse func(line []byte) error) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset < len(data); {
+		reader := bytes.NewReader(data[offset:])
+		member, err := readMember(reader)
+		if err != nil {
+			next := bytes.Index(data[offset+1:], gzipHeader)
+			if next < 0 {
+				log.Printf("%s is truncated at %d", path, offset)
+				return nil
+			}
+			log.Printf("%s is damaged at %d, resume at %d: %v", path, offset, offset+1+next, err)
+			offset += 1 + next
+			continue
+		}
+		offset = len(data) - reader.Len()
+
+		for _, line := range bytes.Split(member, []byte("\n")) {
+			if len(line) == 0 {
+				continue
+			}
+			if err := parse(line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readMember reads one gzip member, it is only trusted once its checksum is read.
+// bytes.Reader is an io.ByteReader, gzip does not read past the member.
+func readMember(reader *bytes.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	zr.Multistream(false)
+	return ioutil.ReadAll(zr)
+}
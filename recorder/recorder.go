@@ -0,0 +1,163 @@
+package recorder
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+const DEFAULT_PARALLEL = 8
+
+type target struct {
+	ex exchange.Exchange
+	p  *pair.Pair
+}
+
+// Recorder captures the order books of the added exchange pairs every Interval
+// and appends them to one file per exchange and UTC day in Dir,
+// e.g. BINANCE-2019-06-01.gz.
+type Recorder struct {
+	Dir      string
+	Interval time.Duration
+	Parallel int // order books fetched at the same time, DEFAULT_PARALLEL if 0
+
+	mutex   sync.Mutex
+	targets []target
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func CreateRecorder(dir string, interval time.Duration) *Recorder {
+	return &Recorder{
+		Dir:      dir,
+		Interval: interval,
+		Parallel: DEFAULT_PARALLEL,
+	}
+}
+
+// Add records the pairs of the exchange, all its pairs if none is given.
+func (r *Recorder) Add(ex exchange.Exchange, pairs ...*pair.Pair) {
+	if len(pairs) == 0 {
+		pairs = ex.GetPairs()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, p := range pairs {
+		r.targets = append(r.targets, target{ex, p})
+	}
+}
+
+// Start captures every Interval until Stop. Calling Start on a started
+// Recorder does nothing.
+func (r *Recorder) Start() error {
+	if r.Interval <= 0 {
+		return fmt.Errorf("Recorder Interval must be positive: %v", r.Interval)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.stop != nil {
+		return nil
+	}
+	r.stop = make(chan struct{})
+
+	r.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.Interval)
+		defer ticker.Stop()
+		for {
+			if err := r.Capture(); err != nil {
+				log.Printf("Recorder Capture Err: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.stop)
+	return nil
+}
+
+// Stop ends the captures and waits for the running one to be written.
+func (r *Recorder) Stop() {
+	r.mutex.Lock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.mutex.Unlock()
+
+	r.wg.Wait()
+}
+
+// Capture fetches the order books once and appends them to the files.
+// The books that fail to fetch are skipped and logged.
+func (r *Recorder) Capture() error {
+	r.mutex.Lock()
+	targets := append([]target{}, r.targets...)
+	r.mutex.Unlock()
+
+	parallel := r.Parallel
+	if parallel <= 0 {
+		parallel = DEFAULT_PARALLEL
+	}
+	semaphore := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	snapshots := make([]*Snapshot, len(targets))
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t target) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			maker, err := t.ex.OrderBook(t.p)
+			if err != nil || maker == nil {
+				log.Printf("%s %s OrderBook Err: %v", t.ex.GetName(), t.p.Name, err)
+				return
+			}
+			snapshots[i] = CreateSnapshot(t.ex.GetName(), t.p, maker)
+		}(i, t)
+	}
+	wg.Wait()
+
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return err
+	}
+	files := make(map[string][]*Snapshot)
+	paths := []string{}
+	for _, snapshot := range snapshots {
+		if snapshot == nil {
+			continue
+		}
+		path := r.path(snapshot)
+		if _, ok := files[path]; !ok {
+			paths = append(paths, path)
+		}
+		files[path] = append(files[path], snapshot)
+	}
+	for _, path := range paths {
+		if err := AppendSnapshots(path, files[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) path(snapshot *Snapshot) string {
+	day := time.Unix(0, int64(snapshot.Timestamp)*1e6).UTC().Format("2006-01-02")
+	return filepath.Join(r.Dir, fmt.Sprintf("%s-%s.gz", snapshot.ExName, day))
+}
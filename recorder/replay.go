@@ -0,0 +1,129 @@
+package recorder
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Replay plays recorded snapshots back in timestamp order. The current book of
+// an exchange pair is its latest snapshot at or before the replay's time.
+type Replay struct {
+	mutex     sync.RWMutex
+	snapshots []*Snapshot
+	next      int
+	time      float64
+	current   map[string]*Snapshot
+}
+
+func replayKey(exName exchange.ExchangeName, pairName string) string {
+	return string(exName) + "/" + pairName
+}
+
+// LoadReplay reads the recorded files, the patterns are filepath.Glob patterns
+// such as "data/books/BINANCE-*.gz".
+func LoadReplay(patterns ...string) (*Replay, error) {
+	snapshots := []*Snapshot{}
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			s, err := ReadSnapshots(path)
+			if err != nil {
+				return nil, fmt.Errorf("Read %s Err: %v", path, err)
+			}
+			snapshots = append(snapshots, s...)
+		}
+	}
+	return CreateReplay(snapshots), nil
+}
+
+func CreateReplay(snapshots []*Snapshot) *Replay {
+	sorted := append([]*Snapshot{}, snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+	return &Replay{
+		snapshots: sorted,
+		current:   make(map[string]*Snapshot),
+	}
+}
+
+// Next moves to the next snapshot and returns it, false at the end.
+func (r *Replay) Next() (*Snapshot, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.next >= len(r.snapshots) {
+		return nil, false
+	}
+	snapshot := r.snapshots[r.next]
+	r.next++
+	r.time = snapshot.Timestamp
+	r.current[replayKey(snapshot.ExName, snapshot.Pair)] = snapshot
+	return snapshot, true
+}
+
+// AdvanceTo moves to the timestamp in milliseconds, applying every snapshot
+// up to it.
+func (r *Replay) AdvanceTo(timestamp float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for r.next < len(r.snapshots) && r.snapshots[r.next].Timestamp <= timestamp {
+		snapshot := r.snapshots[r.next]
+		r.current[replayKey(snapshot.ExName, snapshot.Pair)] = snapshot
+		r.next++
+	}
+	if timestamp > r.time {
+		r.time = timestamp
+	}
+}
+
+// Time is the replay's current timestamp in milliseconds.
+func (r *Replay) Time() float64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.time
+}
+
+// Done is true when every snapshot has been played.
+func (r *Replay) Done() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.next >= len(r.snapshots)
+}
+
+// OrderBook is the current book of the exchange pair.
+func (r *Replay) OrderBook(exName exchange.ExchangeName, p *pair.Pair) (*exchange.Maker, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	snapshot, ok := r.current[replayKey(exName, p.Name)]
+	if !ok {
+		return nil, fmt.Errorf("%s %s has no replayed order book at %v", exName, p.Name, r.time)
+	}
+	return snapshot.Maker(p), nil
+}
+
+// Exchange wraps the exchange so its OrderBook comes from the replay, the
+// constraints and everything else still come from the exchange.
+func (r *Replay) Exchange(ex exchange.Exchange) exchange.Exchange {
+	return &replayExchange{Exchange: ex, replay: r}
+}
+
+type replayExchange struct {
+	exchange.Exchange
+	replay *Replay
+}
+
+func (e *replayExchange) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	return e.replay.OrderBook(e.GetName(), p)
+}
@@ -0,0 +1,86 @@
+package recorder
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Snapshot is one recorded order book. The levels are [rate, quantity] and the
+// Timestamp is in milliseconds, Maker.AfterTimestamp when the adapter sets it.
+type Snapshot struct {
+	ExName       exchange.ExchangeName
+	Pair         string
+	Timestamp    float64
+	LastUpdateID int64        `json:",omitempty"`
+	Bids         [][2]float64 `json:",omitempty"`
+	Asks         [][2]float64 `json:",omitempty"`
+}
+
+func CreateSnapshot(exName exchange.ExchangeName, p *pair.Pair, maker *exchange.Maker) *Snapshot {
+	snapshot := &Snapshot{
+		ExName:       exName,
+		Pair:         p.Name,
+		Timestamp:    maker.AfterTimestamp,
+		LastUpdateID: maker.LastUpdateID,
+	}
+	if snapshot.Timestamp == 0 {
+		snapshot.Timestamp = float64(time.Now().UnixNano() / 1e6)
+	}
+	for _, order := range maker.Bids {
+		snapshot.Bids = append(snapshot.Bids, [2]float64{order.Rate, order.Quantity})
+	}
+	for _, order := range maker.Asks {
+		snapshot.Asks = append(snapshot.Asks, [2]float64{order.Rate, order.Quantity})
+	}
+	return snapshot
+}
+
+// Maker rebuilds the order book of the snapshot for the pair.
+func (s *Snapshot) Maker(p *pair.Pair) *exchange.Maker {
+	maker := &exchange.Maker{
+		Timestamp:      s.Timestamp,
+		AfterTimestamp: s.Timestamp,
+		LastUpdateID:   s.LastUpdateID,
+		Bids:           make([]exchange.Order, 0, len(s.Bids)),
+		Asks:           make([]exchange.Order, 0, len(s.Asks)),
+	}
+	for _, level := range s.Bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Pair: p, Rate: level[0], Quantity: level[1]})
+	}
+	for _, level := range s.Asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Pair: p, Rate: level[0], Quantity: level[1]})
+	}
+	return maker
+}
+
+// AppendSnapshots appends the snapshots to the file as one gzip member of JSON
+// lines. A file is a series of members, readable by any gzip reader.
+func AppendSnapshots(path string, snapshots []*Snapshot) error {
+	values := make([]interface{}, len(snapshots))
+	for i, snapshot := range snapshots {
+		values[i] = snapshot
+	}
+	return appendLines(path, values)
+}
+
+// ReadSnapshots reads the snapshots of the file in the order they were written.
+// A member cut short, e.g. by a crash while writing, is dropped.
+func ReadSnapshots(path string) ([]*Snapshot, error) {
+	snapshots := []*Snapshot{}
+	err := readLines(path, func(line []byte) error {
+		snapshot := &Snapshot{}
+		if err := json.Unmarshal(line, snapshot); err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	})
+	return snapshots, err
+}
@@ -0,0 +1,45 @@
+package recorder
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// Trade is one recorded public trade. Side is the taker's, "Buy" when an ask
+// was taken. The Timestamp is in milliseconds.
+type Trade struct {
+	ExName    exchange.ExchangeName
+	Pair      string
+	Timestamp float64
+	Rate      float64
+	Quantity  float64
+	Side      string
+}
+
+// AppendTrades appends the trades to the file in the format of AppendSnapshots.
+func AppendTrades(path string, trades []*Trade) error {
+	values := make([]interface{}, len(trades))
+	for i, trade := range trades {
+		values[i] = trade
+	}
+	return appendLines(path, values)
+}
+
+// ReadTrades reads the trades of the file in the order they were written.
+func ReadTrades(path string) ([]*Trade, error) {
+	trades := []*Trade{}
+	err := readLines(path, func(line []byte) error {
+		trade := &Trade{}
+		if err := json.Unmarshal(line, trade); err != nil {
+			return err
+		}
+		trades = append(trades, trade)
+		return nil
+	})
+	return trades, err
+}
@@ -0,0 +1,362 @@
+package router
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+)
+
+type Side string
+
+const (
+	BUY  Side = "Buy"
+	SELL Side = "Sell"
+)
+
+const (
+	DEFAULT_POLL_INTERVAL = time.Second
+	DEFAULT_TIMEOUT       = time.Minute
+)
+
+// Child is the part of a routed order placed on one exchange. Rate is the limit
+// rate, the worst level planned on the exchange rounded to its price filter.
+type Child struct {
+	Exchange exchange.Exchange
+	Rate     float64
+	Quantity float64
+	Amount   float64 // expected base amount at the planned levels, fee excluded
+	Fee      float64 // expected fee in the base coin
+}
+
+// Plan splits Quantity of the pair's target coin across exchanges, the levels
+// with the best fee-adjusted rate first. Planned may be less than Quantity when
+// the books, balances or lot sizes do not allow it all.
+type Plan struct {
+	Pair     *pair.Pair
+	Side     Side
+	Quantity float64
+	Planned  float64
+	Amount   float64 // expected base amount of all children, fee excluded
+	Fee      float64
+	Children []*Child
+}
+
+// Fill is the result of a child order.
+type Fill struct {
+	Child *Child
+	Order *exchange.Order
+	Err   error
+}
+
+// Report is the consolidated result of an executed Plan.
+type Report struct {
+	Plan     *Plan
+	Fills    []*Fill
+	Quantity float64 // target coin filled
+	Amount   float64 // base coin filled, fee excluded
+	Rate     float64 // average fill rate
+	Fee      float64 // estimated by the exchanges' fees
+}
+
+// Router plans and places orders over the exchanges of the ExchangeManager
+// where there is balance for the trade. With DryRun, Execute only plans.
+// The plans use the loaded balances, the caller refreshes them unless
+// UpdateBalances is set, which calls UpdateAllBalances on every plan.
+type Router struct {
+	DryRun         bool
+	UpdateBalances bool
+	PollInterval   time.Duration // between OrderStatus checks
+	Timeout        time.Duration // child orders not done by then are cancelled, must be positive
+
+	exMan *exchange.ExchangeManager
+}
+
+func CreateRouter() *Router {
+	return &Router{
+		PollInterval: DEFAULT_POLL_INTERVAL,
+		Timeout:      DEFAULT_TIMEOUT,
+		exMan:        exchange.CreateExchangeManager(),
+	}
+}
+
+// level is a book level of one exchange in the merged plan.
+type level struct {
+	ex        exchange.Exchange
+	rate      float64
+	effective float64
+	quantity  float64
+}
+
+// Plan routes over every exchange of the ExchangeManager.
+func (r *Router) Plan(p *pair.Pair, side Side, quantity float64) (*Plan, error) {
+	return r.PlanExchanges(p, side, quantity, r.exMan.GetExchanges())
+}
+
+// PlanExchanges is Plan limited to the given exchanges.
+func (r *Router) PlanExchanges(p *pair.Pair, side Side, quantity float64, exchanges []exchange.Exchange) (*Plan, error) {
+	if side != BUY && side != SELL {
+		return nil, fmt.Errorf("Invalid side: %v", side)
+	}
+	if quantity <= 0 {
+		return nil, fmt.Errorf("Invalid quantity: %v", quantity)
+	}
+
+	balances := make(map[exchange.Exchange]float64)
+	budgets := make(map[exchange.Exchange]float64)
+	levels := []level{}
+	for _, ex := range exchanges {
+		if !ex.HasPair(p) {
+			continue
+		}
+		if r.UpdateBalances {
+			ex.UpdateAllBalances()
+		}
+		budget := ex.GetBalance(p.Target)
+		if side == BUY {
+			budget = ex.GetBalance(p.Base)
+		}
+		if budget <= 0 {
+			continue
+		}
+		maker, err := ex.OrderBook(p)
+		if err != nil || maker == nil {
+			log.Printf("%s %s OrderBook Err: %v", ex.GetName(), p.Name, err)
+			continue
+		}
+		balances[ex] = budget
+		budgets[ex] = budget
+
+		fee := ex.GetFee(p)
+		orders := orderbook.SortedBids(maker)
+		if side == BUY {
+			orders = orderbook.SortedAsks(maker)
+		}
+		for _, order := range orders {
+			l := level{ex: ex, rate: order.Rate, quantity: order.Quantity, effective: order.Rate * (1 - fee)}
+			if side == BUY {
+				l.effective = order.Rate * (1 + fee)
+			}
+			levels = append(levels, l)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("No exchange with balance and order book for %s", p.Name)
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		if side == BUY {
+			return levels[i].effective < levels[j].effective
+		}
+		return levels[i].effective > levels[j].effective
+	})
+
+	// take the best levels while the budget of their exchange allows
+	children := make(map[exchange.Exchange]*Child)
+	order := []exchange.Exchange{}
+	left := decimal.NewFromFloat(quantity)
+	for _, l := range levels {
+		if left.Sign() <= 0 {
+			break
+		}
+		budget := budgets[l.ex]
+		if side == BUY {
+			budget = budget / l.effective
+		}
+		take := math.Min(math.Min(left.Float64(), l.quantity), budget)
+
+		// keep the child on the lot size so the rest is taken from the next levels
+		child, ok := children[l.ex]
+		if !ok {
+			child = &Child{Exchange: l.ex}
+		}
+		sum := addToStep(child.Quantity, take, l.ex.GetLotSize(p))
+		taken := sum.Sub(decimal.NewFromFloat(child.Quantity))
+		if taken.Sign() <= 0 {
+			continue
+		}
+		take = taken.Float64()
+		if side == BUY {
+			budgets[l.ex] -= take * l.effective
+		} else {
+			budgets[l.ex] -= take
+		}
+		left = left.Sub(taken)
+
+		if !ok {
+			children[l.ex] = child
+			order = append(order, l.ex)
+		}
+		child.Quantity = sum.Float64()
+		child.Amount += take * l.rate
+		child.Rate = l.rate
+	}
+
+	plan := &Plan{Pair: p, Side: side, Quantity: quantity}
+	for _, ex := range order {
+		child := children[ex]
+		r.roundChild(child, p, side, balances[ex])
+		if child.Quantity <= 0 {
+			continue
+		}
+		plan.Children = append(plan.Children, child)
+		plan.Planned += child.Quantity
+		plan.Amount += child.Amount
+		plan.Fee += child.Fee
+	}
+	if len(plan.Children) == 0 {
+		return nil, fmt.Errorf("Quantity %v of %s is below the lot size of every exchange", quantity, p.Name)
+	}
+	return plan, nil
+}
+
+// roundChild snaps the limit rate to the price filter on the marketable side and
+// floors the quantity to the lot size, within the balance at the rounded rate.
+// The expected amount is scaled to match.
+func (r *Router) roundChild(child *Child, p *pair.Pair, side Side, balance float64) {
+	ex := child.Exchange
+	priceFilter := ex.GetPriceFilter(p)
+	if side == BUY {
+		child.Rate = exchange.SnapToStep(child.Rate, priceFilter, decimal.Decimal.Ceil)
+	} else {
+		child.Rate = exchange.SnapToStep(child.Rate, priceFilter, decimal.Decimal.Floor)
+	}
+
+	quantity := child.Quantity
+	if side == BUY {
+		quantity = math.Min(quantity, balance/(child.Rate*(1+ex.GetFee(p))))
+	}
+	quantity = exchange.SnapToStep(quantity, ex.GetLotSize(p), decimal.Decimal.Floor)
+	if child.Quantity > 0 {
+		child.Amount = child.Amount * quantity / child.Quantity
+	}
+	child.Quantity = quantity
+	child.Fee = child.Amount * ex.GetFee(p)
+}
+
+// addToStep adds take to the child quantity and floors the sum to the lot size,
+// in decimal so the summed quantities have no float artefacts.
+func addToStep(quantity, take, lotSize float64) decimal.Decimal {
+	sum := decimal.NewFromFloat(quantity).Add(decimal.NewFromFloat(take))
+	if lotSize > 0 {
+		sum = sum.Floor(decimal.NewFromFloat(lotSize))
+	}
+	return sum
+}
+
+// Execute plans the order and, unless DryRun, places the children at the same
+// time and waits until each is done or cancelled after Timeout. It fails when
+// no child is filled, the Report keeps the Fills with their errors.
+func (r *Router) Execute(p *pair.Pair, side Side, quantity float64) (*Report, error) {
+	return r.ExecuteExchanges(p, side, quantity, r.exMan.GetExchanges())
+}
+
+// ExecuteExchanges is Execute limited to the given exchanges.
+func (r *Router) ExecuteExchanges(p *pair.Pair, side Side, quantity float64, exchanges []exchange.Exchange) (*Report, error) {
+	plan, err := r.PlanExchanges(p, side, quantity, exchanges)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Plan: plan}
+	if r.DryRun {
+		return report, nil
+	}
+	if r.Timeout <= 0 {
+		return nil, fmt.Errorf("Invalid timeout: %v", r.Timeout)
+	}
+
+	report.Fills = make([]*Fill, len(plan.Children))
+	var wg sync.WaitGroup
+	for i, child := range plan.Children {
+		wg.Add(1)
+		go func(i int, child *Child) {
+			defer wg.Done()
+			report.Fills[i] = r.place(p, side, child)
+		}(i, child)
+	}
+	wg.Wait()
+
+	for _, fill := range report.Fills {
+		if fill.Order == nil {
+			continue
+		}
+		report.Quantity += fill.Order.DealQuantity
+		report.Amount += fill.Order.DealQuantity * fill.Order.DealRate
+		report.Fee += fill.Order.DealQuantity * fill.Order.DealRate * fill.Child.Exchange.GetFee(p)
+	}
+	if report.Quantity <= 0 {
+		errs := []string{}
+		for _, fill := range report.Fills {
+			if fill.Err != nil {
+				errs = append(errs, fill.Err.Error())
+			}
+		}
+		if len(errs) > 0 {
+			return report, fmt.Errorf("No order of %s is filled: %s", p.Name, strings.Join(errs, "; "))
+		}
+		return report, fmt.Errorf("No order of %s is filled before the timeout", p.Name)
+	}
+	report.Rate = report.Amount / report.Quantity
+	return report, nil
+}
+
+func (r *Router) place(p *pair.Pair, side Side, child *Child) *Fill {
+	fill := &Fill{Child: child}
+	ex := child.Exchange
+
+	var order *exchange.Order
+	var err error
+	if side == BUY {
+		order, err = ex.LimitBuy(p, child.Quantity, child.Rate)
+	} else {
+		order, err = ex.LimitSell(p, child.Quantity, child.Rate)
+	}
+	if err != nil {
+		fill.Err = fmt.Errorf("%s %s %v %v@%v failed: %v", ex.GetName(), side, p.Name, child.Quantity, child.Rate, err)
+		return fill
+	}
+	fill.Order = order
+
+	pollInterval := r.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DEFAULT_POLL_INTERVAL
+	}
+	deadline := time.Now().Add(r.Timeout)
+	for !done(order) {
+		if time.Now().After(deadline) {
+			if err := ex.CancelOrder(order); err != nil {
+				fill.Err = fmt.Errorf("%s CancelOrder %s failed: %v", ex.GetName(), order.OrderID, err)
+			}
+			// the cancel may race with fills, take the final state
+			if err := ex.OrderStatus(order); err != nil && fill.Err == nil {
+				fill.Err = err
+			}
+			break
+		}
+		time.Sleep(pollInterval)
+		if err := ex.OrderStatus(order); err != nil {
+			log.Printf("%s OrderStatus %s Err: %v", ex.GetName(), order.OrderID, err)
+		}
+	}
+	return fill
+}
+
+func done(order *exchange.Order) bool {
+	switch order.Status {
+	case exchange.Filled, exchange.Cancelled, exchange.Rejected, exchange.Expired:
+		return true
+	}
+	return false
+}
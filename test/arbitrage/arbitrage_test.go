@@ -0,0 +1,105 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bitontop/gored/arbitrage"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func Test_Scan(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	cheap := fake.CreateExchange(9003, "FAKE_CHEAP")
+	cheap.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+	cheap.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", TxFee: 0.01, Withdraw: true, Deposit: true, Listed: true})
+	cheap.SetOrderBook(ethbtc, &exchange.Maker{
+		Asks: []exchange.Order{{Rate: 0.021, Quantity: 5}, {Rate: 0.020, Quantity: 1}},
+		Bids: []exchange.Order{{Rate: 0.019, Quantity: 10}},
+	})
+
+	dear := fake.CreateExchange(9004, "FAKE_DEAR")
+	dear.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+	dear.SetOrderBook(ethbtc, &exchange.Maker{
+		Asks: []exchange.Order{{Rate: 0.023, Quantity: 10}},
+		Bids: []exchange.Order{{Rate: 0.022, Quantity: 2}, {Rate: 0.0205, Quantity: 10}},
+	})
+
+	exMan := exchange.CreateExchangeManager()
+	exMan.Add(cheap)
+	exMan.Add(dear)
+
+	scanner := arbitrage.CreateScanner(0)
+	opportunities := scanner.ScanExchanges([]exchange.Exchange{cheap, dear})
+	if len(opportunities) != 1 {
+		t.Fatalf("Expected 1 opportunity, got %d", len(opportunities))
+	}
+
+	// buy 1 @0.020 + 1.01 @0.021, withdraw 0.01, sell 2 @0.022
+	o := opportunities[0]
+	if o.BuyEx != cheap || o.SellEx != dear {
+		t.Errorf("Expected buy on %s and sell on %s, got %s -> %s", cheap.GetName(), dear.GetName(), o.BuyEx.GetName(), o.SellEx.GetName())
+	}
+	checkFloat(t, "Quantity", o.Quantity, 2.01)
+	checkFloat(t, "SellQuantity", o.SellQuantity, 2)
+	checkFloat(t, "Cost", o.Cost, (0.020+1.01*0.021)*1.001)
+	checkFloat(t, "Revenue", o.Revenue, 2*0.022*0.999)
+	checkFloat(t, "Profit", o.Profit, o.Revenue-o.Cost)
+
+	// nothing can be moved when the withdrawal is disabled
+	cheap.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", TxFee: 0.01, Withdraw: false, Deposit: true, Listed: true})
+	if opportunities := scanner.ScanExchanges([]exchange.Exchange{cheap, dear}); len(opportunities) != 0 {
+		t.Errorf("Expected no opportunity with withdraw disabled, got %d", len(opportunities))
+	}
+}
+
+// the best quantity sells up a level of bids, after an ask level is used up: the
+// bids level is not where the fee-adjusted prices cross
+func Test_ScanBidBreakpoint(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	cheap := fake.CreateExchange(9025, "FAKE_BREAKPOINT_CHEAP")
+	cheap.AddPair(ethbtc, 0.001, 0.001, 0.000001)
+	cheap.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", TxFee: 0.01, Withdraw: true, Deposit: true, Listed: true})
+	buyBook := &exchange.Maker{Asks: []exchange.Order{{Rate: 0.020, Quantity: 2.005}, {Rate: 0.021, Quantity: 10}}}
+
+	dear := fake.CreateExchange(9026, "FAKE_BREAKPOINT_DEAR")
+	dear.AddPair(ethbtc, 0.001, 0.001, 0.000001)
+	sellBook := &exchange.Maker{Bids: []exchange.Order{{Rate: 0.022, Quantity: 2}, {Rate: 0.0205, Quantity: 10}}}
+
+	// buying 2.015 sells 0.005 @0.0205 below the 0.021 ask, buying 2.005 leaves 0.005 @0.022 unsold
+	o := arbitrage.Evaluate(ethbtc, cheap, dear, buyBook, sellBook)
+	if o == nil {
+		t.Fatal("Expected an opportunity")
+	}
+	checkFloat(t, "Quantity", o.Quantity, 2.01)
+	checkFloat(t, "SellQuantity", o.SellQuantity, 2)
+	checkFloat(t, "Cost", o.Cost, (2.005*0.020+0.005*0.021)*1.001)
+	checkFloat(t, "Revenue", o.Revenue, 2*0.022*0.999)
+}
+
+func checkFloat(t *testing.T, name string, got, expected float64) {
+	if math.Abs(got-expected) > 1e-9 {
+		t.Errorf("%s: expected %v, got %v", name, expected, got)
+	}
+}
@@ -0,0 +1,191 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/backtest"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/recorder"
+	"github.com/bitontop/gored/test/fake"
+)
+
+const t0 = 1.5e12
+
+var btc = &coin.Coin{ID: 1, Code: "BTC"}
+var eth = &coin.Coin{ID: 2, Code: "ETH"}
+
+// transfer buys ETH on A, withdraws it to B and sells it there on arrival.
+type transfer struct {
+	t      *testing.T
+	a, b   *backtest.Venue
+	bought bool
+	sold   bool
+}
+
+func (s *transfer) OnStart(engine *backtest.Engine) {
+	s.a = engine.Venue("FAKE_BACKTEST_A")
+	s.b = engine.Venue("FAKE_BACKTEST_B")
+}
+
+func (s *transfer) OnBook(venue *backtest.Venue, p *pair.Pair, maker *exchange.Maker) {
+	if venue == s.a && !s.bought {
+		if _, err := s.a.LimitBuy(p, 1, 100); err != nil {
+			s.t.Fatal(err)
+		}
+		if !s.a.Withdraw(eth, 1, string(s.b.GetName()), "") {
+			s.t.Fatal("Withdraw failed")
+		}
+		s.bought = true
+	}
+	if venue == s.b && !s.sold && s.b.GetBalance(eth) > 0 {
+		if _, err := s.b.LimitSell(p, s.b.GetBalance(eth), 110); err != nil {
+			s.t.Fatal(err)
+		}
+		s.sold = true
+	}
+}
+
+func (s *transfer) OnTrade(venue *backtest.Venue, p *pair.Pair, trade *recorder.Trade) {
+}
+
+func Test_Backtest(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	a := fake.CreateExchange(9014, "FAKE_BACKTEST_A")
+	a.AddPair(ethbtc, 0.002, 0.01, 0.01)
+	a.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", TxFee: 0.01, Withdraw: true, Deposit: true, Listed: true})
+	b := fake.CreateExchange(9015, "FAKE_BACKTEST_B")
+	b.AddPair(ethbtc, 0.002, 0.01, 0.01)
+	b.SetPairConstraint(&exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, ExSymbol: "ETHBTC", MakerFee: 0.001, TakerFee: 0.002, LotSize: 0.01, PriceFilter: 0.01, Listed: true})
+	b.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", Confirmation: 2, Withdraw: true, Deposit: true, Listed: true})
+
+	engine := backtest.CreateEngine(btc)
+	engine.AddVenue(a).SetBalance(btc, 1000)
+	engine.AddVenue(b)
+
+	bidsA := []exchange.Order{{Rate: 99, Quantity: 5}}
+	asksA := []exchange.Order{{Rate: 100, Quantity: 5}}
+	bidsB := []exchange.Order{{Rate: 108, Quantity: 5}}
+	asksB := []exchange.Order{{Rate: 110, Quantity: 3}}
+	engine.AddSnapshots([]*recorder.Snapshot{
+		recorder.CreateSnapshot(a.GetName(), ethbtc, &exchange.Maker{AfterTimestamp: t0, Bids: bidsA, Asks: asksA}),
+		recorder.CreateSnapshot(b.GetName(), ethbtc, &exchange.Maker{AfterTimestamp: t0, Bids: bidsB, Asks: asksB}),
+		// the ETH arrives 2 confirmations of a minute later, meanwhile its price drops
+		recorder.CreateSnapshot(b.GetName(), ethbtc, &exchange.Maker{AfterTimestamp: t0 + 60000, Bids: []exchange.Order{{Rate: 90, Quantity: 5}}, Asks: []exchange.Order{{Rate: 92, Quantity: 5}}}),
+		recorder.CreateSnapshot(b.GetName(), ethbtc, &exchange.Maker{AfterTimestamp: t0 + 150000, Bids: bidsB, Asks: asksB}),
+	})
+	engine.AddTrades([]*recorder.Trade{
+		// the first 3 at 110 are ahead of the sell order
+		{ExName: b.GetName(), Pair: ethbtc.Name, Timestamp: t0 + 160000, Rate: 110, Quantity: 2, Side: "Buy"},
+		{ExName: b.GetName(), Pair: ethbtc.Name, Timestamp: t0 + 170000, Rate: 110, Quantity: 2, Side: "Buy"},
+	})
+
+	strategy := &transfer{t: t}
+	report := engine.Run(strategy)
+
+	if len(report.Transfers) != 1 || !report.Transfers[0].Arrive.Equal(time.Unix(0, t0*1e6).Add(2*time.Minute)) {
+		t.Fatalf("Expected 1 transfer arriving after 2 minutes, got %v", report.Transfers)
+	}
+	if len(report.Fills) != 2 {
+		t.Fatalf("Expected 2 fills, got %d", len(report.Fills))
+	}
+	buy, sell := report.Fills[0], report.Fills[1]
+	if buy.Maker || buy.Rate != 100 || buy.Quantity != 1 {
+		t.Errorf("Expected taker buy of 1 @100, got %+v", buy)
+	}
+	if !sell.Maker || sell.Rate != 110 || sell.Time.UnixNano() != int64(t0+170000)*1e6 {
+		t.Errorf("Expected maker sell @110 at the second trade, got %+v", sell)
+	}
+	checkFloat(t, "Sell quantity", sell.Quantity, 0.99)
+	checkFloat(t, "Buy fee", buy.Fee, 100*0.002)
+	checkFloat(t, "Sell fee", sell.Fee, 0.99*110*0.001)
+	checkFloat(t, "BTC fees", report.Fees["BTC"], 100*0.002+0.99*110*0.001)
+
+	checkFloat(t, "StartEquity", report.StartEquity, 1000)
+	checkFloat(t, "EndEquity", report.EndEquity, 1000-100.2+0.99*110*0.999)
+	checkFloat(t, "PnL", report.PnL, report.EndEquity-report.StartEquity)
+	// from 0.99 ETH in transit at the mid 109 to the mid 91
+	peak := 1000 - 100.2 + 0.99*109
+	checkFloat(t, "MaxDrawdown", report.MaxDrawdown, 0.99*(109-91)/peak)
+}
+
+// rest buys at 100 before the book, then buys again at 100 on the book
+type rest struct {
+	t      *testing.T
+	venue  *backtest.Venue
+	orders []*exchange.Order
+}
+
+func (s *rest) OnStart(engine *backtest.Engine) {
+	s.venue = engine.Venue("FAKE_BACKTEST_C")
+}
+
+func (s *rest) OnBook(venue *backtest.Venue, p *pair.Pair, maker *exchange.Maker) {
+	if len(s.orders) > 1 {
+		return
+	}
+	order, err := venue.LimitBuy(p, 1, 100)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	s.orders = append(s.orders, order)
+}
+
+func (s *rest) OnTrade(venue *backtest.Venue, p *pair.Pair, trade *recorder.Trade) {
+}
+
+// the quantity of a book filling the resting orders is not taken again by the later orders
+func Test_BookFillsAreTaken(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	c := fake.CreateExchange(9028, "FAKE_BACKTEST_C")
+	c.AddPair(ethbtc, 0.001, 0.01, 0.01)
+	engine := backtest.CreateEngine(btc)
+	engine.AddVenue(c).SetBalance(btc, 1000)
+
+	engine.AddSnapshots([]*recorder.Snapshot{
+		recorder.CreateSnapshot(c.GetName(), ethbtc, &exchange.Maker{AfterTimestamp: t0, Bids: []exchange.Order{{Rate: 99, Quantity: 5}}, Asks: []exchange.Order{{Rate: 101, Quantity: 5}}}),
+		// 1.5 at 100 fills the resting order, the second order takes the 0.5 left
+		recorder.CreateSnapshot(c.GetName(), ethbtc, &exchange.Maker{AfterTimestamp: t0 + 1000, Bids: []exchange.Order{{Rate: 99, Quantity: 5}}, Asks: []exchange.Order{{Rate: 100, Quantity: 1.5}}}),
+	})
+
+	strategy := &rest{t: t}
+	report := engine.Run(strategy)
+
+	if len(report.Fills) != 2 {
+		t.Fatalf("Expected 2 fills, got %d", len(report.Fills))
+	}
+	resting, taker := report.Fills[0], report.Fills[1]
+	if !resting.Maker || resting.OrderID != "1" || resting.Quantity != 1 {
+		t.Errorf("Expected maker fill of 1 for the resting order, got %+v", resting)
+	}
+	if taker.Maker || taker.OrderID != "2" || taker.Quantity != 0.5 {
+		t.Errorf("Expected taker fill of 0.5 for the second order, got %+v", taker)
+	}
+	orders, _ := strategy.venue.ListOrders()
+	if len(orders) != 1 || orders[0].DealQuantity != 0.5 {
+		t.Errorf("Expected the second order partially filled, got %v", orders)
+	}
+}
+
+func checkFloat(t *testing.T, name string, got, expected float64) {
+	if math.Abs(got-expected) > 1e-9 {
+		t.Errorf("%s: expected %v, got %v", name, expected, got)
+	}
+}
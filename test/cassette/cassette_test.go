@@ -0,0 +1,234 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitontop/gored/cassette"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/binance"
+	"github.com/bitontop/gored/exchange/huobi"
+	"github.com/bitontop/gored/exchange/kucoin"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+const (
+	testKey        = "test-api-key"
+	testSecret     = "test/api+secret"
+	testPassphrase = "test-passphrase"
+)
+
+func Test_RecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Echo-Key", r.Header.Get("X-KEY"))
+		fmt.Fprintf(w, `{"path":"%s","key":"%s"}`, r.URL.Path, r.URL.Query().Get("apiKey"))
+	}))
+
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "record.json")
+
+	recorder, err := cassette.Open(path, cassette.RECORD, testKey, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchange.SetHttpTransport(recorder)
+	defer exchange.SetHttpTransport(nil)
+
+	params := map[string]string{"symbol": "ETHBTC", "apiKey": testKey, "timestamp": "1", "signature": "abc"}
+	recorded := exchange.HttpGetRequest(server.URL+"/order", params)
+	request, _ := http.NewRequest("POST", server.URL+"/withdraw", strings.NewReader(`{"secret":"`+testSecret+`","nonce":1,"coin":"ETH"}`))
+	request.Header.Set("X-KEY", testKey)
+	if _, err := exchange.NewHttpClient().Do(request); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), testKey) || strings.Contains(string(data), "api+secret") || strings.Contains(string(data), "abc") {
+		t.Fatalf("Cassette leaks a secret: %s", data)
+	}
+
+	// the server is gone, the signature and timestamp differ
+	player, err := cassette.Open(path, cassette.REPLAY, testKey, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchange.SetHttpTransport(player)
+
+	params = map[string]string{"symbol": "ETHBTC", "apiKey": testKey, "timestamp": "2", "signature": "def"}
+	replayed := exchange.HttpGetRequest(server.URL+"/order", params)
+	if replayed != strings.Replace(recorded, testKey, cassette.SCRUBBED, -1) {
+		t.Errorf("Expected the recorded response, got %v", replayed)
+	}
+
+	request, _ = http.NewRequest("POST", server.URL+"/withdraw", strings.NewReader(`{"coin":"ETH","nonce":2,"secret":"`+testSecret+`"}`))
+	response, err := exchange.NewHttpClient().Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Header.Get("X-Echo-Key") != cassette.SCRUBBED {
+		t.Errorf("Expected the scrubbed header, got %v", response.Header.Get("X-Echo-Key"))
+	}
+
+	params["symbol"] = "LTCBTC"
+	if replayed := exchange.HttpGetRequest(server.URL+"/order", params); !strings.Contains(replayed, "no interaction") {
+		t.Errorf("Expected no interaction for another symbol, got %v", replayed)
+	}
+}
+
+// open replays the cassette of the venue as the transport of the adapters
+func open(t *testing.T, venue string, secrets ...string) *cassette.Transport {
+	player, err := cassette.Open(filepath.Join("testdata", venue+".json"), cassette.REPLAY, secrets...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchange.SetHttpTransport(player)
+	coin.Init()
+	pair.Init()
+	return player
+}
+
+// replay checks the recorded book, balances and order of ETH/BTC, runs the
+// conformance suite, then checks every request of the adapter was answered by
+// the cassette.
+func replay(t *testing.T, player *cassette.Transport, e exchange.Exchange, orderID string) {
+	btc, eth := coin.GetCoin("BTC"), coin.GetCoin("ETH")
+	ethbtc := pair.GetPair(btc, eth)
+
+	maker, err := e.OrderBook(ethbtc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(maker.Bids) != 2 || len(maker.Asks) != 2 || maker.Bids[0].Rate != 0.02 || maker.Asks[1].Quantity != 7.25 {
+		t.Errorf("%s unexpected order book: %+v", e.GetName(), maker)
+	}
+
+	e.UpdateAllBalances()
+	if e.GetBalance(btc) != 1.5 || e.GetBalance(eth) != 20 {
+		t.Errorf("%s expected balances 1.5 BTC and 20 ETH, got %v %v", e.GetName(), e.GetBalance(btc), e.GetBalance(eth))
+	}
+
+	order, err := e.LimitBuy(ethbtc, 1, 0.02)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.OrderID != orderID {
+		t.Errorf("%s expected order %v, got %v", e.GetName(), orderID, order.OrderID)
+	}
+	for _, expected := range []exchange.OrderStatus{exchange.Partial, exchange.Filled} {
+		if err := e.OrderStatus(order); err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != expected {
+			t.Errorf("%s expected %v, got %v", e.GetName(), expected, order.Status)
+		}
+	}
+	if order.DealQuantity != 1 {
+		t.Errorf("%s expected deal quantity 1, got %v", e.GetName(), order.DealQuantity)
+	}
+
+	conformance.Run(t, e, ethbtc)
+	conformance.RunTrading(t, e, ethbtc, 0.01, 1)
+
+	if missed := player.Missed(); len(missed) > 0 {
+		t.Errorf("%s requests missing in the cassette: %v", e.GetName(), missed)
+	}
+}
+
+func Test_BinanceReplay(t *testing.T) {
+	player := open(t, "binance", testKey, testSecret)
+	defer exchange.SetHttpTransport(nil)
+
+	config := &exchange.Config{
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    testKey,
+		API_SECRET: testSecret,
+	}
+	e := binance.CreateBinance(config)
+	if e == nil {
+		t.Fatal("Binance failed to init from the cassette")
+	}
+
+	btc, eth := coin.GetCoin("BTC"), coin.GetCoin("ETH")
+	ethbtc := pair.GetPair(btc, eth)
+	if len(e.GetPairs()) != 1 || !e.HasPair(ethbtc) {
+		t.Fatalf("Expected only ETHBTC trading, got %v", e.GetPairs())
+	}
+	if e.GetLotSize(ethbtc) != 0.001 || e.GetPriceFilter(ethbtc) != 0.000001 {
+		t.Errorf("Expected lot size 0.001 and price filter 0.000001, got %v %v", e.GetLotSize(ethbtc), e.GetPriceFilter(ethbtc))
+	}
+	if e.GetMakerFee(ethbtc) != 0.001 || e.GetTakerFee(ethbtc) != 0.001 {
+		t.Errorf("Expected the fees 0.001 of the account, got %v %v", e.GetMakerFee(ethbtc), e.GetTakerFee(ethbtc))
+	}
+	if e.GetTxFee(btc) != 0.0005 || !e.CanWithdraw(btc) || e.CanWithdraw(eth) {
+		t.Errorf("Unexpected coin constraints: %+v %+v", e.GetCoinConstraint(btc), e.GetCoinConstraint(eth))
+	}
+	if status := exchange.GetClock(e.GetName()).Status(); status.LastSync.IsZero() {
+		t.Errorf("Expected the clock synced with the server, got %+v", status)
+	}
+
+	replay(t, player, e, "28")
+}
+
+func Test_HuobiReplay(t *testing.T) {
+	player := open(t, "huobi", testKey, testSecret)
+	defer exchange.SetHttpTransport(nil)
+
+	e := huobi.CreateHuobi(&exchange.Config{Source: exchange.EXCHANGE_API, API_KEY: testKey, API_SECRET: testSecret})
+	if e == nil {
+		t.Fatal("Huobi failed to init from the cassette")
+	}
+	ethbtc := pair.GetPairByKey("BTC|ETH")
+	if len(e.GetPairs()) != 1 || e.GetLotSize(ethbtc) != 0.001 || e.GetPriceFilter(ethbtc) != 0.000001 {
+		t.Errorf("Expected ETH/BTC with lot size 0.001 and price filter 0.000001, got %v %+v", e.GetPairs(), e.GetPairConstraint(ethbtc))
+	}
+	if e.GetMakerFee(ethbtc) != 0.0018 || e.GetTakerFee(ethbtc) != 0.002 {
+		t.Errorf("Expected the fees 0.0018 and 0.002 of the account, got %v %v", e.GetMakerFee(ethbtc), e.GetTakerFee(ethbtc))
+	}
+
+	replay(t, player, e, "1001")
+	if e.Account_ID != "10001" {
+		t.Errorf("Expected account 10001, got %v", e.Account_ID)
+	}
+}
+
+func Test_KucoinReplay(t *testing.T) {
+	player := open(t, "kucoin", testKey, testSecret, testPassphrase)
+	defer exchange.SetHttpTransport(nil)
+
+	e := kucoin.CreateKucoin(&exchange.Config{Source: exchange.EXCHANGE_API, API_KEY: testKey, API_SECRET: testSecret, Passphrase: testPassphrase})
+	if e == nil {
+		t.Fatal("Kucoin failed to init from the cassette")
+	}
+	ethbtc := pair.GetPairByKey("BTC|ETH")
+	if len(e.GetPairs()) != 1 || e.GetLotSize(ethbtc) != 0.001 || e.GetPriceFilter(ethbtc) != 0.000001 {
+		t.Errorf("Expected ETH/BTC with lot size 0.001 and price filter 0.000001, got %v %+v", e.GetPairs(), e.GetPairConstraint(ethbtc))
+	}
+	if pc := e.GetPairConstraint(ethbtc); pc.MinQty != 0.001 || pc.MaxQty != 100000 || pc.MinNotional != 0.0001 {
+		t.Errorf("Expected the order limits of the symbol, got %+v", pc)
+	}
+
+	replay(t, player, e, "1001")
+}
@@ -0,0 +1,116 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// server is a server clock ahead of the local one, the replies delayed by the
+// next latency in the list.
+type server struct {
+	mutex     sync.Mutex
+	offset    time.Duration
+	latencies []time.Duration
+	err       error
+	calls     int
+}
+
+func (s *server) serverTime() (time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	if s.err != nil {
+		return time.Time{}, s.err
+	}
+	latency := time.Duration(0)
+	if len(s.latencies) > 0 {
+		latency, s.latencies = s.latencies[0], s.latencies[1:]
+	}
+	// the server reads its clock half way, the reply takes the other half
+	time.Sleep(latency / 2)
+	now := time.Now().Add(s.offset)
+	time.Sleep(latency / 2)
+	return now, nil
+}
+
+func near(a, b, tolerance time.Duration) bool {
+	return a-b < tolerance && b-a < tolerance
+}
+
+func Test_Sync(t *testing.T) {
+	s := &server{offset: -3 * time.Second, latencies: []time.Duration{200 * time.Millisecond, 20 * time.Millisecond, 100 * time.Millisecond}}
+	clock := exchange.SetServerTime("FAKE_CLOCK", s.serverTime)
+
+	if !near(clock.Now().Sub(time.Now()), 0, 10*time.Millisecond) {
+		t.Errorf("Expected the local time before the sync, got %v", clock.Now())
+	}
+	if err := clock.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	status := clock.Status()
+	if s.calls != exchange.CLOCK_SAMPLES {
+		t.Errorf("Expected %d samples, got %d", exchange.CLOCK_SAMPLES, s.calls)
+	}
+	// the sample with the shortest round trip is kept
+	if !near(status.RTT, 20*time.Millisecond, 10*time.Millisecond) {
+		t.Errorf("Expected the round trip of 20ms, got %v", status.RTT)
+	}
+	if !near(status.Offset, s.offset, 10*time.Millisecond) || !near(clock.Now().Sub(time.Now()), s.offset, 10*time.Millisecond) {
+		t.Errorf("Expected the offset %v, got %v", s.offset, status.Offset)
+	}
+	if exchange.GetClock("FAKE_CLOCK") != clock {
+		t.Error("Expected one clock per exchange")
+	}
+
+	// a failed sync keeps the offset
+	s.err = errors.New("timeout")
+	if err := clock.Sync(); err == nil || clock.Status().LastError != err {
+		t.Errorf("Expected the sync to fail, got %v", err)
+	}
+	if clock.Status().Offset != status.Offset {
+		t.Errorf("Expected the offset %v to be kept, got %v", status.Offset, clock.Status().Offset)
+	}
+
+	if err := exchange.GetClock("FAKE_NO_CLOCK").Sync(); err == nil {
+		t.Error("Expected the sync without a server time endpoint to fail")
+	}
+}
+
+func Test_Start(t *testing.T) {
+	s := &server{offset: time.Minute}
+	clock := exchange.SetServerTime("FAKE_CLOCK_START", s.serverTime)
+
+	clock.Start(20 * time.Millisecond)
+	if clock.Status().LastSync.IsZero() {
+		t.Error("Expected the clock to be synced when Start returns")
+	}
+	clock.Start(20 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	clock.Stop()
+
+	s.mutex.Lock()
+	calls := s.calls
+	s.mutex.Unlock()
+	// synced on start, then on every tick
+	if calls < 2*exchange.CLOCK_SAMPLES {
+		t.Errorf("Expected at least 2 syncs, got %d samples", calls)
+	}
+	if !near(clock.Status().Offset, time.Minute, 10*time.Millisecond) {
+		t.Errorf("Expected the offset of a minute, got %v", clock.Status().Offset)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.calls != calls {
+		t.Errorf("Expected no sync after Stop, got %d samples", s.calls-calls)
+	}
+}
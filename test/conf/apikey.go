@@ -35,6 +35,7 @@ func Exchange(name exchange.ExchangeName, config *exchange.Config) {
 	case exchange.BITMEX:
 		config.API_KEY = ""
 		config.API_SECRET = ""
+		config.Two_Factor = "" // the TOTP seed
 		break
 
 	case exchange.KUCOIN:
@@ -66,6 +67,8 @@ func Exchange(name exchange.ExchangeName, config *exchange.Config) {
 	case exchange.BIBOX:
 		config.API_KEY = ""
 		config.API_SECRET = ""
+		config.Two_Factor = "" // the TOTP seed
+		config.TradePassword = ""
 		break
 
 	case exchange.OKEX:
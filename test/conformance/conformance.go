@@ -0,0 +1,275 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// the statuses an order may move to from each status, an order stays in its
+// status between two polls
+var transitions = map[exchange.OrderStatus][]exchange.OrderStatus{
+	exchange.New:       {exchange.New, exchange.Partial, exchange.Filled, exchange.Canceling, exchange.Cancelled, exchange.Rejected, exchange.Expired},
+	exchange.Partial:   {exchange.Partial, exchange.Filled, exchange.Canceling, exchange.Cancelled, exchange.Expired},
+	exchange.Canceling: {exchange.Canceling, exchange.Cancelled, exchange.Filled},
+	exchange.Filled:    {exchange.Filled},
+	exchange.Cancelled: {exchange.Cancelled},
+	exchange.Rejected:  {exchange.Rejected},
+	exchange.Expired:   {exchange.Expired},
+}
+
+// Run checks the public data of the adapter: the order book of every pair, the
+// symbols and the constraints. Each check is a subtest, its failures are errors.
+func Run(t *testing.T, e exchange.Exchange, pairs ...*pair.Pair) {
+	if len(pairs) == 0 {
+		pairs = e.GetPairs()
+	}
+	if len(pairs) == 0 {
+		t.Fatalf("%s has no pair", e.GetName())
+	}
+
+	t.Run("Symbols", func(t *testing.T) {
+		report(t, CheckSymbols(e))
+	})
+	for _, p := range pairs {
+		p := p
+		t.Run("Constraints/"+p.Name, func(t *testing.T) {
+			report(t, CheckConstraints(e, p))
+		})
+		t.Run("OrderBook/"+p.Name, func(t *testing.T) {
+			maker, err := e.OrderBook(p)
+			if err != nil {
+				t.Fatalf("%s OrderBook %s: %v", e.GetName(), p.Name, err)
+			}
+			report(t, CheckMaker(maker))
+		})
+	}
+}
+
+// RunTrading places a buy order that must stay open at the rate, then checks its
+// status transitions and the cancel semantics. It trades with real funds on a
+// real adapter, the rate should be far from the book.
+func RunTrading(t *testing.T, e exchange.Exchange, p *pair.Pair, rate, quantity float64) {
+	report(t, CheckTrading(e, p, rate, quantity))
+}
+
+func report(t *testing.T, errs []error) {
+	t.Helper()
+	for _, err := range errs {
+		t.Error(err)
+	}
+}
+
+// CheckMaker checks the bids are sorted descending and the asks ascending, the
+// book is not crossed and the rates and quantities are positive.
+func CheckMaker(maker *exchange.Maker) []error {
+	if maker == nil {
+		return []error{fmt.Errorf("Order book is nil")}
+	}
+	errs := []error{}
+	check := func(side string, orders []exchange.Order, sorted func(prev, next float64) bool) {
+		for i, order := range orders {
+			if order.Rate <= 0 || order.Quantity <= 0 {
+				errs = append(errs, fmt.Errorf("%s[%d] rate %v quantity %v is not positive", side, i, order.Rate, order.Quantity))
+			}
+			if i > 0 && !sorted(orders[i-1].Rate, order.Rate) {
+				errs = append(errs, fmt.Errorf("%s[%d] rate %v is out of order after %v", side, i, order.Rate, orders[i-1].Rate))
+			}
+		}
+	}
+	check("Bids", maker.Bids, func(prev, next float64) bool { return prev > next })
+	check("Asks", maker.Asks, func(prev, next float64) bool { return prev < next })
+
+	if len(maker.Bids) == 0 && len(maker.Asks) == 0 {
+		errs = append(errs, fmt.Errorf("Order book is empty"))
+	} else if len(maker.Bids) > 0 && len(maker.Asks) > 0 && maker.Bids[0].Rate >= maker.Asks[0].Rate {
+		errs = append(errs, fmt.Errorf("Order book is crossed: bid %v >= ask %v", maker.Bids[0].Rate, maker.Asks[0].Rate))
+	}
+	return errs
+}
+
+// CheckSymbols checks every pair and coin round-trips through its symbol. A
+// symbol shared by several pairs or coins is reported once, whatever the one
+// the adapter maps it back to.
+func CheckSymbols(e exchange.Exchange) []error {
+	errs := []error{}
+
+	pairs := map[string][]*pair.Pair{}
+	for _, p := range e.GetPairs() {
+		symbol := e.GetSymbolByPair(p)
+		if symbol == "" {
+			errs = append(errs, fmt.Errorf("%s %s has no symbol", e.GetName(), p.Name))
+		} else {
+			pairs[symbol] = append(pairs[symbol], p)
+		}
+	}
+	for _, symbol := range sortedSymbols(pairs) {
+		shared := pairs[symbol]
+		if len(shared) > 1 {
+			names := []string{}
+			for _, p := range shared {
+				names = append(names, p.Name)
+			}
+			sort.Strings(names)
+			errs = append(errs, fmt.Errorf("%s symbol %s is shared by %s", e.GetName(), symbol, strings.Join(names, ", ")))
+		} else if got := e.GetPairBySymbol(symbol); got == nil || got.ID != shared[0].ID {
+			errs = append(errs, fmt.Errorf("%s symbol %s of %s maps back to %v", e.GetName(), symbol, shared[0].Name, got))
+		}
+	}
+
+	coins := map[string][]*coin.Coin{}
+	for _, c := range e.GetCoins() {
+		symbol := e.GetSymbolByCoin(c)
+		if symbol == "" {
+			errs = append(errs, fmt.Errorf("%s %s has no symbol", e.GetName(), c.Code))
+		} else {
+			coins[symbol] = append(coins[symbol], c)
+		}
+	}
+	for _, symbol := range sortedSymbols(coins) {
+		shared := coins[symbol]
+		if len(shared) > 1 {
+			codes := []string{}
+			for _, c := range shared {
+				codes = append(codes, c.Code)
+			}
+			sort.Strings(codes)
+			errs = append(errs, fmt.Errorf("%s symbol %s is shared by %s", e.GetName(), symbol, strings.Join(codes, ", ")))
+		} else if got := e.GetCoinBySymbol(symbol); got == nil || got.ID != shared[0].ID {
+			errs = append(errs, fmt.Errorf("%s symbol %s of %s maps back to %v", e.GetName(), symbol, shared[0].Code, got))
+		}
+	}
+	return errs
+}
+
+// sortedSymbols lists the symbols of the map in order, the map being of pairs or coins.
+func sortedSymbols(bySymbol interface{}) []string {
+	symbols := []string{}
+	switch m := bySymbol.(type) {
+	case map[string][]*pair.Pair:
+		for symbol := range m {
+			symbols = append(symbols, symbol)
+		}
+	case map[string][]*coin.Coin:
+		for symbol := range m {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// CheckConstraints checks the pair and its coins have constraints, with non-zero
+// lot size and price filter, and fees within [0, 1).
+func CheckConstraints(e exchange.Exchange, p *pair.Pair) []error {
+	errs := []error{}
+	pc := e.GetPairConstraint(p)
+	if pc == nil {
+		return []error{fmt.Errorf("%s %s has no pair constraint", e.GetName(), p.Name)}
+	}
+	if pc.LotSize <= 0 || e.GetLotSize(p) <= 0 {
+		errs = append(errs, fmt.Errorf("%s %s lot size %v is not positive", e.GetName(), p.Name, pc.LotSize))
+	}
+	if pc.PriceFilter <= 0 || e.GetPriceFilter(p) <= 0 {
+		errs = append(errs, fmt.Errorf("%s %s price filter %v is not positive", e.GetName(), p.Name, pc.PriceFilter))
+	}
+	for name, fee := range map[string]float64{"maker fee": pc.MakerFee, "taker fee": pc.TakerFee, "fee": e.GetFee(p)} {
+		if fee < 0 || fee >= 1 {
+			errs = append(errs, fmt.Errorf("%s %s %s %v is out of [0, 1)", e.GetName(), p.Name, name, fee))
+		}
+	}
+	for _, c := range []*exchange.CoinConstraint{e.GetCoinConstraint(p.Base), e.GetCoinConstraint(p.Target)} {
+		if c == nil {
+			errs = append(errs, fmt.Errorf("%s %s has no coin constraint for a coin", e.GetName(), p.Name))
+		} else if c.TxFee < 0 {
+			errs = append(errs, fmt.Errorf("%s %s withdraw fee %v is negative", e.GetName(), c.Coin.Code, c.TxFee))
+		}
+	}
+	return errs
+}
+
+// CheckTransition checks an order may move from one status to the other and its
+// dealt quantity does not go back nor over the order quantity.
+func CheckTransition(order *exchange.Order, from exchange.OrderStatus, fromDeal float64) error {
+	allowed := false
+	for _, status := range transitions[from] {
+		if status == order.Status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("Order %s moved from %v to %v", order.OrderID, from, order.Status)
+	}
+	if order.DealQuantity < fromDeal {
+		return fmt.Errorf("Order %s deal quantity went back from %v to %v", order.OrderID, fromDeal, order.DealQuantity)
+	}
+	if order.DealQuantity > order.Quantity*(1+1e-9) {
+		return fmt.Errorf("Order %s deal quantity %v is over its quantity %v", order.OrderID, order.DealQuantity, order.Quantity)
+	}
+	return nil
+}
+
+// CheckTrading places a buy order, polls it, cancels it and polls it again.
+// The placed order must echo the request, cancelling an open order must end it
+// Cancelled, unless it was filled meanwhile, and cancelling a closed order must
+// fail or leave its status unchanged.
+func CheckTrading(e exchange.Exchange, p *pair.Pair, rate, quantity float64) []error {
+	order, err := e.LimitBuy(p, quantity, rate)
+	if err != nil {
+		return []error{fmt.Errorf("%s LimitBuy: %v", e.GetName(), err)}
+	}
+	errs := []error{}
+	if order.OrderID == "" {
+		return []error{fmt.Errorf("%s LimitBuy returned no order ID", e.GetName())}
+	}
+	if order.Pair == nil || order.Pair.ID != p.ID || order.Side != "Buy" || order.Rate != rate || order.Quantity != quantity {
+		errs = append(errs, fmt.Errorf("%s LimitBuy returned %+v for %s %v @%v", e.GetName(), order, p.Name, quantity, rate))
+	}
+	if _, ok := transitions[order.Status]; !ok {
+		errs = append(errs, fmt.Errorf("%s LimitBuy returned status %v", e.GetName(), order.Status))
+		return errs
+	}
+
+	status, deal := order.Status, order.DealQuantity
+	poll := func(step string) bool {
+		if err := e.OrderStatus(order); err != nil {
+			errs = append(errs, fmt.Errorf("%s OrderStatus %s: %v", e.GetName(), step, err))
+			return false
+		}
+		if err := CheckTransition(order, status, deal); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %v", e.GetName(), step, err))
+		}
+		status, deal = order.Status, order.DealQuantity
+		return true
+	}
+	if !poll("after placing") {
+		return errs
+	}
+
+	open := status == exchange.New || status == exchange.Partial
+	cancelErr := e.CancelOrder(order)
+	if open && cancelErr != nil {
+		errs = append(errs, fmt.Errorf("%s CancelOrder of a %v order: %v", e.GetName(), status, cancelErr))
+	}
+	if !poll("after cancelling") {
+		return errs
+	}
+	if open && order.Status != exchange.Cancelled && order.Status != exchange.Canceling && order.Status != exchange.Filled {
+		errs = append(errs, fmt.Errorf("%s order %s is %v after cancelling", e.GetName(), order.OrderID, order.Status))
+	}
+
+	// a second cancel finds the order closed, it must fail or change nothing
+	if e.CancelOrder(order) == nil {
+		poll("after cancelling again")
+	}
+	return errs
+}
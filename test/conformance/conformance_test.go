@@ -0,0 +1,103 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+)
+
+var btc = &coin.Coin{ID: 1, Code: "BTC"}
+var eth = &coin.Coin{ID: 2, Code: "ETH"}
+
+// sloppy acknowledges the cancel without cancelling the order
+type sloppy struct {
+	*fake.Exchange
+}
+
+func (e *sloppy) CancelOrder(order *exchange.Order) error {
+	return nil
+}
+
+func setup(id int, name exchange.ExchangeName) (*fake.Exchange, *pair.Pair) {
+	coin.Init()
+	pair.Init()
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	e := fake.CreateExchange(id, name)
+	e.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+	e.SetOrderBook(ethbtc, &exchange.Maker{
+		Bids: []exchange.Order{{Rate: 0.02, Quantity: 1}, {Rate: 0.019, Quantity: 2}},
+		Asks: []exchange.Order{{Rate: 0.021, Quantity: 1}, {Rate: 0.022, Quantity: 2}},
+	})
+	return e, ethbtc
+}
+
+func Test_Conformance(t *testing.T) {
+	e, ethbtc := setup(9016, "FAKE_CONFORMANCE")
+	Run(t, e)
+	RunTrading(t, e, ethbtc, 0.01, 1)
+
+	e.AutoFill = true
+	RunTrading(t, e, ethbtc, 0.01, 1)
+}
+
+func Test_CheckMaker(t *testing.T) {
+	cases := map[string]*exchange.Maker{
+		"unsorted bids": {Bids: []exchange.Order{{Rate: 1, Quantity: 1}, {Rate: 2, Quantity: 1}}},
+		"unsorted asks": {Asks: []exchange.Order{{Rate: 2, Quantity: 1}, {Rate: 1, Quantity: 1}}},
+		"crossed":       {Bids: []exchange.Order{{Rate: 2, Quantity: 1}}, Asks: []exchange.Order{{Rate: 2, Quantity: 1}}},
+		"zero quantity": {Bids: []exchange.Order{{Rate: 1, Quantity: 0}}},
+		"negative rate": {Asks: []exchange.Order{{Rate: -1, Quantity: 1}}},
+		"empty":         {},
+	}
+	for name, maker := range cases {
+		if errs := CheckMaker(maker); len(errs) != 1 {
+			t.Errorf("%s: expected 1 error, got %v", name, errs)
+		}
+	}
+}
+
+func Test_CheckSymbolsAndConstraints(t *testing.T) {
+	e, ethbtc := setup(9017, "FAKE_CONFORMANCE_BAD")
+	e.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "BTC", TxFee: -1})
+	e.SetPairConstraint(&exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, ExSymbol: "ETHBTC", TakerFee: 1})
+
+	// whatever the coin the fake maps BTC back to, the shared symbol is the error
+	for i := 0; i < 20; i++ {
+		if errs := CheckSymbols(e); len(errs) != 1 || errs[0].Error() != "FAKE_CONFORMANCE_BAD symbol BTC is shared by BTC, ETH" {
+			t.Fatalf("Expected the BTC symbol shared by BTC and ETH, got %v", errs)
+		}
+	}
+	// lot size, price filter, taker fee, fee and withdraw fee
+	if errs := CheckConstraints(e, ethbtc); len(errs) != 5 {
+		t.Errorf("Expected 5 errors, got %v", errs)
+	}
+}
+
+func Test_CheckTrading(t *testing.T) {
+	e, ethbtc := setup(9018, "FAKE_CONFORMANCE_SLOPPY")
+	if errs := CheckTrading(&sloppy{e}, ethbtc, 0.01, 1); len(errs) != 1 {
+		t.Errorf("Expected the order to stay New after cancelling, got %v", errs)
+	}
+
+	order := &exchange.Order{OrderID: "1", Quantity: 1, Status: exchange.Cancelled}
+	if err := CheckTransition(order, exchange.Filled, 1); err == nil {
+		t.Error("Expected Filled to Cancelled to fail")
+	}
+	order.Status, order.DealQuantity = exchange.Partial, 0.5
+	if err := CheckTransition(order, exchange.Partial, 0.6); err == nil {
+		t.Error("Expected the deal quantity going back to fail")
+	}
+	if err := CheckTransition(order, exchange.New, 0); err != nil {
+		t.Error(err)
+	}
+}
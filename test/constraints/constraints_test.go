@@ -0,0 +1,147 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cmap "github.com/orcaman/concurrent-map"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+func setup() (*exchange.Constraints, *pair.Pair, *pair.Pair) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	ltc := &coin.Coin{ID: 3, Code: "LTC"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	coin.AddCoin(ltc)
+	ethbtc := pair.SetPair(1, btc, eth)
+	ltcbtc := pair.SetPair(2, btc, ltc)
+
+	c := exchange.NewConstraints("FAKE_CONSTRAINTS")
+	c.SetPair(&exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, ExSymbol: "ETHBTC", Listed: true})
+	c.SetPair(&exchange.PairConstraint{PairID: ltcbtc.ID, Pair: ltcbtc, ExSymbol: "LTCBTC", Listed: true})
+	return c, ethbtc, ltcbtc
+}
+
+// the pairs missing in the fetch are dropped, the stage resolves the symbols from the current data
+func Test_FetchDropsDelisted(t *testing.T) {
+	c, ethbtc, ltcbtc := setup()
+
+	stage := c.Stage()
+	err := c.Fetch(stage, func() error {
+		p := stage.PairBySymbol("ETHBTC")
+		if p == nil {
+			return fmt.Errorf("ETHBTC is not resolved by the stage")
+		}
+		stage.SetPair(&exchange.PairConstraint{PairID: p.ID, Pair: p, ExSymbol: "ETHBTC", LotSize: 0.1, Listed: true})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.HasPair(ltcbtc) {
+		t.Errorf("delisted pair is kept")
+	}
+	if pc := c.GetPair(ethbtc); pc == nil || pc.LotSize != 0.1 {
+		t.Errorf("fetched pair constraint: %+v", pc)
+	}
+}
+
+// a failed fetch or a fetch without pairs keeps the current data
+func Test_FetchFailure(t *testing.T) {
+	c, ethbtc, _ := setup()
+
+	stage := c.Stage()
+	err := c.Fetch(stage, func() error {
+		stage.SetPair(&exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, LotSize: 0.1})
+		return fmt.Errorf("exchange is down")
+	})
+	if err == nil {
+		t.Errorf("failed fetch returns no error")
+	}
+	if err := c.Fetch(c.Stage()); err == nil {
+		t.Errorf("fetch without pairs returns no error")
+	}
+	if len(c.Pairs()) != 2 || c.GetPair(ethbtc).LotSize != 0 {
+		t.Errorf("current data is changed by the failed fetches: %v", c.Pairs())
+	}
+}
+
+// a write during a fetch waits for the swap and is not lost
+func Test_WriteDuringFetch(t *testing.T) {
+	c, ethbtc, ltcbtc := setup()
+
+	written := make(chan struct{})
+	stage := c.Stage()
+	err := c.Fetch(stage, func() error {
+		go func() {
+			c.SetPair(&exchange.PairConstraint{PairID: ltcbtc.ID, Pair: ltcbtc, LotSize: 0.5, Listed: true})
+			close(written)
+		}()
+		select {
+		case <-written:
+			return fmt.Errorf("write is not blocked by the fetch")
+		case <-time.After(20 * time.Millisecond):
+		}
+		stage.SetPair(&exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, Listed: true})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-written
+	if pc := c.GetPair(ltcbtc); pc == nil || pc.LotSize != 0.5 {
+		t.Errorf("write during the fetch is lost: %+v", pc)
+	}
+}
+
+// a loaded pair without limits keeps the fetched ones, the loaded limits replace them
+func Test_LoadKeepsLimits(t *testing.T) {
+	c, ethbtc, ltcbtc := setup()
+	c.SetPair(&exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, ExSymbol: "ETHBTC", MinQty: 0.01, MaxOrders: 200, Listed: true})
+	c.SetPair(&exchange.PairConstraint{PairID: ltcbtc.ID, Pair: ltcbtc, ExSymbol: "LTCBTC", MinQty: 0.1, Listed: true})
+
+	load := func() cmap.ConcurrentMap {
+		pairs := cmap.New()
+		pairs.Set(fmt.Sprintf("%d", ethbtc.ID), &exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, ExSymbol: "ETHBTC", LotSize: 0.001, Listed: true})
+		pairs.Set(fmt.Sprintf("%d", ltcbtc.ID), &exchange.PairConstraint{PairID: ltcbtc.ID, Pair: ltcbtc, ExSymbol: "LTCBTC", MinNotional: 0.001, Listed: true})
+		return pairs
+	}
+	check := func(name string) {
+		if pc := c.GetPair(ethbtc); pc.MinQty != 0.01 || pc.MaxOrders != 200 || pc.LotSize != 0.001 {
+			t.Errorf("%s: pair loaded without limits: %+v", name, pc)
+		}
+		if pc := c.GetPair(ltcbtc); pc.MinQty != 0 || pc.MinNotional != 0.001 {
+			t.Errorf("%s: pair loaded with limits: %+v", name, pc)
+		}
+	}
+
+	c.Load(cmap.New(), load())
+	check("load")
+
+	// the stage of a load and fetch keeps the limits of the live pairs
+	stage := c.Stage()
+	stage.Load(cmap.New(), load())
+	if err := c.Fetch(stage); err != nil {
+		t.Fatal(err)
+	}
+	check("staged load")
+}
+
+func Test_NilConstraints(t *testing.T) {
+	var c *exchange.Constraints
+	if len(c.Coins()) != 0 || len(c.Pairs()) != 0 || c.PairBySymbol("ETHBTC") != nil {
+		t.Errorf("nil constraints are not empty")
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/utils"
+)
+
+func Test_DiffJsonData(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	old := &utils.JsonData{
+		CoinConstraint: []*exchange.CoinConstraint{
+			{CoinID: 1, ExSymbol: "BTC", TxFee: 0.0005, Withdraw: true, Deposit: true},
+			{CoinID: 2, ExSymbol: "ETH", TxFee: 0.01, Withdraw: true, Deposit: true},
+		},
+		PairConstraint: []*exchange.PairConstraint{
+			{PairID: 1, ExSymbol: "ETHBTC", LotSize: 0.001, PriceFilter: 0.000001},
+			{PairID: 2, ExSymbol: "LTCBTC", LotSize: 0.01, PriceFilter: 0.000001},
+		},
+	}
+	new := &utils.JsonData{
+		CoinConstraint: []*exchange.CoinConstraint{
+			{CoinID: 1, ExSymbol: "BTC", TxFee: 0.0004, Withdraw: true, Deposit: true},
+			{CoinID: 2, ExSymbol: "ETH", TxFee: 0.01, Withdraw: false, Deposit: true},
+		},
+		PairConstraint: []*exchange.PairConstraint{
+			{PairID: 1, ExSymbol: "ETHBTC", LotSize: 0.001, PriceFilter: 0.000001, MinNotional: 0.0001},
+			{PairID: 3, ExSymbol: "BNBBTC", LotSize: 0.01, PriceFilter: 0.0000001},
+		},
+	}
+
+	changes := utils.DiffJsonData(exchange.BINANCE, old, new)
+	expected := []struct {
+		Type   utils.ChangeType
+		CoinID int
+		PairID int
+		Field  string
+	}{
+		{utils.CHANGED, 1, 0, "TxFee"},
+		{utils.CHANGED, 2, 0, "Withdraw"},
+		{utils.CHANGED, 0, 1, "MinNotional"},
+		{utils.REMOVED, 0, 2, ""},
+		{utils.ADDED, 0, 3, ""},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i, e := range expected {
+		c := changes[i]
+		if c.Type != e.Type || c.CoinID != e.CoinID || c.PairID != e.PairID || c.Field != e.Field {
+			t.Errorf("change %d: expected %+v, got %v", i, e, c)
+		}
+	}
+	if changes[0].Old != 0.0005 || changes[0].New != 0.0004 {
+		t.Errorf("TxFee change: %v", changes[0])
+	}
+	if changes[3].Name != "LTCBTC" {
+		t.Errorf("unknown pair should be named by its symbol: %v", changes[3])
+	}
+
+	if changes := utils.DiffJsonData(exchange.BINANCE, old, old); len(changes) != 0 {
+		t.Errorf("identical snapshots: %v", changes)
+	}
+}
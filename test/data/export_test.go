@@ -0,0 +1,39 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/utils"
+)
+
+// the exported common.json must be identical to the file it is loaded from
+func Test_ExportCommonData(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	utils.GetCommonDataFromJSON("../../data")
+
+	dir, err := ioutil.TempDir("", "gored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := utils.ConvertBaseDataToJson(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	original, _ := ioutil.ReadFile("../../data/common.json")
+	exported, _ := ioutil.ReadFile(dir + "/common.json")
+	if !bytes.Equal(original, exported) {
+		t.Errorf("exported common.json differs from data/common.json: %d vs %d bytes", len(exported), len(original))
+	}
+}
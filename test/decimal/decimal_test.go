@@ -0,0 +1,165 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func parse(t *testing.T, s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func Test_Parse(t *testing.T) {
+	cases := map[string]string{
+		"12.3400": "12.34",
+		"-0.5":    "-0.5",
+		"+7":      "7",
+		"1e-8":    "0.00000001",
+		"1.5E+3":  "1500",
+		"0.000":   "0",
+		".25":     "0.25",
+	}
+	for in, expected := range cases {
+		if got := parse(t, in).String(); got != expected {
+			t.Errorf("%s: expected %s, got %s", in, expected, got)
+		}
+	}
+	for _, in := range []string{"", "-", "1.2.3", "abc", "1e", "--1"} {
+		if _, err := decimal.NewFromString(in); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
+
+// a negative scale multiplies the value
+func Test_New(t *testing.T) {
+	cases := map[string]decimal.Decimal{
+		"1.25":  decimal.New(125, 2),
+		"12500": decimal.New(125, -2),
+		"-3000": decimal.New(-3, -3),
+		"0":     decimal.New(0, -5),
+	}
+	for expected, d := range cases {
+		if d.String() != expected || !d.Equal(parse(t, expected)) {
+			t.Errorf("Expected %s, got %s", expected, d)
+		}
+	}
+	if got := decimal.New(125, -2).Add(decimal.New(1, 2)).String(); got != "12500.01" {
+		t.Errorf("Expected 12500.01, got %s", got)
+	}
+	if got := parse(t, "12345").Div(parse(t, "1"), -2).String(); got != "12300" {
+		t.Errorf("Expected 12300 to -2 places, got %s", got)
+	}
+}
+
+func Test_Arithmetic(t *testing.T) {
+	sum := decimal.NewFromFloat(0.1).Add(decimal.NewFromFloat(0.2))
+	if sum.String() != "0.3" || !sum.Equal(parse(t, "0.3")) {
+		t.Errorf("Expected 0.1 + 0.2 = 0.3, got %s", sum)
+	}
+	if got := parse(t, "1.005").Sub(parse(t, "0.005")).String(); got != "1" {
+		t.Errorf("Expected 1, got %s", got)
+	}
+	if got := parse(t, "1.1").Mul(parse(t, "-1.1")).String(); got != "-1.21" {
+		t.Errorf("Expected -1.21, got %s", got)
+	}
+	if got := parse(t, "2").Div(parse(t, "3"), 4).String(); got != "0.6666" {
+		t.Errorf("Expected 0.6666, got %s", got)
+	}
+	if got := parse(t, "-1").Div(parse(t, "0.03"), 2).String(); got != "-33.33" {
+		t.Errorf("Expected -33.33, got %s", got)
+	}
+	if parse(t, "0.10").Cmp(parse(t, "0.1")) != 0 || parse(t, "-2").Cmp(parse(t, "1")) != -1 {
+		t.Error("Unexpected Cmp")
+	}
+	if got := decimal.NewFromFloat(1e21).String(); got != "1000000000000000000000" {
+		t.Errorf("Expected 1e21 without exponent, got %s", got)
+	}
+}
+
+func Test_Step(t *testing.T) {
+	step := parse(t, "0.05")
+	cases := []struct {
+		value              string
+		floor, ceil, round string
+	}{
+		{"1.23", "1.2", "1.25", "1.25"},
+		{"1.225", "1.2", "1.25", "1.25"},
+		{"1.2", "1.2", "1.2", "1.2"},
+		{"-1.23", "-1.25", "-1.2", "-1.25"},
+	}
+	for _, c := range cases {
+		d := parse(t, c.value)
+		if got := d.Floor(step).String(); got != c.floor {
+			t.Errorf("Floor %s: expected %s, got %s", c.value, c.floor, got)
+		}
+		if got := d.Ceil(step).String(); got != c.ceil {
+			t.Errorf("Ceil %s: expected %s, got %s", c.value, c.ceil, got)
+		}
+		if got := d.Round(step).String(); got != c.round {
+			t.Errorf("Round %s: expected %s, got %s", c.value, c.round, got)
+		}
+	}
+	if got := parse(t, "1.5").StringFixed(4); got != "1.5000" {
+		t.Errorf("Expected 1.5000, got %s", got)
+	}
+	if got := parse(t, "-0.015").StringFixed(2); got != "-0.01" {
+		t.Errorf("Expected -0.01, got %s", got)
+	}
+}
+
+func Test_JSON(t *testing.T) {
+	var values struct {
+		A decimal.Decimal `json:"a"`
+		B decimal.Decimal `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"0.00012300","b":12.5}`), &values); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(values)
+	if string(data) != `{"a":"0.000123","b":"12.5"}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+}
+
+func Test_Format(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	e := fake.CreateExchange(9019, "FAKE_DECIMAL")
+	e.AddPair(ethbtc, 0.001, 0.01, 0.5)
+
+	// math.Log10 of a 0.5 tick gave 0 decimals and 2.5 was sent as "2"
+	if got := exchange.FormatRate(e, ethbtc, 2.4); got != "2.5" {
+		t.Errorf("Expected 2.5, got %s", got)
+	}
+	// the quantity is floored, rounding 1.999 up to 2.00 overspent the balance
+	if got := exchange.FormatQuantity(e, ethbtc, 1.999); got != "1.99" {
+		t.Errorf("Expected 1.99, got %s", got)
+	}
+	if got := exchange.FormatQuantity(e, ethbtc, 0.1+0.2); got != "0.30" {
+		t.Errorf("Expected 0.30, got %s", got)
+	}
+	if got := exchange.FormatToStep(0.12345, 0, decimal.Decimal.Floor); got != "0.12345" {
+		t.Errorf("Expected the value without a step as is, got %s", got)
+	}
+}
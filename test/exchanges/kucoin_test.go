@@ -31,9 +31,11 @@ func Test_Kucoin(t *testing.T) {
 	// Test_Orderbook(e, pair)
 	// Test_ConstraintFetch(e, pair)
 	// Test_Constraint(e, pair)
+	Test_Conformance(t, e, pair)
 
 	// Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.00000001, 100)
+	// Test_TradingConformance(t, e, pair, 0.00000001, 100)
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
 
 	Test_CheckBalance(e, pair.Target, exchange.AssetWallet)
@@ -0,0 +1,24 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"log"
+	"os"
+	"testing"
+)
+
+// LIVE_ENV runs the tests against the live exchange APIs with the keys of
+// test/conf, they are skipped without it. The adapters are tested offline by
+// test/cassette and test/mock.
+const LIVE_ENV = "GORED_LIVE"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(LIVE_ENV) == "" {
+		log.Printf("Live exchange tests are skipped, set %s=1 to run them", LIVE_ENV)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
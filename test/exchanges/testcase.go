@@ -6,10 +6,12 @@ package test
 
 import (
 	"log"
+	"testing"
 
 	"github.com/bitontop/gored/coin"
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -237,3 +239,15 @@ func Test_Constraint(e exchange.Exchange, p *pair.Pair) {
 	log.Printf("%s %s Coin Constraint: %+v", e.GetName(), p.Target.Code, targerConstraint)
 	log.Printf("%s %s Pair Constraint: %+v", e.GetName(), p.Name, pairConstrinat)
 }
+
+/********************Conformance********************/
+// Test_Conformance asserts the public data of the pairs, the helpers above only log
+func Test_Conformance(t *testing.T, e exchange.Exchange, pairs ...*pair.Pair) {
+	conformance.Run(t, e, pairs...)
+}
+
+// Test_TradingConformance asserts the order status and cancel semantics, the rate
+// should keep the order open
+func Test_TradingConformance(t *testing.T, e exchange.Exchange, p *pair.Pair, rate, quantity float64) {
+	conformance.RunTrading(t, e, p, rate, quantity)
+}
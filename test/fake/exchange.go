@@ -0,0 +1,346 @@
+package fake
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Exchange is an in-memory exchange.Exchange for offline tests.
+// The constraints, balances and order books are set by the test,
+// OnInitData is called by InitData if set. With AutoFill the orders
+// are filled at their rate when placed. BalanceUpdates counts the
+// UpdateAllBalances calls, the balances are kept.
+type Exchange struct {
+	ID             int
+	Name           exchange.ExchangeName
+	OnInitData     func(e *Exchange) error
+	AutoFill       bool
+	BalanceUpdates int
+
+	constraints *exchange.Constraints
+	balances    *exchange.Balances
+
+	mutex  sync.Mutex
+	books  map[int]*exchange.Maker
+	orders []*exchange.Order
+}
+
+func CreateExchange(id int, name exchange.ExchangeName) *Exchange {
+	return &Exchange{
+		ID:          id,
+		Name:        name,
+		constraints: exchange.NewConstraints(name),
+		balances:    exchange.NewBalances(name),
+		books:       make(map[int]*exchange.Maker),
+	}
+}
+
+// AddPair lists the pair and its coins with the given fee, lot size and price filter.
+func (e *Exchange) AddPair(p *pair.Pair, fee, lotSize, priceFilter float64) {
+	for _, c := range []*coin.Coin{p.Base, p.Target} {
+		if e.GetCoinConstraint(c) == nil {
+			e.SetCoinConstraint(&exchange.CoinConstraint{
+				CoinID:   c.ID,
+				Coin:     c,
+				ExSymbol: c.Code,
+				Withdraw: true,
+				Deposit:  true,
+				Listed:   true,
+			})
+		}
+	}
+	e.SetPairConstraint(&exchange.PairConstraint{
+		PairID:      p.ID,
+		Pair:        p,
+		ExSymbol:    p.Target.Code + p.Base.Code,
+		MakerFee:    fee,
+		TakerFee:    fee,
+		LotSize:     lotSize,
+		PriceFilter: priceFilter,
+		Listed:      true,
+	})
+}
+
+func (e *Exchange) SetBalance(c *coin.Coin, balance float64) {
+	e.balances.Set(c.Code, balance)
+}
+
+func (e *Exchange) SetOrderBook(p *pair.Pair, maker *exchange.Maker) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.books[p.ID] = maker
+}
+
+func (e *Exchange) InitData() error {
+	if e.OnInitData != nil {
+		return e.OnInitData(e)
+	}
+	return nil
+}
+
+/**************** Exchange Information ****************/
+func (e *Exchange) GetID() int {
+	return e.ID
+}
+
+func (e *Exchange) GetName() exchange.ExchangeName {
+	return e.Name
+}
+
+func (e *Exchange) GetTradingWebURL(pair *pair.Pair) string {
+	return ""
+}
+
+func (e *Exchange) GetBalance(coin *coin.Coin) float64 {
+	return e.balances.Get(coin.Code)
+}
+
+/*************** Coins on the Exchanges ***************/
+func (e *Exchange) GetCoinConstraint(coin *coin.Coin) *exchange.CoinConstraint {
+	return e.constraints.GetCoin(coin)
+}
+
+func (e *Exchange) SetCoinConstraint(coinConstraint *exchange.CoinConstraint) {
+	e.constraints.SetCoin(coinConstraint)
+}
+
+func (e *Exchange) GetCoins() []*coin.Coin {
+	return e.constraints.Coins()
+}
+
+func (e *Exchange) GetCoinBySymbol(symbol string) *coin.Coin {
+	return e.constraints.CoinBySymbol(symbol)
+}
+
+func (e *Exchange) GetSymbolByCoin(coin *coin.Coin) string {
+	if cc := e.GetCoinConstraint(coin); cc != nil {
+		return cc.ExSymbol
+	}
+	return ""
+}
+
+func (e *Exchange) DeleteCoin(coin *coin.Coin) {
+	e.constraints.DeleteCoin(coin)
+}
+
+/*************** Pairs on the Exchanges ***************/
+func (e *Exchange) GetPairConstraint(pair *pair.Pair) *exchange.PairConstraint {
+	return e.constraints.GetPair(pair)
+}
+
+func (e *Exchange) SetPairConstraint(pairConstraint *exchange.PairConstraint) {
+	e.constraints.SetPair(pairConstraint)
+}
+
+func (e *Exchange) GetPairs() []*pair.Pair {
+	return e.constraints.Pairs()
+}
+
+func (e *Exchange) GetPairBySymbol(symbol string) *pair.Pair {
+	return e.constraints.PairBySymbol(symbol)
+}
+
+func (e *Exchange) GetSymbolByPair(pair *pair.Pair) string {
+	if pc := e.GetPairConstraint(pair); pc != nil {
+		return pc.ExSymbol
+	}
+	return ""
+}
+
+func (e *Exchange) HasPair(pair *pair.Pair) bool {
+	return e.constraints.HasPair(pair)
+}
+
+func (e *Exchange) DeletePair(pair *pair.Pair) {
+	e.constraints.DeletePair(pair)
+}
+
+/*************** Public API ***************/
+func (e *Exchange) GetCoinsData() error {
+	return nil
+}
+
+func (e *Exchange) GetPairsData() error {
+	return nil
+}
+
+func (e *Exchange) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	maker, ok := e.books[p.ID]
+	if !ok {
+		return nil, fmt.Errorf("%s OrderBook: no order book for %s", e.Name, p.Name)
+	}
+	copied := *maker
+	copied.Bids = append([]exchange.Order{}, maker.Bids...)
+	copied.Asks = append([]exchange.Order{}, maker.Asks...)
+	return &copied, nil
+}
+
+/*************** Private API ***************/
+func (e *Exchange) UpdateAllBalances() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.BalanceUpdates++
+}
+
+func (e *Exchange) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
+	return false
+}
+
+func (e *Exchange) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return e.placeOrder(pair, "Sell", quantity, rate)
+}
+
+func (e *Exchange) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return e.placeOrder(pair, "Buy", quantity, rate)
+}
+
+// placeOrder records the order, it stays New until the test changes it
+// unless AutoFill.
+func (e *Exchange) placeOrder(pair *pair.Pair, side string, quantity, rate float64) (*exchange.Order, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	order := &exchange.Order{
+		Pair:     pair,
+		OrderID:  fmt.Sprintf("%d", len(e.orders)+1),
+		Rate:     rate,
+		Quantity: quantity,
+		Side:     side,
+		Status:   exchange.New,
+	}
+	if e.AutoFill {
+		order.Status = exchange.Filled
+		order.DealRate = rate
+		order.DealQuantity = quantity
+	}
+	e.orders = append(e.orders, order)
+	copied := *order
+	return &copied, nil
+}
+
+// Orders returns the orders placed so far.
+func (e *Exchange) Orders() []*exchange.Order {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]*exchange.Order{}, e.orders...)
+}
+
+func (e *Exchange) OrderStatus(order *exchange.Order) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, o := range e.orders {
+		if o.OrderID == order.OrderID {
+			order.Status = o.Status
+			order.DealRate = o.DealRate
+			order.DealQuantity = o.DealQuantity
+			return nil
+		}
+	}
+	return fmt.Errorf("%s OrderStatus: order %s not found", e.Name, order.OrderID)
+}
+
+func (e *Exchange) ListOrders() ([]*exchange.Order, error) {
+	return e.Orders(), nil
+}
+
+func (e *Exchange) CancelOrder(order *exchange.Order) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, o := range e.orders {
+		if o.OrderID == order.OrderID {
+			if o.Status != exchange.New && o.Status != exchange.Partial {
+				return fmt.Errorf("%s CancelOrder: order %s is %v", e.Name, order.OrderID, o.Status)
+			}
+			o.Status = exchange.Cancelled
+			order.Status = exchange.Cancelled
+			return nil
+		}
+	}
+	return fmt.Errorf("%s CancelOrder: order %s not found", e.Name, order.OrderID)
+}
+
+func (e *Exchange) CancelAllOrder() error {
+	return nil
+}
+
+/**************** Exchange Constraint ****************/
+func (e *Exchange) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetchMethod {
+	return &exchange.ConstrainFetchMethod{PublicAPI: true, PrivateAPI: true}
+}
+
+func (e *Exchange) UpdateConstraint() error {
+	return e.InitData()
+}
+
+/**************** Coin Constraint ****************/
+func (e *Exchange) GetTxFee(coin *coin.Coin) float64 {
+	if cc := e.GetCoinConstraint(coin); cc != nil {
+		return cc.TxFee
+	}
+	return 0.0
+}
+
+func (e *Exchange) CanWithdraw(coin *coin.Coin) bool {
+	if cc := e.GetCoinConstraint(coin); cc != nil {
+		return cc.Withdraw
+	}
+	return false
+}
+
+func (e *Exchange) CanDeposit(coin *coin.Coin) bool {
+	if cc := e.GetCoinConstraint(coin); cc != nil {
+		return cc.Deposit
+	}
+	return false
+}
+
+func (e *Exchange) GetConfirmation(coin *coin.Coin) int {
+	if cc := e.GetCoinConstraint(coin); cc != nil {
+		return cc.Confirmation
+	}
+	return 0
+}
+
+/**************** Pair Constraint ****************/
+func (e *Exchange) GetFee(pair *pair.Pair) float64 {
+	if pc := e.GetPairConstraint(pair); pc != nil {
+		return pc.TakerFee
+	}
+	return 0.0
+}
+
+func (e *Exchange) GetMakerFee(pair *pair.Pair) float64 {
+	return exchange.MakerFee(e, pair)
+}
+
+func (e *Exchange) GetTakerFee(pair *pair.Pair) float64 {
+	return exchange.TakerFee(e, pair)
+}
+
+func (e *Exchange) GetLotSize(pair *pair.Pair) float64 {
+	if pc := e.GetPairConstraint(pair); pc != nil {
+		return pc.LotSize
+	}
+	return 0.0
+}
+
+func (e *Exchange) GetPriceFilter(pair *pair.Pair) float64 {
+	if pc := e.GetPairConstraint(pair); pc != nil {
+		return pc.PriceFilter
+	}
+	return 0.0
+}
+
+/**************** Account Operation ****************/
+func (e *Exchange) DoAccoutOperation(operation *exchange.AccountOperation) error {
+	return fmt.Errorf("Operation type invalid: %v", operation.Type)
+}
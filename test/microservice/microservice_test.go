@@ -0,0 +1,152 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/binance"
+	"github.com/bitontop/gored/microservice"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+	"github.com/bitontop/gored/utils"
+)
+
+func Test_Microservice(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	coin.AddCoin(&coin.Coin{ID: 1, Code: "BTC"})
+	coin.AddCoin(&coin.Coin{ID: 2, Code: "ETH"})
+	pair.SetPair(1, coin.GetCoinByID(1), coin.GetCoinByID(2))
+
+	server := microservice.CreateServer("", time.Minute)
+	server.Build()
+
+	requests := 0
+	notModified := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") != "" {
+			notModified++
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	if !utils.GetCommonDataFromMicroservice(ts.URL) {
+		t.Fatalf("common data is not loaded")
+	}
+	if utils.GetCommonDataFromMicroservice(ts.URL) {
+		t.Errorf("unchanged common data is reported as modified")
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("requests: %d, with ETag: %d", requests, notModified)
+	}
+
+	if p := pair.GetPairByKey("BTC|ETH"); p == nil || p.ID != 1 {
+		t.Errorf("pair BTC|ETH: %+v", p)
+	}
+
+	if data := utils.GetExchangeDataFromMicroservice(ts.URL, "UNREGISTERED"); data != nil {
+		t.Errorf("unregistered exchange returns data")
+	}
+}
+
+// Binance loads the constraints served for a fake exchange registered under its name
+func Test_MicroserviceExchangeData(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	coin.AddCoin(&coin.Coin{ID: 1, Code: "BTC"})
+	coin.AddCoin(&coin.Coin{ID: 2, Code: "ETH"})
+	coin.AddCoin(&coin.Coin{ID: 3, Code: "LTC"})
+	btcEth := pair.SetPair(1, coin.GetCoinByID(1), coin.GetCoinByID(2))
+
+	ex := fake.CreateExchange(900, exchange.BINANCE)
+	ex.AddPair(btcEth, 0.001, 0.01, 0.0001)
+	exchange.CreateExchangeManager().Add(ex)
+
+	server := microservice.CreateServer("", time.Minute)
+	server.Build()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	e := binance.CreateBinance(&exchange.Config{Source: exchange.MICROSERVICE_API, SourceURI: ts.URL})
+	if e == nil {
+		t.Fatalf("Binance is not created from the microservice")
+	}
+	if pc := e.GetPairConstraint(btcEth); pc == nil || pc.LotSize != 0.01 {
+		t.Fatalf("pair constraint: %+v", pc)
+	}
+
+	// the adapter changes do not leak into the data of the next call
+	e.SetPairConstraint(&exchange.PairConstraint{PairID: btcEth.ID, Pair: btcEth, LotSize: 1})
+	data := utils.GetExchangeDataFromMicroservice(ts.URL, e.GetName())
+	if tmp, ok := data.PairConstraint.Get("1"); !ok || tmp.(*exchange.PairConstraint).LotSize != 0.01 {
+		t.Errorf("not modified data is shared with the adapter: %+v", tmp)
+	}
+
+	// a pair listed later is loaded by UpdateConstraint
+	btcLtc := pair.SetPair(2, coin.GetCoinByID(1), coin.GetCoinByID(3))
+	ex.AddPair(btcLtc, 0.001, 0.1, 0.00001)
+	server.Build()
+	if err := e.UpdateConstraint(); err != nil {
+		t.Fatal(err)
+	}
+	if pc := e.GetPairConstraint(btcLtc); pc == nil || pc.LotSize != 0.1 {
+		t.Errorf("listed pair constraint: %+v", pc)
+	}
+	if pc := e.GetPairConstraint(btcEth); pc == nil || pc.LotSize != 0.01 {
+		t.Errorf("pair constraint is not refreshed: %+v", pc)
+	}
+}
+
+// Run refreshes the exchanges by the scheduler until Shutdown
+func Test_MicroserviceRun(t *testing.T) {
+	var calls int32
+	ex := fake.CreateExchange(9024, "FAKE_SERVER")
+	ex.OnInitData = func(e *fake.Exchange) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	exchange.CreateExchangeManager().Add(ex)
+
+	server := microservice.CreateServer("127.0.0.1:0", 10*time.Millisecond)
+	done := make(chan error)
+	go func() {
+		done <- server.Run()
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&calls) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&calls) < 2 {
+		t.Errorf("timed refresh ran %d times", calls)
+	}
+
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returns %v after Shutdown", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Run does not return after Shutdown")
+	}
+	stopped := atomic.LoadInt32(&calls)
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&calls) != stopped {
+		t.Errorf("refresh continues after Shutdown")
+	}
+}
@@ -0,0 +1,231 @@
+package mock
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+var binanceErrors = map[error]int{
+	errUnknownSymbol: -1121,
+	errUnknownOrder:  -2013,
+	errOrderClosed:   -2011,
+	errLotSize:       -1013,
+	errPriceFilter:   -1013,
+	errBalance:       -2010,
+}
+
+var binanceStatus = map[exchange.OrderStatus]string{
+	exchange.New:       "NEW",
+	exchange.Partial:   "PARTIALLY_FILLED",
+	exchange.Filled:    "FILLED",
+	exchange.Cancelled: "CANCELED",
+}
+
+// StartBinance emulates api.binance.com and its HMAC-SHA256 signed query: the
+// hex signature of the query and the body without it, keyed by X-MBX-APIKEY,
+// with the timestamp within recvWindow of the server clock.
+func StartBinance(key, secret string) *Server {
+	return start("binance", key, secret, []string{"api.binance.com", "www.binance.com"}, func(s *Server) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/assetWithdraw/getAllAsset.html", s.binanceCoins)
+		mux.HandleFunc("/api/v1/exchangeInfo", s.binancePairs)
+		mux.HandleFunc("/api/v1/depth", s.binanceDepth)
+		mux.HandleFunc("/api/v3/time", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]int64{"serverTime": s.Now().UnixNano() / 1e6})
+		})
+		mux.HandleFunc("/api/v3/account", s.binanceSigned(s.binanceAccount))
+		mux.HandleFunc("/api/v3/order", s.binanceSigned(s.binanceOrder))
+		mux.HandleFunc("/sapi/v1/account/apiRestrictions", s.binanceSigned(s.binanceRestrictions))
+		return mux
+	})
+}
+
+func binanceError(w http.ResponseWriter, status, code int, msg string) {
+	writeJSON(w, status, map[string]interface{}{"code": code, "msg": msg})
+}
+
+func (s *Server) binanceCoins(w http.ResponseWriter, r *http.Request) {
+	coins := []map[string]interface{}{}
+	for _, code := range s.coins() {
+		coins = append(coins, map[string]interface{}{
+			"assetCode":      code,
+			"assetName":      code,
+			"transactionFee": 0.001,
+			"confirmTimes":   "12",
+			"enableCharge":   true,
+			"enableWithdraw": true,
+		})
+	}
+	writeJSON(w, http.StatusOK, coins)
+}
+
+func (s *Server) binancePairs(w http.ResponseWriter, r *http.Request) {
+	symbols := []map[string]interface{}{}
+	for _, market := range s.listMarkets() {
+		symbols = append(symbols, map[string]interface{}{
+			"symbol":     market.Symbol,
+			"status":     "TRADING",
+			"baseAsset":  market.Target,
+			"quoteAsset": market.Base,
+			"filters": []map[string]interface{}{
+				{"filterType": "PRICE_FILTER", "tickSize": format(market.PriceFilter)},
+				{"filterType": "LOT_SIZE", "stepSize": format(market.LotSize), "minQty": format(market.MinQty), "maxQty": format(market.MaxQty)},
+				{"filterType": "MIN_NOTIONAL", "minNotional": format(market.MinNotional)},
+				{"filterType": "MAX_NUM_ORDERS", "maxNumOrders": market.MaxOrders},
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"timezone": "UTC", "serverTime": s.Now().UnixNano() / 1e6, "symbols": symbols})
+}
+
+func (s *Server) binanceDepth(w http.ResponseWriter, r *http.Request) {
+	bids, asks, sequence, ok := s.book(r.URL.Query().Get("symbol"))
+	if !ok {
+		binanceError(w, http.StatusBadRequest, binanceErrors[errUnknownSymbol], "Invalid symbol.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"lastUpdateId": sequence, "bids": formatLevels(bids), "asks": formatLevels(asks)})
+}
+
+// binanceSigned checks the key, the signature and the timestamp, and passes the
+// query and body parameters on.
+func (s *Server) binanceSigned(handler func(w http.ResponseWriter, r *http.Request, params url.Values)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-MBX-APIKEY") != s.Key {
+			binanceError(w, http.StatusUnauthorized, -2015, "Invalid API-key, IP, or permissions for action.")
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := r.URL.RawQuery
+		if len(body) > 0 {
+			if payload != "" {
+				payload += "&"
+			}
+			payload += string(body)
+		}
+
+		signature, unsigned := "", []string{}
+		for _, param := range strings.Split(payload, "&") {
+			if strings.HasPrefix(param, "signature=") {
+				signature = strings.TrimPrefix(param, "signature=")
+			} else {
+				unsigned = append(unsigned, param)
+			}
+		}
+		if signature != exchange.ComputeHmac256NoDecode(strings.Join(unsigned, "&"), s.Secret) {
+			binanceError(w, http.StatusBadRequest, -1022, "Signature for this request is not valid.")
+			return
+		}
+
+		params, _ := url.ParseQuery(payload)
+		timestamp, _ := strconv.ParseInt(params.Get("timestamp"), 10, 64)
+		recvWindow, err := strconv.ParseInt(params.Get("recvWindow"), 10, 64)
+		if err != nil {
+			recvWindow = 5000
+		}
+		now := s.Now().UnixNano() / int64(time.Millisecond)
+		if timestamp > now+1000 || now-timestamp > recvWindow {
+			binanceError(w, http.StatusBadRequest, -1021, "Timestamp for this request is outside of the recvWindow.")
+			return
+		}
+		handler(w, r, params)
+	}
+}
+
+func (s *Server) binanceAccount(w http.ResponseWriter, r *http.Request, params url.Values) {
+	balances := []map[string]string{}
+	for code, balance := range s.balanceList() {
+		balances = append(balances, map[string]string{"asset": code, "free": format(balance[0]), "locked": format(balance[1])})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"makerCommission": math.Round(s.MakerFee * 10000),
+		"takerCommission": math.Round(s.TakerFee * 10000),
+		"canTrade":        true,
+		"canWithdraw":     true,
+		"canDeposit":      true,
+		"balances":        balances,
+	})
+}
+
+func (s *Server) binanceRestrictions(w http.ResponseWriter, r *http.Request, params url.Values) {
+	restrictions := map[string]interface{}{
+		"ipRestrict":                 len(s.IPs) > 0,
+		"createTime":                 s.Now().UnixNano() / 1e6,
+		"enableReading":              s.can(exchange.READ),
+		"enableSpotAndMarginTrading": s.can(exchange.TRADE),
+		"enableWithdrawals":          s.can(exchange.WITHDRAW),
+		"enableInternalTransfer":     false,
+		"enableMargin":               s.can(exchange.MARGIN),
+		"enableFutures":              s.can(exchange.FUTURES),
+		"enableVanillaOptions":       false,
+		"permitsUniversalTransfer":   false,
+	}
+	if !s.KeyExpiry.IsZero() {
+		restrictions["tradingAuthorityExpirationTime"] = s.KeyExpiry.UnixNano() / 1e6
+	}
+	writeJSON(w, http.StatusOK, restrictions)
+}
+
+func (s *Server) binanceOrder(w http.ResponseWriter, r *http.Request, params url.Values) {
+	var order *Order
+	var err error
+	switch r.Method {
+	case "POST":
+		if params.Get("type") != "LIMIT" {
+			binanceError(w, http.StatusBadRequest, -1116, "Invalid orderType.")
+			return
+		}
+		side := "Buy"
+		if params.Get("side") == "SELL" {
+			side = "Sell"
+		}
+		rate, _ := strconv.ParseFloat(params.Get("price"), 64)
+		quantity, _ := strconv.ParseFloat(params.Get("quantity"), 64)
+		order, err = s.place(params.Get("symbol"), side, rate, quantity)
+	case "GET":
+		if order = s.Order(params.Get("orderId")); order == nil {
+			err = errUnknownOrder
+		}
+	case "DELETE":
+		if order = s.Order(params.Get("orderId")); order == nil || order.Symbol != params.Get("symbol") {
+			err = errUnknownOrder
+		} else {
+			order, err = s.cancel(order.ID)
+		}
+	default:
+		binanceError(w, http.StatusMethodNotAllowed, -1000, "Unsupported method.")
+		return
+	}
+	if err == nil && order.Symbol != params.Get("symbol") {
+		err = errUnknownOrder
+	}
+	if err != nil {
+		binanceError(w, http.StatusBadRequest, binanceErrors[err], err.Error())
+		return
+	}
+
+	orderID, _ := strconv.Atoi(order.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"symbol":       order.Symbol,
+		"orderId":      orderID,
+		"transactTime": s.Now().UnixNano() / 1e6,
+		"price":        format(order.Rate),
+		"origQty":      format(order.Quantity),
+		"executedQty":  format(order.Filled),
+		"status":       binanceStatus[order.Status],
+		"timeInForce":  "GTC",
+		"type":         "LIMIT",
+		"side":         strings.ToUpper(order.Side),
+	})
+}
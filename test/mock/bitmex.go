@@ -0,0 +1,161 @@
+package mock
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+var bitmexStatus = map[exchange.OrderStatus]string{
+	exchange.New:       "New",
+	exchange.Partial:   "PartiallyFilled",
+	exchange.Filled:    "Filled",
+	exchange.Cancelled: "Canceled",
+}
+
+// StartBitmex emulates www.bitmex.com: the active instruments, the orders of a
+// symbol, the XBt margin, and the XBt
+// withdrawals signed with the hex HMAC-SHA256 of the method, path, api-expires
+// and body, confirmed by the otpToken of the TOTP seed.
+func StartBitmex(key, secret, seed string) *Server {
+	return start("bitmex", key, secret, []string{"www.bitmex.com"}, func(s *Server) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v1/instrument/active", s.bitmexInstruments)
+		mux.HandleFunc("/api/v1/order", s.bitmexSigned(s.bitmexOrders))
+		mux.HandleFunc("/api/v1/user/margin", s.bitmexSigned(s.bitmexMargin))
+		mux.HandleFunc("/api/v1/user/requestWithdrawal", s.bitmexSigned(func(w http.ResponseWriter, r *http.Request, body []byte) {
+			s.bitmexWithdraw(w, body, seed)
+		}))
+		return mux
+	})
+}
+
+func bitmexError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]interface{}{"error": map[string]string{"message": msg, "name": "HTTPError"}})
+}
+
+func (s *Server) bitmexInstruments(w http.ResponseWriter, r *http.Request) {
+	instruments := []map[string]interface{}{}
+	for _, market := range s.listMarkets() {
+		instruments = append(instruments, map[string]interface{}{
+			"symbol":        market.Symbol,
+			"rootSymbol":    market.Target,
+			"quoteCurrency": market.Base,
+			"typ":           "FFWCSX", // the perpetual contracts
+			"lotSize":       market.LotSize,
+			"tickSize":      market.PriceFilter,
+			"makerFee":      s.MakerFee,
+			"takerFee":      s.TakerFee,
+		})
+	}
+	writeJSON(w, http.StatusOK, instruments)
+}
+
+// bitmexOrders lists the orders of the symbol, an unknown order is not listed.
+func (s *Server) bitmexOrders(w http.ResponseWriter, r *http.Request, body []byte) {
+	symbol := r.URL.Query().Get("symbol")
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	orders := []map[string]interface{}{}
+	for _, order := range s.orders {
+		if order.Symbol == symbol {
+			orders = append(orders, map[string]interface{}{
+				"orderID":         order.ID,
+				"symbol":          order.Symbol,
+				"side":            order.Side,
+				"price":           order.Rate,
+				"simpleOrderQty":  order.Quantity,
+				"simpleLeavesQty": order.Quantity - order.Filled,
+				"ordStatus":       bitmexStatus[order.Status],
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, orders)
+}
+
+// bitmexMargin lists the XBT balance in satoshis.
+func (s *Server) bitmexMargin(w http.ResponseWriter, r *http.Request, body []byte) {
+	free, locked := s.Balance("XBT")
+	writeJSON(w, http.StatusOK, []map[string]interface{}{{
+		"currency":        "XBt",
+		"walletBalance":   int64(math.Round((free + locked) * 1e8)),
+		"availableMargin": int64(math.Round(free * 1e8)),
+	}})
+}
+
+// bitmexSigned checks the key, the expiry and the signature, and passes the body on.
+func (s *Server) bitmexSigned(handler func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("api-key") != s.Key {
+			bitmexError(w, http.StatusUnauthorized, "Invalid API Key.")
+			return
+		}
+		expires, err := strconv.ParseInt(r.Header.Get("api-expires"), 10, 64)
+		if err != nil || expires < s.Now().Unix() {
+			bitmexError(w, http.StatusUnauthorized, "This request has expired - `expires` is in the past.")
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		path := r.URL.Path
+		if r.URL.RawQuery != "" {
+			path += "?" + r.URL.RawQuery
+		}
+		payload := r.Method + path + r.Header.Get("api-expires") + string(body)
+		if r.Header.Get("api-signature") != exchange.ComputeHmac256NoDecode(payload, s.Secret) {
+			bitmexError(w, http.StatusUnauthorized, "Signature not valid.")
+			return
+		}
+		handler(w, r, body)
+	}
+}
+
+// bitmexWithdraw takes the amount in satoshis from the XBT balance, the otpToken
+// of the current or the previous 30 s step is accepted.
+func (s *Server) bitmexWithdraw(w http.ResponseWriter, body []byte, seed string) {
+	params := struct {
+		Currency string `json:"currency"`
+		Amount   string `json:"amount"`
+		Address  string `json:"address"`
+		OtpToken string `json:"otpToken"`
+	}{}
+	if err := json.Unmarshal(body, &params); err != nil {
+		bitmexError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	current, _ := exchange.TOTP(seed, s.Now())
+	previous, _ := exchange.TOTP(seed, s.Now().Add(-30*time.Second))
+	if params.OtpToken == "" || (params.OtpToken != current && params.OtpToken != previous) {
+		bitmexError(w, http.StatusUnauthorized, "Invalid otpToken.")
+		return
+	}
+	satoshis, err := strconv.ParseInt(params.Amount, 10, 64)
+	if params.Currency != "XBt" || err != nil || satoshis <= 0 {
+		bitmexError(w, http.StatusBadRequest, "Invalid amount or currency.")
+		return
+	}
+
+	withdrawal, err := s.withdraw("XBT", float64(satoshis)/1e8, params.Address, params.OtpToken)
+	if err != nil {
+		bitmexError(w, http.StatusBadRequest, "Insufficient available balance.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"transactID":     withdrawal.ID,
+		"currency":       "XBt",
+		"transactType":   "Withdrawal",
+		"amount":         -satoshis,
+		"transactStatus": "Pending",
+		"address":        withdrawal.Address,
+		"timestamp":      s.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,304 @@
+package mock
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+const (
+	HUOBI_HOST       = "api.huobi.pro"
+	HUOBI_ACCOUNT_ID = "10001" // the spot account of the Huobi Server
+	HUOBI_UID        = 20001   // the user owning the key
+)
+
+var huobiErrors = map[error]string{
+	errUnknownSymbol: "invalid-parameter",
+	errUnknownOrder:  "base-record-invalid",
+	errOrderClosed:   "order-orderstate-error",
+	errLotSize:       "order-amount-precision-error",
+	errPriceFilter:   "order-price-precision-error",
+	errBalance:       "account-frozen-balance-insufficient-error",
+}
+
+var huobiState = map[exchange.OrderStatus]string{
+	exchange.New:       "submitted",
+	exchange.Partial:   "partial-filled",
+	exchange.Filled:    "filled",
+	exchange.Cancelled: "canceled",
+}
+
+// StartHuobi emulates api.huobi.pro and its signature version 2: the base64
+// HmacSHA256 of the method, host, path and sorted query, keyed by AccessKeyId,
+// with the Timestamp within 5 minutes of the server clock. The orders and
+// balances are of the spot account HUOBI_ACCOUNT_ID.
+func StartHuobi(key, secret string) *Server {
+	return start("huobi", key, secret, []string{HUOBI_HOST}, func(s *Server) http.Handler {
+		return http.HandlerFunc(s.huobiRoute)
+	})
+}
+
+func huobiError(w http.ResponseWriter, code, msg string) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "error", "err-code": code, "err-msg": msg})
+}
+
+func huobiOK(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok", "data": data})
+}
+
+func (s *Server) huobiRoute(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/v2/reference/currencies":
+		s.huobiCoins(w, r)
+	case r.URL.Path == "/v1/common/symbols":
+		s.huobiPairs(w, r)
+	case r.URL.Path == "/market/depth":
+		s.huobiDepth(w, r)
+	case r.URL.Path == "/v1/common/timestamp":
+		huobiOK(w, s.Now().UnixNano()/1e6)
+	case !s.huobiVerify(w, r):
+	case r.URL.Path == "/v1/account/accounts":
+		huobiOK(w, []map[string]interface{}{{"id": json.Number(HUOBI_ACCOUNT_ID), "type": "spot", "subtype": "", "state": "working"}})
+	case len(path) == 5 && path[2] == "accounts" && path[4] == "balance":
+		s.huobiBalance(w, path[3])
+	case r.URL.Path == "/v2/reference/transact-fee-rate":
+		s.huobiFees(w, r)
+	case r.URL.Path == "/v2/user/uid":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "data": HUOBI_UID})
+	case r.URL.Path == "/v2/user/api-key":
+		s.huobiApiKey(w, r)
+	case r.URL.Path == "/v1/order/orders/place" && r.Method == "POST":
+		s.huobiPlace(w, r)
+	case len(path) == 5 && path[2] == "orders" && path[4] == "submitcancel" && r.Method == "POST":
+		if order, err := s.cancel(path[3]); err != nil {
+			huobiError(w, huobiErrors[err], err.Error())
+		} else {
+			huobiOK(w, order.ID)
+		}
+	case len(path) == 4 && path[2] == "orders" && r.Method == "GET":
+		s.huobiOrder(w, path[3])
+	default:
+		huobiError(w, "invalid-parameter", fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) huobiFees(w http.ResponseWriter, r *http.Request) {
+	symbols := "," + r.URL.Query().Get("symbols") + ","
+	rates := []map[string]string{}
+	for _, market := range s.listMarkets() {
+		if strings.Contains(symbols, ","+market.Symbol+",") {
+			rates = append(rates, map[string]string{
+				"symbol":          market.Symbol,
+				"makerFeeRate":    format(s.MakerFee),
+				"takerFeeRate":    format(s.TakerFee),
+				"actualMakerRate": format(s.MakerFee),
+				"actualTakerRate": format(s.TakerFee),
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "data": rates, "success": true})
+}
+
+func (s *Server) huobiApiKey(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("uid") != strconv.Itoa(HUOBI_UID) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"code": 2002, "message": "invalid field value in `uid`"})
+		return
+	}
+	// the valid days are counted from the end of the current day, UTC
+	validDays, status := -1, "normal"
+	if !s.KeyExpiry.IsZero() {
+		now := s.Now().UTC()
+		endOfDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		validDays = int(math.Max(0, math.Ceil(float64(s.KeyExpiry.Sub(endOfDay))/float64(24*time.Hour))))
+		if s.KeyExpiry.Before(now) {
+			status = "expired"
+		}
+	}
+	apiKeys := []map[string]interface{}{}
+	if r.URL.Query().Get("accessKey") == s.Key {
+		apiKeys = append(apiKeys, map[string]interface{}{
+			"accessKey":   s.Key,
+			"note":        "mock",
+			"permission":  s.permissionList(map[exchange.Permission]string{exchange.READ: "readOnly", exchange.TRADE: "trade", exchange.WITHDRAW: "withdraw"}),
+			"ipAddresses": strings.Join(s.IPs, ","),
+			"validDays":   validDays,
+			"status":      status,
+			"createTime":  s.Now().UnixNano() / 1e6,
+			"updateTime":  s.Now().UnixNano() / 1e6,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "data": apiKeys, "ok": true})
+}
+
+func (s *Server) huobiCoins(w http.ResponseWriter, r *http.Request) {
+	coins := []map[string]interface{}{}
+	for _, code := range s.coins() {
+		currency := strings.ToLower(code)
+		coins = append(coins, map[string]interface{}{
+			"currency": currency,
+			"chains": []map[string]interface{}{{
+				"chain":              currency,
+				"numOfConfirmations": 12,
+				"depositStatus":      "allowed",
+				"withdrawStatus":     "allowed",
+			}},
+			"instStatus": "normal",
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": 200, "data": coins})
+}
+
+func (s *Server) huobiPairs(w http.ResponseWriter, r *http.Request) {
+	symbols := []map[string]interface{}{}
+	for _, market := range s.listMarkets() {
+		symbols = append(symbols, map[string]interface{}{
+			"base-currency":    strings.ToLower(market.Target),
+			"quote-currency":   strings.ToLower(market.Base),
+			"price-precision":  int(math.Round(-math.Log10(market.PriceFilter))),
+			"amount-precision": int(math.Round(-math.Log10(market.LotSize))),
+			"min-order-amt":    market.MinQty,
+			"max-order-amt":    market.MaxQty,
+			"min-order-value":  market.MinNotional,
+			"symbol":           market.Symbol,
+			"state":            "online",
+		})
+	}
+	huobiOK(w, symbols)
+}
+
+// huobiDepth sends the book in the tick of the market data, not in data
+func (s *Server) huobiDepth(w http.ResponseWriter, r *http.Request) {
+	symbol := r.URL.Query().Get("symbol")
+	bids, asks, sequence, ok := s.book(symbol)
+	if !ok {
+		huobiError(w, huobiErrors[errUnknownSymbol], "invalid symbol")
+		return
+	}
+	if bids == nil {
+		bids = [][2]float64{}
+	}
+	if asks == nil {
+		asks = [][2]float64{}
+	}
+	ts := s.Now().UnixNano() / 1e6
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "ok",
+		"ch":     fmt.Sprintf("market.%s.depth.%s", symbol, r.URL.Query().Get("type")),
+		"ts":     ts,
+		"tick":   map[string]interface{}{"bids": bids, "asks": asks, "version": sequence, "ts": ts},
+	})
+}
+
+// huobiVerify checks the signed query and writes the error if it is invalid.
+func (s *Server) huobiVerify(w http.ResponseWriter, r *http.Request) bool {
+	params := r.URL.Query()
+	if params.Get("AccessKeyId") != s.Key {
+		huobiError(w, "api-signature-not-valid", "Signature not valid: Incorrect Access key")
+		return false
+	}
+	timestamp, err := time.Parse("2006-01-02T15:04:05", params.Get("Timestamp"))
+	if err != nil || math.Abs(float64(s.Now().Sub(timestamp))) > float64(5*time.Minute) {
+		huobiError(w, "api-signature-not-valid", "Signature not valid: Timestamp is invalid")
+		return false
+	}
+
+	keys := []string{}
+	for key := range params {
+		if key != "Signature" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	sorted := []string{}
+	for _, key := range keys {
+		sorted = append(sorted, key+"="+url.QueryEscape(params.Get(key)))
+	}
+	payload := r.Method + "\n" + HUOBI_HOST + "\n" + r.URL.Path + "\n" + strings.Join(sorted, "&")
+	if params.Get("Signature") != exchange.ComputeHmac256Base64(payload, s.Secret) {
+		huobiError(w, "api-signature-not-valid", "Signature not valid: Verification failure")
+		return false
+	}
+	return true
+}
+
+func (s *Server) huobiBalance(w http.ResponseWriter, accountID string) {
+	if accountID != HUOBI_ACCOUNT_ID {
+		huobiError(w, "account-get-balance-account-inexistent-error", "account for id "+accountID+" is inexistent")
+		return
+	}
+	list := []map[string]string{}
+	for code, balance := range s.balanceList() {
+		currency := strings.ToLower(code)
+		list = append(list,
+			map[string]string{"currency": currency, "type": "trade", "balance": format(balance[0])},
+			map[string]string{"currency": currency, "type": "frozen", "balance": format(balance[1])})
+	}
+	huobiOK(w, map[string]interface{}{"id": json.Number(HUOBI_ACCOUNT_ID), "type": "spot", "state": "working", "list": list})
+}
+
+func (s *Server) huobiPlace(w http.ResponseWriter, r *http.Request) {
+	params := make(map[string]string)
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &params); err != nil {
+		huobiError(w, "invalid-parameter", "invalid body")
+		return
+	}
+	if params["account-id"] != HUOBI_ACCOUNT_ID {
+		huobiError(w, "account-frozen-account-inexistent-error", "account for id "+params["account-id"]+" is inexistent")
+		return
+	}
+
+	side := ""
+	switch params["type"] {
+	case "buy-limit":
+		side = "Buy"
+	case "sell-limit":
+		side = "Sell"
+	default:
+		huobiError(w, "order-type-invalid", "order type "+params["type"]+" is not supported")
+		return
+	}
+	rate, _ := strconv.ParseFloat(params["price"], 64)
+	quantity, _ := strconv.ParseFloat(params["amount"], 64)
+	order, err := s.place(params["symbol"], side, rate, quantity)
+	if err != nil {
+		huobiError(w, huobiErrors[err], err.Error())
+		return
+	}
+	huobiOK(w, order.ID)
+}
+
+func (s *Server) huobiOrder(w http.ResponseWriter, id string) {
+	order := s.Order(id)
+	if order == nil {
+		huobiError(w, huobiErrors[errUnknownOrder], errUnknownOrder.Error())
+		return
+	}
+	orderID, _ := strconv.Atoi(order.ID)
+	huobiOK(w, map[string]interface{}{
+		"id":                 orderID,
+		"symbol":             order.Symbol,
+		"account-id":         json.Number(HUOBI_ACCOUNT_ID),
+		"amount":             format(order.Quantity),
+		"price":              format(order.Rate),
+		"type":               strings.ToLower(order.Side) + "-limit",
+		"filled-amount":      format(order.Filled),
+		"filled-cash-amount": format(order.Filled * order.Rate),
+		"filled-fees":        format(0),
+		"state":              huobiState[order.Status],
+	})
+}
@@ -0,0 +1,241 @@
+package mock
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+var kucoinErrors = map[error]string{
+	errUnknownSymbol: "900001",
+	errUnknownOrder:  "400100",
+	errOrderClosed:   "400100",
+	errLotSize:       "400100",
+	errPriceFilter:   "400100",
+	errBalance:       "200004",
+}
+
+// kucoinMessages are the messages of Kucoin for the errors
+var kucoinMessages = map[error]string{
+	errUnknownSymbol: "symbol not exists",
+	errUnknownOrder:  "order not exist.",
+	errOrderClosed:   "order_not_exist_or_not_allow_to_cancel",
+	errLotSize:       "Order size increment invalid.",
+	errPriceFilter:   "Price increment invalid.",
+	errBalance:       "Balance insufficient!",
+}
+
+// StartKucoin emulates openapi-v2.kucoin.com and its passphrase headers: the
+// KC-API-SIGN is the base64 HmacSHA256 of the KC-API-TIMESTAMP, method, path
+// with query and body, the KC-API-KEY and KC-API-PASSPHRASE must match and the
+// timestamp be within 5 seconds of the server clock.
+func StartKucoin(key, secret, passphrase string) *Server {
+	return start("kucoin", key, secret, []string{"openapi-v2.kucoin.com"}, func(s *Server) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v1/currencies", s.kucoinCoins)
+		mux.HandleFunc("/api/v1/symbols", s.kucoinPairs)
+		mux.HandleFunc("/api/v1/market/orderbook/level2", s.kucoinDepth)
+		mux.HandleFunc("/api/v1/timestamp", func(w http.ResponseWriter, r *http.Request) {
+			kucoinOK(w, s.Now().UnixNano()/1e6)
+		})
+		mux.HandleFunc("/api/v1/accounts", s.kucoinSigned(passphrase, s.kucoinAccounts))
+		mux.HandleFunc("/api/v1/orders", s.kucoinSigned(passphrase, s.kucoinPlace))
+		mux.HandleFunc("/api/v1/orders/", s.kucoinSigned(passphrase, s.kucoinOrder))
+		mux.HandleFunc("/api/v1/user/api-key", s.kucoinSigned(passphrase, s.kucoinApiKey))
+		return mux
+	})
+}
+
+func kucoinError(w http.ResponseWriter, status int, code, msg string) {
+	writeJSON(w, status, map[string]string{"code": code, "msg": msg})
+}
+
+func kucoinOK(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"code": "200000", "data": data})
+}
+
+func (s *Server) kucoinCoins(w http.ResponseWriter, r *http.Request) {
+	coins := []map[string]interface{}{}
+	for _, code := range s.coins() {
+		coins = append(coins, map[string]interface{}{
+			"currency":          code,
+			"name":              code,
+			"fullName":          code,
+			"precision":         8,
+			"withdrawalMinSize": "0.01",
+			"withdrawalMinFee":  "0.001",
+			"isWithdrawEnabled": true,
+			"isDepositEnabled":  true,
+		})
+	}
+	kucoinOK(w, coins)
+}
+
+func (s *Server) kucoinPairs(w http.ResponseWriter, r *http.Request) {
+	symbols := []map[string]interface{}{}
+	for _, market := range s.listMarkets() {
+		symbols = append(symbols, map[string]interface{}{
+			"symbol":         market.Symbol,
+			"name":           market.Symbol,
+			"baseCurrency":   market.Target,
+			"quoteCurrency":  market.Base,
+			"baseIncrement":  format(market.LotSize),
+			"priceIncrement": format(market.PriceFilter),
+			"baseMinSize":    format(market.MinQty),
+			"baseMaxSize":    format(market.MaxQty),
+			"quoteMinSize":   format(market.MinNotional),
+			"enableTrading":  true,
+		})
+	}
+	kucoinOK(w, symbols)
+}
+
+// kucoinDepth sends the asks from the highest rate as the level2 endpoint does
+func (s *Server) kucoinDepth(w http.ResponseWriter, r *http.Request) {
+	bids, asks, sequence, ok := s.book(r.URL.Query().Get("symbol"))
+	if !ok {
+		kucoinError(w, http.StatusOK, kucoinErrors[errUnknownSymbol], kucoinMessages[errUnknownSymbol])
+		return
+	}
+	for i, j := 0, len(asks)-1; i < j; i, j = i+1, j-1 {
+		asks[i], asks[j] = asks[j], asks[i]
+	}
+	kucoinOK(w, map[string]interface{}{
+		"sequence": strconv.Itoa(sequence),
+		"time":     s.Now().UnixNano() / 1e6,
+		"bids":     formatLevels(bids),
+		"asks":     formatLevels(asks),
+	})
+}
+
+// kucoinSigned checks the headers and the signature, and passes the body on.
+func (s *Server) kucoinSigned(passphrase string, handler func(w http.ResponseWriter, r *http.Request, body []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("KC-API-KEY") != s.Key {
+			kucoinError(w, http.StatusUnauthorized, "400003", "KC-API-KEY not exists")
+			return
+		}
+		if r.Header.Get("KC-API-PASSPHRASE") != passphrase {
+			kucoinError(w, http.StatusUnauthorized, "400004", "Invalid KC-API-PASSPHRASE")
+			return
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get("KC-API-TIMESTAMP"), 10, 64)
+		now := s.Now().UnixNano() / int64(time.Millisecond)
+		if err != nil || timestamp > now+5000 || now-timestamp > 5000 {
+			kucoinError(w, http.StatusUnauthorized, "400002", "KC-API-TIMESTAMP Invalid")
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		payload := r.Header.Get("KC-API-TIMESTAMP") + r.Method + r.URL.Path
+		if r.URL.RawQuery != "" {
+			payload += "?" + r.URL.RawQuery
+		}
+		payload += string(body)
+		if r.Header.Get("KC-API-SIGN") != exchange.ComputeHmac256Base64(payload, s.Secret) {
+			kucoinError(w, http.StatusUnauthorized, "400005", "Invalid KC-API-SIGN")
+			return
+		}
+		handler(w, r, body)
+	}
+}
+
+func (s *Server) kucoinAccounts(w http.ResponseWriter, r *http.Request, body []byte) {
+	accounts := []map[string]string{}
+	if accountType := r.URL.Query().Get("type"); accountType == "" || accountType == "trade" {
+		for code, balance := range s.balanceList() {
+			accounts = append(accounts, map[string]string{
+				"id":        "trade-" + code,
+				"currency":  code,
+				"type":      "trade",
+				"balance":   format(balance[0] + balance[1]),
+				"available": format(balance[0]),
+				"holds":     format(balance[1]),
+			})
+		}
+	}
+	kucoinOK(w, accounts)
+}
+
+func (s *Server) kucoinApiKey(w http.ResponseWriter, r *http.Request, body []byte) {
+	kucoinOK(w, map[string]interface{}{
+		"remark":      "mock",
+		"apiKey":      s.Key,
+		"apiVersion":  3,
+		"permission":  s.permissionList(map[exchange.Permission]string{exchange.READ: "General", exchange.TRADE: "Spot", exchange.WITHDRAW: "Withdrawal", exchange.MARGIN: "Margin", exchange.FUTURES: "Futures"}),
+		"ipWhitelist": strings.Join(s.IPs, ","),
+		"createdAt":   s.Now().UnixNano() / 1e6,
+		"isMaster":    true,
+	})
+}
+
+func (s *Server) kucoinPlace(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.Method != "POST" {
+		kucoinError(w, http.StatusMethodNotAllowed, "400000", "Unsupported method")
+		return
+	}
+	params := make(map[string]string)
+	if err := json.Unmarshal(body, &params); err != nil {
+		kucoinError(w, http.StatusBadRequest, "400000", "Invalid body")
+		return
+	}
+	if params["type"] != "limit" || (params["side"] != "buy" && params["side"] != "sell") {
+		kucoinError(w, http.StatusBadRequest, "400100", fmt.Sprintf("Unsupported %s %s order", params["type"], params["side"]))
+		return
+	}
+	rate, _ := strconv.ParseFloat(params["price"], 64)
+	quantity, _ := strconv.ParseFloat(params["size"], 64)
+	order, err := s.place(params["symbol"], strings.Title(params["side"]), rate, quantity)
+	if err != nil {
+		kucoinError(w, http.StatusOK, kucoinErrors[err], kucoinMessages[err])
+		return
+	}
+	kucoinOK(w, map[string]string{"orderId": order.ID})
+}
+
+func (s *Server) kucoinOrder(w http.ResponseWriter, r *http.Request, body []byte) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/orders/")
+	switch r.Method {
+	case "GET":
+		order := s.Order(id)
+		if order == nil {
+			kucoinError(w, http.StatusNotFound, kucoinErrors[errUnknownOrder], kucoinMessages[errUnknownOrder])
+			return
+		}
+		// opType is DEAL while the order is open and CANCEL once cancelled
+		opType := "DEAL"
+		if order.Status == exchange.Cancelled {
+			opType = "CANCEL"
+		}
+		kucoinOK(w, map[string]interface{}{
+			"id":        order.ID,
+			"symbol":    order.Symbol,
+			"opType":    opType,
+			"type":      "limit",
+			"side":      strings.ToLower(order.Side),
+			"price":     format(order.Rate),
+			"size":      format(order.Quantity),
+			"dealSize":  format(order.Filled),
+			"dealFunds": format(order.Filled * order.Rate),
+			"isActive":  order.Status == exchange.New || order.Status == exchange.Partial,
+		})
+	case "DELETE":
+		if _, err := s.cancel(id); err != nil {
+			kucoinError(w, http.StatusOK, kucoinErrors[err], kucoinMessages[err])
+			return
+		}
+		kucoinOK(w, map[string][]string{"cancelledOrderIds": {id}})
+	default:
+		kucoinError(w, http.StatusMethodNotAllowed, "400000", "Unsupported method")
+	}
+}
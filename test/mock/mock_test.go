@@ -0,0 +1,397 @@
+package mock
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/binance"
+	"github.com/bitontop/gored/exchange/bitmex"
+	"github.com/bitontop/gored/exchange/huobi"
+	"github.com/bitontop/gored/exchange/kucoin"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+	"github.com/bitontop/gored/utils"
+)
+
+const (
+	testKey        = "mock-key"
+	testSecret     = "mock-secret"
+	testPassphrase = "mock-passphrase"
+	testSeed       = "JBSWY3DPEHPK3PXP"
+)
+
+// flow runs the conformance suite and an order through the adapter against the
+// Server: place, partial fill, cancel and a cancel of the closed order, then
+// checks the server balances.
+func flow(t *testing.T, s *Server, e exchange.Exchange) {
+	btc, eth := coin.GetCoin("BTC"), coin.GetCoin("ETH")
+	ethbtc := pair.GetPair(btc, eth)
+	if !e.HasPair(ethbtc) {
+		t.Fatalf("%s did not list ETH/BTC from the server", e.GetName())
+	}
+	conformance.Run(t, e)
+
+	s.SetBalance("BTC", 1)
+	e.UpdateAllBalances()
+	if e.GetBalance(btc) != 1 {
+		t.Errorf("%s expected 1 BTC, got %v", e.GetName(), e.GetBalance(btc))
+	}
+
+	if _, err := e.LimitBuy(ethbtc, 100, 0.02); !errors.Is(err, exchange.ErrInsufficientFunds) {
+		t.Errorf("%s expected the order over the balance to fail with %v, got %v", e.GetName(), exchange.ErrInsufficientFunds, err)
+	}
+	order, err := e.LimitBuy(ethbtc, 10, 0.02)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free, locked := s.Balance("BTC"); !equal(free, 0.8) || !equal(locked, 0.2) {
+		t.Errorf("%s expected 0.8 BTC free and 0.2 locked, got %v %v", e.GetName(), free, locked)
+	}
+
+	if err := s.Fill(order.OrderID, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.OrderStatus(order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != exchange.Partial || order.DealQuantity != 4 {
+		t.Errorf("%s expected Partial with 4 dealt, got %v %v", e.GetName(), order.Status, order.DealQuantity)
+	}
+
+	if err := e.CancelOrder(order); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.OrderStatus(order); err != nil || order.Status != exchange.Cancelled {
+		t.Errorf("%s expected Cancelled, got %v %v", e.GetName(), order.Status, err)
+	}
+	if err := e.CancelOrder(order); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("%s expected the cancel of a cancelled order to fail with %v, got %v", e.GetName(), exchange.ErrOrderNotFound, err)
+	}
+	unknown := &exchange.Order{Pair: ethbtc, OrderID: "999"}
+	if err := e.OrderStatus(unknown); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("%s expected the status of an unknown order to fail with %v, got %v", e.GetName(), exchange.ErrOrderNotFound, err)
+	}
+	if free, locked := s.Balance("BTC"); !equal(free, 1-4*0.02) || !equal(locked, 0) {
+		t.Errorf("%s expected %v BTC free and none locked, got %v %v", e.GetName(), 1-4*0.02, free, locked)
+	}
+	if free, _ := s.Balance("ETH"); free != 4 {
+		t.Errorf("%s expected 4 ETH, got %v", e.GetName(), free)
+	}
+
+	conformance.RunTrading(t, e, ethbtc, 0.01, 1)
+
+	// the balance spent since UpdateAllBalances is only known to the server
+	s.SetBalance("BTC", 0.001)
+	_, err = e.LimitBuy(ethbtc, 1, 0.02)
+	var apiErr *exchange.ApiError
+	if !errors.Is(err, exchange.ErrInsufficientFunds) || !errors.As(err, &apiErr) || apiErr.Payload == "" {
+		t.Errorf("%s expected the server to reject the order with %v and the payload, got %v", e.GetName(), exchange.ErrInsufficientFunds, err)
+	}
+	s.SetBalance("BTC", 1)
+
+	// a server clock 10 minutes ahead rejects the signed requests until synced
+	clock := exchange.GetClock(e.GetName())
+	s.TimeOffset = 10 * time.Minute
+	defer func() {
+		s.TimeOffset = 0
+		clock.Sync()
+	}()
+	if err := e.OrderStatus(order); !errors.Is(err, exchange.ErrTimestamp) {
+		t.Errorf("%s expected the request out of the time window to fail with %v, got %v", e.GetName(), exchange.ErrTimestamp, err)
+	}
+	if err := clock.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if offset := clock.Status().Offset; offset < 10*time.Minute-time.Second || offset > 10*time.Minute+time.Second {
+		t.Errorf("%s expected the offset of 10 minutes, got %v", e.GetName(), offset)
+	}
+	if err := e.OrderStatus(order); err != nil {
+		t.Errorf("%s expected the request on the synced clock to pass, got %v", e.GetName(), err)
+	}
+}
+
+// limits checks the order limits listed by the server reach the pair constraint,
+// and the orders out of them are rejected before they are sent.
+func limits(t *testing.T, s *Server, e exchange.Exchange, maxOrders int) {
+	ethbtc := pair.GetPairByKey("BTC|ETH")
+	pc := e.GetPairConstraint(ethbtc)
+	if pc.MinQty != 0.01 || pc.MaxQty != 9000 || pc.MinNotional != 0.0001 || pc.MaxOrders != maxOrders {
+		t.Errorf("%s expected the limits of the server, got %+v", e.GetName(), pc)
+	}
+
+	orders := len(s.orders)
+	if _, err := e.LimitBuy(ethbtc, 0.009, 0.02); !rejected(err, exchange.MIN_QUANTITY) {
+		t.Errorf("%s expected %s, got %v", e.GetName(), exchange.MIN_QUANTITY, err)
+	}
+	if _, err := e.LimitBuy(ethbtc, 9001, 0.000001); !rejected(err, exchange.MAX_QUANTITY) {
+		t.Errorf("%s expected %s, got %v", e.GetName(), exchange.MAX_QUANTITY, err)
+	}
+	if _, err := e.LimitBuy(ethbtc, 0.01, 0.001); !rejected(err, exchange.MIN_NOTIONAL) {
+		t.Errorf("%s expected %s, got %v", e.GetName(), exchange.MIN_NOTIONAL, err)
+	}
+	if len(s.orders) != orders {
+		t.Errorf("%s expected the orders out of the limits not to reach the server", e.GetName())
+	}
+}
+
+// fees checks the fees of the account replaced the default ones.
+func fees(t *testing.T, e exchange.Exchange) {
+	ethbtc := pair.GetPairByKey("BTC|ETH")
+	if !e.GetConstraintFetchMethod(ethbtc).Fee {
+		t.Errorf("%s expected the fees from the API", e.GetName())
+	}
+	if e.GetMakerFee(ethbtc) != 0.0009 || e.GetTakerFee(ethbtc) != 0.001 || e.GetFee(ethbtc) != 0.001 {
+		t.Errorf("%s expected the maker fee 0.0009 and the taker fee 0.001, got %v %v", e.GetName(), e.GetMakerFee(ethbtc), e.GetTakerFee(ethbtc))
+	}
+}
+
+// credentials checks the permissions listed by the server reach the adapter,
+// and the expiry of the config.
+func credentials(t *testing.T, s *Server, e exchange.Exchange, known ...exchange.Permission) {
+	s.Permissions = []exchange.Permission{exchange.READ, exchange.TRADE}
+	s.IPs = []string{"10.0.0.1", "10.0.0.2"}
+	defer func() { s.Permissions, s.IPs = nil, nil }()
+
+	c, err := exchange.CheckCredentials(e, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Probed || !c.Can(exchange.READ) || !c.Can(exchange.TRADE) {
+		t.Errorf("%s expected the key to read and trade, got %v", e.GetName(), c.Permissions)
+	}
+	for _, permission := range known {
+		if !c.Known(permission) || c.Can(permission) {
+			t.Errorf("%s expected no %v permission, got %v", e.GetName(), permission, c.Permissions)
+		}
+	}
+	if !c.IPRestricted {
+		t.Errorf("%s expected the key restricted to the IPs", e.GetName())
+	}
+
+	if _, err := exchange.CheckCredentials(e, &exchange.Config{ExpireTS: time.Now().Add(10 * 24 * time.Hour).Unix()}); err != nil {
+		t.Errorf("%s expected the key expiring in 10 days to pass, got %v", e.GetName(), err)
+	}
+	if _, err := exchange.CheckCredentials(e, &exchange.Config{ExpireTS: time.Now().Add(-time.Hour).Unix()}); !errors.Is(err, exchange.ErrAuth) {
+		t.Errorf("%s expected the expired key to fail with %v, got %v", e.GetName(), exchange.ErrAuth, err)
+	}
+}
+
+func rejected(err error, errType exchange.OrderErrorType) bool {
+	orderErr, ok := err.(*exchange.OrderError)
+	return ok && orderErr.Type == errType
+}
+
+func equal(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func setup(s *Server) {
+	market := s.AddMarket("ETH", "BTC", 0.001, 0.000001)
+	market.MinQty = 0.01
+	market.MaxQty = 9000
+	market.MinNotional = 0.0001
+	market.MaxOrders = 200
+	market.Bids = [][2]float64{{0.02, 12.5}, {0.0199, 3}}
+	market.Asks = [][2]float64{{0.0201, 4}, {0.0202, 7.25}}
+	s.MakerFee = 0.0009
+	s.TakerFee = 0.001
+	exchange.SetHttpTransport(Transport(s))
+	coin.Init()
+	pair.Init()
+}
+
+func Test_Binance(t *testing.T) {
+	s := StartBinance(testKey, testSecret)
+	defer s.Close()
+	setup(s)
+	defer exchange.SetHttpTransport(nil)
+
+	e := binance.CreateBinance(&exchange.Config{Source: exchange.EXCHANGE_API, API_KEY: testKey, API_SECRET: testSecret})
+	if e == nil {
+		t.Fatal("Binance failed to init from the server")
+	}
+	fees(t, e)
+
+	// the loaded data has the default fees, the account ones replace them
+	dir, err := ioutil.TempDir("", "mock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ethbtc := pair.GetPairByKey("BTC|ETH")
+	pairConstraint := *e.GetPairConstraint(ethbtc)
+	pairConstraint.MakerFee, pairConstraint.TakerFee = binance.DEFAULT_MAKER_FEE, binance.DEFAULT_TAKER_FEE
+	e.SetPairConstraint(&pairConstraint)
+	if err := utils.ConvertExchangeDataToJson(dir, e); err != nil {
+		t.Fatal(err)
+	}
+	e.Source, e.SourceURI = exchange.JSON_FILE, dir
+	err = e.InitData()
+	e.Source, e.SourceURI = exchange.EXCHANGE_API, ""
+	if err != nil {
+		t.Fatal(err)
+	}
+	fees(t, e)
+
+	flow(t, s, e)
+	limits(t, s, e, 200)
+	credentials(t, s, e, exchange.WITHDRAW, exchange.MARGIN, exchange.FUTURES)
+
+	// the adapter lists no balance, the probe knows nothing and sends no order request
+	orders := len(s.orders)
+	probed := exchange.ProbeCredentials(e)
+	if !probed.Probed || len(probed.Permissions) != 0 || len(s.orders) != orders {
+		t.Errorf("Expected the probe to know no permission, got %v", probed.Permissions)
+	}
+
+	e.API_SECRET = "wrong"
+	defer func() { e.API_SECRET = testSecret }()
+	if _, err := e.LimitBuy(pair.GetPairByKey("BTC|ETH"), 1, 0.02); err == nil || !strings.Contains(err.Error(), "-1022") || !errors.Is(err, exchange.ErrAuth) {
+		t.Errorf("Expected the signature to be rejected, got %v", err)
+	}
+	if _, err := e.CheckCredentials(); !errors.Is(err, exchange.ErrAuth) {
+		t.Errorf("Expected the credentials check to be rejected, got %v", err)
+	}
+	e.API_SECRET = testSecret
+
+	// the expired trading right is not the key expiry
+	s.Permissions = []exchange.Permission{exchange.READ, exchange.TRADE}
+	defer func() { s.Permissions, s.KeyExpiry = nil, time.Time{} }()
+	s.KeyExpiry = time.Now().Add(-time.Hour)
+	if c, err := exchange.CheckCredentials(e, nil); err != nil || c.Can(exchange.TRADE) || !c.Known(exchange.TRADE) || c.ExpireTS != 0 {
+		t.Errorf("Expected the expired trading right to clear the trade permission only, got %v %v", c, err)
+	}
+	s.KeyExpiry = time.Now().Add(30 * 24 * time.Hour)
+	if c, err := exchange.CheckCredentials(e, nil); err != nil || !c.Can(exchange.TRADE) || c.ExpireTS != s.KeyExpiry.Unix() {
+		t.Errorf("Expected the key to expire with the trading right, got %v %v", c, err)
+	}
+}
+
+func Test_Huobi(t *testing.T) {
+	s := StartHuobi(testKey, testSecret)
+	defer s.Close()
+	setup(s)
+	defer exchange.SetHttpTransport(nil)
+
+	e := huobi.CreateHuobi(&exchange.Config{Source: exchange.EXCHANGE_API, API_KEY: testKey, API_SECRET: testSecret})
+	if e == nil {
+		t.Fatal("Huobi failed to init from the server")
+	}
+	fees(t, e)
+	flow(t, s, e)
+	limits(t, s, e, 0)
+	credentials(t, s, e, exchange.WITHDRAW)
+	if e.Account_ID != HUOBI_ACCOUNT_ID {
+		t.Errorf("Expected account %v, got %v", HUOBI_ACCOUNT_ID, e.Account_ID)
+	}
+
+	// a key valid for 0 days expires at the end of the day, only the expired one fails
+	defer func() { s.KeyExpiry = time.Time{} }()
+	s.KeyExpiry = time.Now().Add(time.Minute)
+	if c, err := exchange.CheckCredentials(e, nil); err != nil || c.ExpireTS <= time.Now().Unix() || c.ExpireTS > time.Now().Add(48*time.Hour).Unix() {
+		t.Errorf("Expected the key valid today to pass, got %v %v", c, err)
+	}
+	s.KeyExpiry = time.Now().Add(-time.Hour)
+	if _, err := exchange.CheckCredentials(e, nil); !errors.Is(err, exchange.ErrAuth) {
+		t.Errorf("Expected the expired key to fail with %v, got %v", exchange.ErrAuth, err)
+	}
+
+	e.Account_ID = "1"
+	defer func() { e.Account_ID = HUOBI_ACCOUNT_ID }()
+	if _, err := e.LimitBuy(pair.GetPairByKey("BTC|ETH"), 1, 0.02); err == nil || !strings.Contains(err.Error(), "inexistent") {
+		t.Errorf("Expected the unknown account to be rejected, got %v", err)
+	}
+}
+
+func Test_Kucoin(t *testing.T) {
+	s := StartKucoin(testKey, testSecret, testPassphrase)
+	defer s.Close()
+	setup(s)
+	defer exchange.SetHttpTransport(nil)
+
+	e := kucoin.CreateKucoin(&exchange.Config{Source: exchange.EXCHANGE_API, API_KEY: testKey, API_SECRET: testSecret, Passphrase: testPassphrase})
+	if e == nil {
+		t.Fatal("Kucoin failed to init from the server")
+	}
+	flow(t, s, e)
+	limits(t, s, e, 0)
+	credentials(t, s, e, exchange.WITHDRAW, exchange.MARGIN, exchange.FUTURES)
+
+	e.Passphrase = "wrong"
+	defer func() { e.Passphrase = testPassphrase }()
+	if _, err := e.LimitBuy(pair.GetPairByKey("BTC|ETH"), 1, 0.02); err == nil || !strings.Contains(err.Error(), "400004") || !errors.Is(err, exchange.ErrAuth) {
+		t.Errorf("Expected the passphrase to be rejected, got %v", err)
+	}
+}
+
+// Test_Bitmex runs a withdrawal confirmed by the TOTP code, and probes the
+// credentials of an adapter without CheckCredentials.
+func Test_Bitmex(t *testing.T) {
+	s := StartBitmex(testKey, testSecret, testSeed)
+	defer s.Close()
+	s.AddMarket("XBT", "USD", 1, 0.5)
+	exchange.SetHttpTransport(Transport(s))
+	defer exchange.SetHttpTransport(nil)
+	coin.Init()
+	pair.Init()
+
+	e := bitmex.CreateBitmex(&exchange.Config{Source: exchange.EXCHANGE_API, API_KEY: testKey, API_SECRET: testSecret, Two_Factor: testSeed})
+	if e == nil {
+		t.Fatal("Bitmex failed to init from the server")
+	}
+	xbt := coin.GetCoin("XBT")
+	s.SetBalance("XBT", 1)
+
+	if !e.Withdraw(xbt, 0.25, "3BMEXqGpG4FxBA1KWhRFufXfSTRgzfDBhJ", "") {
+		t.Fatal("Expected the withdrawal to pass")
+	}
+	operation := &exchange.AccountOperation{Type: exchange.Withdraw, Coin: xbt, WithdrawAmount: "0.1", WithdrawAddress: "3BMEXqGpG4FxBA1KWhRFufXfSTRgzfDBhJ"}
+	if err := e.DoAccoutOperation(operation); err != nil || operation.WithdrawID == "" {
+		t.Fatalf("Expected the withdrawal operation to pass, got %v %v", operation.WithdrawID, err)
+	}
+	withdrawals := s.Withdrawals()
+	if len(withdrawals) != 2 || withdrawals[0].OTP == "" || len(withdrawals[0].OTP) != 6 || withdrawals[1].ID != operation.WithdrawID {
+		t.Errorf("Expected the 2 withdrawals confirmed by the TOTP code, got %+v", withdrawals)
+	}
+	if free, _ := s.Balance("XBT"); !equal(free, 0.65) {
+		t.Errorf("Expected 0.65 XBT left, got %v", free)
+	}
+
+	e.Two_Factor = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	defer func() { e.Two_Factor = testSeed }()
+	operation = &exchange.AccountOperation{Type: exchange.Withdraw, Coin: xbt, WithdrawAmount: "0.1", WithdrawAddress: "3BMEXqGpG4FxBA1KWhRFufXfSTRgzfDBhJ"}
+	if err := e.DoAccoutOperation(operation); err == nil || !strings.Contains(err.Error(), "otpToken") {
+		t.Errorf("Expected the code of another seed to be rejected, got %v", err)
+	}
+	if len(s.Withdrawals()) != 2 {
+		t.Error("Expected no withdrawal with the wrong code")
+	}
+
+	// the balances are listed, the trade is not probed
+	probed := exchange.ProbeCredentials(e)
+	if !probed.Probed || !probed.Can(exchange.READ) || probed.Known(exchange.TRADE) {
+		t.Errorf("Expected the probe to find the key can read, got %v", probed.Permissions)
+	}
+	credentials, err := exchange.CheckCredentials(e, nil)
+	if err != nil || !credentials.Probed || !credentials.Can(exchange.READ) {
+		t.Errorf("Expected CheckCredentials to probe the key, got %v %v", credentials, err)
+	}
+
+	e.API_SECRET = "wrong"
+	defer func() { e.API_SECRET = testSecret }()
+	probed = exchange.ProbeCredentials(e)
+	if !probed.Known(exchange.READ) || probed.Can(exchange.READ) {
+		t.Errorf("Expected the probe to find the rejected key can not read, got %v", probed.Permissions)
+	}
+}
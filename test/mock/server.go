@@ -0,0 +1,416 @@
+package mock
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+var (
+	errUnknownSymbol = errors.New("unknown symbol")
+	errUnknownOrder  = errors.New("unknown order")
+	errOrderClosed   = errors.New("order is not open")
+	errLotSize       = errors.New("quantity is not a multiple of the lot size")
+	errPriceFilter   = errors.New("price is not a multiple of the price filter")
+	errBalance       = errors.New("insufficient balance")
+)
+
+// Market is a pair listed by the Server, Base is the quote coin as in pair.Pair.
+type Market struct {
+	Symbol      string
+	Base        string
+	Target      string
+	LotSize     float64
+	PriceFilter float64
+	MinQty      float64 // listed by the venues with the limits, not enforced
+	MaxQty      float64
+	MinNotional float64
+	MaxOrders   int
+	// the book of the other traders served by the depth endpoints, rate and
+	// quantity from the best level, the orders placed on the Server are not in it
+	Bids [][2]float64
+	Asks [][2]float64
+}
+
+// Order is an order placed on the Server. It stays New until Fill.
+type Order struct {
+	ID       string
+	Symbol   string
+	Side     string // "Buy" or "Sell"
+	Rate     float64
+	Quantity float64
+	Filled   float64
+	Status   exchange.OrderStatus
+}
+
+// Withdrawal is a withdrawal requested on the Server, with the TOTP code sent.
+type Withdrawal struct {
+	ID       string
+	Code     string
+	Quantity float64
+	Address  string
+	OTP      string
+}
+
+// Server is an in-process exchange server that emulates the REST endpoints and
+// the signature checks of one venue, and keeps the balances and orders. Point
+// the adapters at it with exchange.SetHttpTransport(mock.Transport(servers...)).
+// The fees are listed by the account endpoints, not charged.
+type Server struct {
+	URL        string
+	Key        string
+	Secret     string
+	TimeOffset time.Duration // of the server clock from the local clock
+	MakerFee   float64
+	TakerFee   float64
+	// the rights of the key listed by the key endpoints, not enforced
+	Permissions []exchange.Permission
+	IPs         []string
+	KeyExpiry   time.Time // zero if the key does not expire
+
+	venue   string
+	hosts   []string
+	handler http.Handler
+	server  *httptest.Server
+
+	mutex    sync.Mutex
+	markets  []*Market
+	balances map[string]float64 // free, by coin code
+	locked   map[string]float64
+	orders   map[string]*Order
+	nextID   int
+
+	withdrawals []Withdrawal
+}
+
+func start(venue string, key, secret string, hosts []string, handler func(s *Server) http.Handler) *Server {
+	s := &Server{
+		Key:      key,
+		Secret:   secret,
+		venue:    venue,
+		hosts:    hosts,
+		balances: make(map[string]float64),
+		locked:   make(map[string]float64),
+		orders:   make(map[string]*Order),
+		nextID:   1000,
+	}
+	s.handler = handler(s)
+	s.server = httptest.NewServer(s.handler)
+	s.URL = s.server.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Now is the server clock, the signed requests must be within its window.
+func (s *Server) Now() time.Time {
+	return time.Now().Add(s.TimeOffset)
+}
+
+// AddMarket lists the pair Target/Base under the venue's symbol.
+func (s *Server) AddMarket(target, base string, lotSize, priceFilter float64) *Market {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	target, base = strings.ToUpper(target), strings.ToUpper(base)
+	market := &Market{Base: base, Target: target, LotSize: lotSize, PriceFilter: priceFilter}
+	switch s.venue {
+	case "huobi":
+		market.Symbol = strings.ToLower(target + base)
+	case "kucoin":
+		market.Symbol = target + "-" + base
+	default:
+		market.Symbol = target + base
+	}
+	s.markets = append(s.markets, market)
+	return market
+}
+
+func (s *Server) SetBalance(code string, free float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.balances[strings.ToUpper(code)] = free
+}
+
+// Balance returns the free and the locked balance of the coin.
+func (s *Server) Balance(code string) (float64, float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	code = strings.ToUpper(code)
+	return s.balances[code], s.locked[code]
+}
+
+// Order returns a copy of the order, nil if unknown.
+func (s *Server) Order(id string) *Order {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if order, ok := s.orders[id]; ok {
+		copied := *order
+		return &copied
+	}
+	return nil
+}
+
+// Fill trades the quantity of an open order at its rate, as if it was matched.
+func (s *Server) Fill(id string, quantity float64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	order, ok := s.orders[id]
+	if !ok {
+		return errUnknownOrder
+	} else if order.Status != exchange.New && order.Status != exchange.Partial {
+		return errOrderClosed
+	}
+	quantity = math.Min(quantity, order.Quantity-order.Filled)
+	market := s.market(order.Symbol)
+	if order.Side == "Buy" {
+		s.locked[market.Base] -= quantity * order.Rate
+		s.balances[market.Target] += quantity
+	} else {
+		s.locked[market.Target] -= quantity
+		s.balances[market.Base] += quantity * order.Rate
+	}
+	order.Filled += quantity
+	if order.Quantity-order.Filled < market.LotSize/2 {
+		order.Status = exchange.Filled
+	} else {
+		order.Status = exchange.Partial
+	}
+	return nil
+}
+
+// Withdrawals returns the withdrawals requested so far.
+func (s *Server) Withdrawals() []Withdrawal {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Withdrawal{}, s.withdrawals...)
+}
+
+// withdraw takes the quantity from the free balance.
+func (s *Server) withdraw(code string, quantity float64, address, otp string) (*Withdrawal, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	code = strings.ToUpper(code)
+	if s.balances[code] < quantity-1e-12 {
+		return nil, errBalance
+	}
+	s.balances[code] -= quantity
+
+	s.nextID++
+	withdrawal := Withdrawal{ID: strconv.Itoa(s.nextID), Code: code, Quantity: quantity, Address: address, OTP: otp}
+	s.withdrawals = append(s.withdrawals, withdrawal)
+	return &withdrawal, nil
+}
+
+// coins are the codes of the listed coins, sorted.
+func (s *Server) coins() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	set := make(map[string]bool)
+	for _, market := range s.markets {
+		set[market.Base], set[market.Target] = true, true
+	}
+	codes := []string{}
+	for code := range set {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func (s *Server) can(permission exchange.Permission) bool {
+	for _, p := range s.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionList lists the venue's names of the permissions of the key.
+func (s *Server) permissionList(names map[exchange.Permission]string) string {
+	list := []string{}
+	for _, permission := range s.Permissions {
+		if name, ok := names[permission]; ok {
+			list = append(list, name)
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+func (s *Server) listMarkets() []*Market {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*Market{}, s.markets...)
+}
+
+// market is called with the mutex locked.
+func (s *Server) market(symbol string) *Market {
+	for _, market := range s.markets {
+		if market.Symbol == symbol {
+			return market
+		}
+	}
+	return nil
+}
+
+// book returns a copy of the levels of the market and the sequence of the book,
+// false if the symbol is not listed.
+func (s *Server) book(symbol string) (bids, asks [][2]float64, sequence int, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	market := s.market(symbol)
+	if market == nil {
+		return nil, nil, 0, false
+	}
+	return append([][2]float64{}, market.Bids...), append([][2]float64{}, market.Asks...), s.nextID, true
+}
+
+// formatLevels formats the levels as the pairs of strings most venues send.
+func formatLevels(levels [][2]float64) [][2]string {
+	formatted := [][2]string{}
+	for _, level := range levels {
+		formatted = append(formatted, [2]string{format(level[0]), format(level[1])})
+	}
+	return formatted
+}
+
+// balanceList returns the free and locked balances of every coin.
+func (s *Server) balanceList() map[string][2]float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	list := make(map[string][2]float64)
+	for code, free := range s.balances {
+		list[code] = [2]float64{free, s.locked[code]}
+	}
+	for code, locked := range s.locked {
+		list[code] = [2]float64{s.balances[code], locked}
+	}
+	return list
+}
+
+// place checks the order against the market and locks its funds.
+func (s *Server) place(symbol, side string, rate, quantity float64) (*Order, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	market := s.market(symbol)
+	if market == nil {
+		return nil, errUnknownSymbol
+	}
+	if quantity <= 0 || !onStep(quantity, market.LotSize) {
+		return nil, errLotSize
+	}
+	if rate <= 0 || !onStep(rate, market.PriceFilter) {
+		return nil, errPriceFilter
+	}
+
+	code, amount := market.Target, quantity
+	if side == "Buy" {
+		code, amount = market.Base, quantity*rate
+	}
+	if s.balances[code] < amount-1e-12 {
+		return nil, errBalance
+	}
+	s.balances[code] -= amount
+	s.locked[code] += amount
+
+	s.nextID++
+	order := &Order{
+		ID:       strconv.Itoa(s.nextID),
+		Symbol:   symbol,
+		Side:     side,
+		Rate:     rate,
+		Quantity: quantity,
+		Status:   exchange.New,
+	}
+	s.orders[order.ID] = order
+	copied := *order
+	return &copied, nil
+}
+
+// cancel unlocks the funds of the unfilled quantity.
+func (s *Server) cancel(id string) (*Order, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	order, ok := s.orders[id]
+	if !ok {
+		return nil, errUnknownOrder
+	} else if order.Status != exchange.New && order.Status != exchange.Partial {
+		return nil, errOrderClosed
+	}
+	market := s.market(order.Symbol)
+	remain := order.Quantity - order.Filled
+	if order.Side == "Buy" {
+		s.locked[market.Base] -= remain * order.Rate
+		s.balances[market.Base] += remain * order.Rate
+	} else {
+		s.locked[market.Target] -= remain
+		s.balances[market.Target] += remain
+	}
+	order.Status = exchange.Cancelled
+	copied := *order
+	return &copied, nil
+}
+
+func onStep(value, step float64) bool {
+	if step <= 0 {
+		return true
+	}
+	ratio := value / step
+	return math.Abs(ratio-math.Round(ratio)) < 1e-6
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func format(value float64) string {
+	return strconv.FormatFloat(value, 'f', 8, 64)
+}
+
+type transport struct {
+	servers map[string]*Server
+}
+
+// Transport routes the requests to the hosts of the venues to their Servers,
+// the requests to any other host fail, nothing leaves the process.
+func Transport(servers ...*Server) http.RoundTripper {
+	t := &transport{servers: make(map[string]*Server)}
+	for _, s := range servers {
+		for _, host := range s.hosts {
+			t.servers[host] = s
+		}
+	}
+	return t
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	s, ok := t.servers[request.URL.Host]
+	if !ok {
+		return nil, fmt.Errorf("mock: no server for host %s", request.URL.Host)
+	}
+	target, _ := url.Parse(s.URL)
+	routed := request.WithContext(request.Context())
+	copied := *request.URL
+	copied.Scheme, copied.Host = target.Scheme, target.Host
+	routed.URL = &copied
+	return http.DefaultTransport.RoundTrip(routed)
+}
@@ -0,0 +1,104 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func Test_Concurrent(t *testing.T) {
+	// seconds, so most of the nonces are raised above the clock
+	nonce := exchange.GetNonce("FAKE_NONCE", "key", time.Second)
+	if exchange.GetNonce("FAKE_NONCE", "key", time.Millisecond) != nonce {
+		t.Error("Expected one nonce per API key")
+	}
+	if exchange.GetNonce("FAKE_NONCE", "other", time.Second) == nonce {
+		t.Error("Expected another nonce for another API key")
+	}
+
+	const goroutines, calls = 8, 500
+	results := make([][]int64, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				results[i] = append(results[i], nonce.Next())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[int64]bool)
+	for _, result := range results {
+		for j, n := range result {
+			if j > 0 && n <= result[j-1] {
+				t.Fatalf("Expected the nonces of a goroutine to increase, got %d after %d", n, result[j-1])
+			}
+			if seen[n] {
+				t.Fatalf("Expected unique nonces, got %d twice", n)
+			}
+			seen[n] = true
+		}
+	}
+	if now := time.Now().Unix(); results[0][0] < now-1 {
+		t.Errorf("Expected the nonce to start at the time, got %d at %d", results[0][0], now)
+	}
+}
+
+func Test_Store(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nonce", "nonces.json")
+	exchange.SetNonceStore(exchange.NewFileNonceStore(path))
+	defer exchange.SetNonceStore(nil)
+
+	nonce := exchange.GetNonce("FAKE_NONCE_STORE", "secret-key", time.Nanosecond)
+	last := nonce.Next()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Error("Expected the API key not to be written to the store")
+	}
+	saved := make(map[string]int64)
+	if err := json.Unmarshal(data, &saved); err != nil || len(saved) != 1 {
+		t.Fatalf("Expected one nonce in the store, got %s %v", data, err)
+	}
+
+	// a restart continues from the saved nonce, even with the clock behind it
+	restarted := exchange.NewFileNonceStore(path)
+	ahead := time.Now().Add(time.Hour).UnixNano()
+	for key, n := range saved {
+		if n != last {
+			t.Errorf("Expected the saved nonce %d, got %d", last, n)
+		}
+		if err := restarted.Save(key, ahead); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exchange.SetNonceStore(restarted)
+	if next := nonce.Next(); next != ahead+1 {
+		t.Errorf("Expected the nonce after the saved %d, got %d", ahead, next)
+	}
+	if n, err := exchange.NewFileNonceStore(path).Load(nonceKey(saved)); err != nil || n != ahead+1 {
+		t.Errorf("Expected the nonce %d to be saved, got %d %v", ahead+1, n, err)
+	}
+}
+
+func nonceKey(saved map[string]int64) string {
+	for key := range saved {
+		return key
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func Test_Consolidate(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+	now := float64(time.Now().UnixNano() / 1e6)
+
+	ex1 := fake.CreateExchange(9005, "FAKE_BOOK_1")
+	ex1.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+	ex1.SetOrderBook(ethbtc, &exchange.Maker{
+		AfterTimestamp: now,
+		Bids:           []exchange.Order{{Rate: 99, Quantity: 1}},
+		Asks:           []exchange.Order{{Rate: 101, Quantity: 1}, {Rate: 102, Quantity: 3}},
+	})
+	ex2 := fake.CreateExchange(9006, "FAKE_BOOK_2")
+	ex2.AddPair(ethbtc, 0.002, 0.01, 0.000001)
+	ex2.SetOrderBook(ethbtc, &exchange.Maker{
+		AfterTimestamp: now,
+		Bids:           []exchange.Order{{Rate: 98, Quantity: 2}, {Rate: 99, Quantity: 2}},
+		Asks:           []exchange.Order{{Rate: 101, Quantity: 5}},
+	})
+	stale := fake.CreateExchange(9007, "FAKE_BOOK_STALE")
+	stale.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+	stale.SetOrderBook(ethbtc, &exchange.Maker{
+		AfterTimestamp: now - 60000,
+		Bids:           []exchange.Order{{Rate: 100, Quantity: 1}},
+	})
+	exchanges := []exchange.Exchange{ex1, ex2, stale}
+
+	book := orderbook.ConsolidateExchanges(ethbtc, exchanges, &orderbook.Options{MaxAge: 10 * time.Second})
+	if len(book.Exchanges) != 2 || book.Skipped[stale.GetName()] == nil {
+		t.Fatalf("Expected the stale book skipped, got %v", book.Exchanges)
+	}
+	if len(book.Bids) != 2 || len(book.Asks) != 2 {
+		t.Fatalf("Expected 2 bid and 2 ask levels, got %d and %d", len(book.Bids), len(book.Asks))
+	}
+	top := book.Asks[0]
+	checkFloat(t, "Top ask rate", top.Rate, 101)
+	checkFloat(t, "Top ask quantity", top.Quantity, 6)
+	if len(top.Venues) != 2 || top.Venues[0].ExName != ex1.GetName() || top.Venues[1].ExName != ex2.GetName() {
+		t.Errorf("Expected the top ask from both exchanges, got %v", top.Venues)
+	}
+	checkFloat(t, "Top bid quantity", book.Bids[0].Quantity, 3)
+
+	mid, _ := orderbook.Mid(book.Maker())
+	checkFloat(t, "Consolidated mid", mid, 100)
+
+	// fees split the shared levels
+	book = orderbook.ConsolidateExchanges(ethbtc, exchanges, &orderbook.Options{FeeAdjusted: true, MaxAge: 10 * time.Second})
+	checkFloat(t, "Fee adjusted top ask", book.Asks[0].Rate, 101*1.001)
+	checkFloat(t, "Fee adjusted top ask venue rate", book.Asks[0].Venues[0].Rate, 101)
+	if len(book.Asks) != 3 {
+		t.Errorf("Expected 3 fee adjusted ask levels, got %d", len(book.Asks))
+	}
+
+	ex2.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", Withdraw: true, Deposit: false})
+	book = orderbook.ConsolidateExchanges(ethbtc, exchanges, &orderbook.Options{WalletEnabled: true})
+	if len(book.Exchanges) != 2 || book.Skipped[ex2.GetName()] == nil {
+		t.Errorf("Expected %s skipped with deposit disabled, got %v", ex2.GetName(), book.Exchanges)
+	}
+}
@@ -0,0 +1,90 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+)
+
+// unsorted on purpose, the adapters do not all sort their books
+var maker = &exchange.Maker{
+	Bids: []exchange.Order{{Rate: 98, Quantity: 2}, {Rate: 99, Quantity: 1}, {Rate: 90, Quantity: 10}},
+	Asks: []exchange.Order{{Rate: 102, Quantity: 2}, {Rate: 101, Quantity: 1}, {Rate: 0, Quantity: 5}, {Rate: 110, Quantity: 10}},
+}
+
+func Test_SpreadMid(t *testing.T) {
+	mid, err := orderbook.Mid(maker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFloat(t, "Mid", mid, 100)
+
+	spread, _ := orderbook.Spread(maker)
+	checkFloat(t, "Spread", spread, 2)
+	bps, _ := orderbook.SpreadBps(maker)
+	checkFloat(t, "SpreadBps", bps, 200)
+
+	if _, err := orderbook.Mid(&exchange.Maker{}); err == nil {
+		t.Errorf("Expected error for empty book")
+	}
+}
+
+func Test_Fill(t *testing.T) {
+	f, err := orderbook.Buy(maker, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFloat(t, "Buy VWAP", f.VWAP, (101+102)/2.0)
+	checkFloat(t, "Buy WorstRate", f.WorstRate, 102)
+	checkFloat(t, "Buy Slippage", f.Slippage(), (101.5-101)/101)
+	impact, _ := orderbook.Impact(maker, f)
+	checkFloat(t, "Buy Impact", impact, 0.015)
+
+	f, err = orderbook.SellAmount(maker, 99+98)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFloat(t, "SellAmount Quantity", f.Quantity, 2)
+	checkFloat(t, "SellAmount VWAP", f.VWAP, 98.5)
+
+	f, err = orderbook.Sell(maker, 20)
+	if err == nil {
+		t.Errorf("Expected error for a fill deeper than the book")
+	}
+	checkFloat(t, "Partial Quantity", f.Quantity, 13)
+}
+
+func Test_Depth(t *testing.T) {
+	bid, ask, err := orderbook.Depth(maker, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFloat(t, "Bid depth", bid, 3)
+	checkFloat(t, "Ask depth", ask, 3)
+
+	bid, ask, _ = orderbook.DepthBps(maker, 150)
+	checkFloat(t, "Bid depth bps", bid, 1)
+	checkFloat(t, "Ask depth bps", ask, 1)
+
+	quantity, amount := orderbook.BuyQuantity(maker, 105)
+	checkFloat(t, "BuyQuantity", quantity, 3)
+	checkFloat(t, "BuyQuantity amount", amount, 101+204)
+}
+
+func Test_EffectiveRate(t *testing.T) {
+	pc := &exchange.PairConstraint{TakerFee: 0.001}
+	checkFloat(t, "EffectiveBuyRate", orderbook.EffectiveBuyRate(100, pc), 100.1)
+	checkFloat(t, "EffectiveSellRate", orderbook.EffectiveSellRate(100, pc), 99.9)
+}
+
+func checkFloat(t *testing.T, name string, got, expected float64) {
+	if math.Abs(got-expected) > 1e-9 {
+		t.Errorf("%s: expected %v, got %v", name, expected, got)
+	}
+}
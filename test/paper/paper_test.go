@@ -0,0 +1,98 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/paper"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func Test_Paper(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	market := fake.CreateExchange(9011, "FAKE_PAPER")
+	market.AddPair(ethbtc, 0.001, 0.01, 0.01)
+	market.SetOrderBook(ethbtc, &exchange.Maker{
+		Bids: []exchange.Order{{Rate: 99, Quantity: 5}},
+		Asks: []exchange.Order{{Rate: 101, Quantity: 1}, {Rate: 102, Quantity: 1}},
+	})
+
+	ex := paper.CreateExchange(market)
+	ex.SetBalance(btc, 1000)
+
+	// crosses the first ask, the rest rests on the book
+	order, err := ex.LimitBuy(ethbtc, 3, 101.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != exchange.Partial {
+		t.Errorf("Expected Partial, got %v", order.Status)
+	}
+	checkFloat(t, "DealQuantity", order.DealQuantity, 1)
+	checkFloat(t, "DealRate", order.DealRate, 101)
+	checkFloat(t, "ETH balance", ex.GetBalance(eth), 1)
+	checkFloat(t, "BTC locked", ex.GetLockedBalance(btc), 2*101.5*1.001)
+	checkFloat(t, "BTC balance", ex.GetBalance(btc), 1000-101*1.001-2*101.5*1.001)
+
+	if _, err := ex.LimitSell(ethbtc, 2, 100); err == nil {
+		t.Errorf("Expected insufficient balance error")
+	}
+
+	orders, _ := ex.ListOrders()
+	if len(orders) != 1 || orders[0].OrderID != order.OrderID {
+		t.Errorf("Expected the buy order open, got %v", orders)
+	}
+
+	// the market moves through the order's rate
+	market.SetOrderBook(ethbtc, &exchange.Maker{
+		Bids: []exchange.Order{{Rate: 99, Quantity: 5}},
+		Asks: []exchange.Order{{Rate: 100, Quantity: 1}},
+	})
+	if err := ex.OrderStatus(order); err != nil {
+		t.Fatal(err)
+	}
+	checkFloat(t, "DealQuantity", order.DealQuantity, 2)
+	checkFloat(t, "DealRate", order.DealRate, 100.5)
+
+	if err := ex.CancelOrder(order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != exchange.Cancelled {
+		t.Errorf("Expected Cancelled, got %v", order.Status)
+	}
+	checkFloat(t, "BTC locked after cancel", ex.GetLockedBalance(btc), 0)
+	checkFloat(t, "BTC balance after cancel", ex.GetBalance(btc), 1000-(101+100)*1.001)
+	if err := ex.CancelOrder(order); err == nil {
+		t.Errorf("Expected error cancelling a cancelled order")
+	}
+
+	sell, err := ex.LimitSell(ethbtc, 2, 99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sell.Status != exchange.Filled {
+		t.Errorf("Expected Filled, got %v", sell.Status)
+	}
+	checkFloat(t, "ETH after sell", ex.GetBalance(eth), 0)
+	checkFloat(t, "BTC after sell", ex.GetBalance(btc), 1000-(101+100)*1.001+2*99*0.999)
+}
+
+func checkFloat(t *testing.T, name string, got, expected float64) {
+	if math.Abs(got-expected) > 1e-9 {
+		t.Errorf("%s: expected %v, got %v", name, expected, got)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/recorder"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func Test_RecordReplay(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ex1 := fake.CreateExchange(9012, "FAKE_RECORD_1")
+	ex1.AddPair(ethbtc, 0.001, 0.01, 0.01)
+	ex2 := fake.CreateExchange(9013, "FAKE_RECORD_2")
+	ex2.AddPair(ethbtc, 0.001, 0.01, 0.01)
+
+	r := recorder.CreateRecorder(dir, time.Minute)
+	r.Add(ex1)
+	r.Add(ex2, ethbtc)
+
+	start := float64(time.Now().UnixNano() / 1e6)
+	for i := 0; i < 3; i++ {
+		ts := start + float64(i)*1000
+		ex1.SetOrderBook(ethbtc, &exchange.Maker{AfterTimestamp: ts, Bids: []exchange.Order{{Rate: 100 + float64(i), Quantity: 1}}})
+		// ex2 is recorded half a second later
+		ex2.SetOrderBook(ethbtc, &exchange.Maker{AfterTimestamp: ts + 500, Asks: []exchange.Order{{Rate: 200 + float64(i), Quantity: 2}}})
+		if err := r.Capture(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.gz"))
+	if len(files) < 2 {
+		t.Fatalf("Expected a file per exchange, got %v", files)
+	}
+
+	replay, err := recorder.LoadReplay(filepath.Join(dir, "*.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := replay.Exchange(ex1)
+	if _, err := replayed.OrderBook(ethbtc); err == nil {
+		t.Errorf("Expected no book before the first snapshot")
+	}
+
+	order := []exchange.ExchangeName{}
+	last := 0.0
+	for {
+		snapshot, ok := replay.Next()
+		if !ok {
+			break
+		}
+		if snapshot.Timestamp < last {
+			t.Errorf("Snapshots out of order: %v after %v", snapshot.Timestamp, last)
+		}
+		last = snapshot.Timestamp
+		order = append(order, snapshot.ExName)
+	}
+	if len(order) != 6 || order[0] != ex1.GetName() || order[1] != ex2.GetName() {
+		t.Errorf("Expected 6 snapshots alternating exchanges, got %v", order)
+	}
+
+	maker, err := replayed.OrderBook(ethbtc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(maker.Bids) != 1 || maker.Bids[0].Rate != 102 {
+		t.Errorf("Expected the last recorded book, got %v", maker.Bids)
+	}
+
+	// a member cut short by a crash is dropped without error
+	info, _ := os.Stat(files[0])
+	os.Truncate(files[0], info.Size()-10)
+	snapshots, err := recorder.ReadSnapshots(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 {
+		t.Errorf("Expected 2 snapshots before the truncated member, got %d", len(snapshots))
+	}
+
+	// the members appended after the restart are read past the damaged one
+	if err := recorder.AppendSnapshots(files[0], snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, err = recorder.ReadSnapshots(files[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 4 {
+		t.Errorf("Expected 4 snapshots around the truncated member, got %d", len(snapshots))
+	}
+}
@@ -0,0 +1,169 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/router"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func setup() (*pair.Pair, *fake.Exchange, *fake.Exchange, *fake.Exchange) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	ex1 := fake.CreateExchange(9008, "FAKE_ROUTE_1")
+	ex1.AddPair(ethbtc, 0.001, 0.1, 0.01)
+	ex1.SetBalance(btc, 1000)
+	ex1.SetOrderBook(ethbtc, &exchange.Maker{
+		Asks: []exchange.Order{{Rate: 100, Quantity: 1}, {Rate: 103, Quantity: 10}},
+	})
+
+	// cheaper book but only enough balance for about 2 ETH
+	ex2 := fake.CreateExchange(9009, "FAKE_ROUTE_2")
+	ex2.AddPair(ethbtc, 0.001, 0.1, 0.01)
+	ex2.SetBalance(btc, 205)
+	ex2.SetOrderBook(ethbtc, &exchange.Maker{
+		Asks: []exchange.Order{{Rate: 101, Quantity: 5}},
+	})
+
+	// no balance, never routed
+	ex3 := fake.CreateExchange(9010, "FAKE_ROUTE_3")
+	ex3.AddPair(ethbtc, 0.001, 0.1, 0.01)
+	ex3.SetOrderBook(ethbtc, &exchange.Maker{
+		Asks: []exchange.Order{{Rate: 50, Quantity: 100}},
+	})
+	return ethbtc, ex1, ex2, ex3
+}
+
+func Test_Plan(t *testing.T) {
+	ethbtc, ex1, ex2, ex3 := setup()
+	r := router.CreateRouter()
+	r.DryRun = true
+
+	report, err := r.ExecuteExchanges(ethbtc, router.BUY, 5, []exchange.Exchange{ex1, ex2, ex3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Fills) != 0 || len(ex1.Orders()) != 0 {
+		t.Errorf("Expected no order placed in dry run")
+	}
+
+	plan := report.Plan
+	if len(plan.Children) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(plan.Children))
+	}
+	// 1 @100 on ex1, 2 @101 on ex2 (budget 205), the rest @103 on ex1
+	first, second := plan.Children[0], plan.Children[1]
+	if first.Exchange != ex1 || second.Exchange != ex2 {
+		t.Fatalf("Expected children on %s then %s", ex1.GetName(), ex2.GetName())
+	}
+	checkFloat(t, "ex1 quantity", first.Quantity, 3)
+	checkFloat(t, "ex1 rate", first.Rate, 103)
+	checkFloat(t, "ex2 quantity", second.Quantity, 2)
+	checkFloat(t, "ex2 rate", second.Rate, 101)
+	if second.Quantity*second.Rate*1.001 > 205 {
+		t.Errorf("ex2 child exceeds the balance: %v", second.Quantity*second.Rate*1.001)
+	}
+	checkFloat(t, "Planned", plan.Planned, 5)
+}
+
+func Test_Execute(t *testing.T) {
+	ethbtc, ex1, ex2, ex3 := setup()
+	ex1.AutoFill = true
+	r := router.CreateRouter()
+	r.PollInterval = time.Millisecond
+	r.Timeout = 20 * time.Millisecond
+
+	report, err := r.ExecuteExchanges(ethbtc, router.BUY, 5, []exchange.Exchange{ex1, ex2, ex3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Fills) != 2 {
+		t.Fatalf("Expected 2 fills, got %d", len(report.Fills))
+	}
+	if report.Fills[0].Order.Status != exchange.Filled {
+		t.Errorf("Expected %s order filled, got %v", ex1.GetName(), report.Fills[0].Order.Status)
+	}
+	// ex2 never fills and is cancelled at the timeout
+	if report.Fills[1].Order.Status != exchange.Cancelled {
+		t.Errorf("Expected %s order cancelled, got %v", ex2.GetName(), report.Fills[1].Order.Status)
+	}
+	checkFloat(t, "Filled quantity", report.Quantity, 3)
+	checkFloat(t, "Filled rate", report.Rate, 103)
+	checkFloat(t, "Fee", report.Fee, 3*103*0.001)
+}
+
+func Test_ExecuteNotFilled(t *testing.T) {
+	ethbtc, ex1, ex2, ex3 := setup()
+	r := router.CreateRouter()
+	r.PollInterval = time.Millisecond
+
+	r.Timeout = 0
+	if _, err := r.ExecuteExchanges(ethbtc, router.BUY, 5, []exchange.Exchange{ex1, ex2, ex3}); err == nil {
+		t.Errorf("Expected an error without timeout")
+	}
+	if len(ex1.Orders()) != 0 || len(ex2.Orders()) != 0 {
+		t.Errorf("Expected no order placed without timeout")
+	}
+
+	r.Timeout = 10 * time.Millisecond
+	report, err := r.ExecuteExchanges(ethbtc, router.BUY, 5, []exchange.Exchange{ex1, ex2, ex3})
+	if err == nil {
+		t.Errorf("Expected an error when no order is filled")
+	}
+	if report == nil || len(report.Fills) != 2 || report.Quantity != 0 {
+		t.Errorf("Expected the report of the cancelled orders, got %+v", report)
+	}
+}
+
+// the child is floored to the lot size within the balance, without a float nudge,
+// and the balances are refreshed only with UpdateBalances
+func Test_PlanWithinBalance(t *testing.T) {
+	ethbtc, _, _, _ := setup()
+	ex := fake.CreateExchange(9027, "FAKE_ROUTE_4")
+	ex.AddPair(ethbtc, 0, 0.1, 0.01)
+	ex.SetBalance(ethbtc.Base, 0.29999999995)
+	ex.SetOrderBook(ethbtc, &exchange.Maker{
+		Asks: []exchange.Order{{Rate: 1, Quantity: 10}},
+	})
+	r := router.CreateRouter()
+
+	plan, err := r.PlanExchanges(ethbtc, router.BUY, 1, []exchange.Exchange{ex})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Children) != 1 || plan.Children[0].Quantity != 0.2 {
+		t.Errorf("Expected a child of 0.2 within the balance, got %+v", plan.Children)
+	}
+	if ex.BalanceUpdates != 0 {
+		t.Errorf("Expected no balance update, got %d", ex.BalanceUpdates)
+	}
+
+	r.UpdateBalances = true
+	if _, err := r.PlanExchanges(ethbtc, router.BUY, 1, []exchange.Exchange{ex}); err != nil {
+		t.Fatal(err)
+	}
+	if ex.BalanceUpdates != 1 {
+		t.Errorf("Expected 1 balance update, got %d", ex.BalanceUpdates)
+	}
+}
+
+func checkFloat(t *testing.T, name string, got, expected float64) {
+	if math.Abs(got-expected) > 1e-9 {
+		t.Errorf("%s: expected %v, got %v", name, expected, got)
+	}
+}
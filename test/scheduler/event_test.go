@@ -0,0 +1,160 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"testing"
+	"time"
+
+	cmap "github.com/orcaman/concurrent-map"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func Test_Events(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	ltc := &coin.Coin{ID: 3, Code: "LTC"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	coin.AddCoin(ltc)
+	ethbtc := pair.SetPair(1, btc, eth)
+	ltcbtc := pair.SetPair(2, btc, ltc)
+
+	ex := fake.CreateExchange(9002, "FAKE_EVENT")
+	ex.AddPair(ethbtc, 0.001, 0.001, 0.000001)
+	ex.OnInitData = func(e *fake.Exchange) error {
+		e.AddPair(ltcbtc, 0.002, 0.01, 0.000001)
+		e.SetCoinConstraint(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", TxFee: 0.02, Withdraw: false, Deposit: true})
+		return nil
+	}
+
+	exMan := exchange.CreateExchangeManager()
+	exMan.Add(ex)
+	events, id := exMan.SubscribeChan(10)
+	defer exMan.Unsubscribe(id)
+
+	if err := exMan.CreateScheduler(1).RefreshNow(ex.GetName()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the writes of the refresh publish in their order
+	expectEvents(t, events, ex.GetName(), []expectedEvent{
+		{exchange.PAIR_LISTED, "BTC|LTC"},
+		{exchange.WITHDRAW_DISABLED, "ETH"},
+		{exchange.TX_FEE_CHANGED, "ETH"},
+	})
+
+	// a write outside of a refresh publishes too
+	ex.DeletePair(ltcbtc)
+	expectEvents(t, events, ex.GetName(), []expectedEvent{
+		{exchange.PAIR_DELISTED, "BTC|LTC"},
+	})
+}
+
+// the swap of a fetch publishes the changes, ordered by coin ID then pair ID
+func Test_EventsSwap(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	ltc := &coin.Coin{ID: 3, Code: "LTC"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	coin.AddCoin(ltc)
+	ethbtc := pair.SetPair(1, btc, eth)
+	ltcbtc := pair.SetPair(2, btc, ltc)
+
+	ex := fake.CreateExchange(9023, "FAKE_SWAP")
+	exMan := exchange.CreateExchangeManager()
+	exMan.Add(ex)
+	events, id := exMan.SubscribeChan(10)
+	defer exMan.Unsubscribe(id)
+
+	// the first load publishes nothing
+	coins, pairs := cmap.New(), cmap.New()
+	coins.Set("2", &exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", Withdraw: true})
+	pairs.Set("1", &exchange.PairConstraint{PairID: ethbtc.ID, Pair: ethbtc, ExSymbol: "ETHBTC", Listed: true})
+	constraints := exchange.NewConstraints(ex.GetName())
+	constraints.Load(coins, pairs)
+
+	stage := constraints.Stage()
+	err := constraints.Fetch(stage, func() error {
+		stage.SetCoin(&exchange.CoinConstraint{CoinID: eth.ID, Coin: eth, ExSymbol: "ETH", Withdraw: false})
+		stage.SetPair(&exchange.PairConstraint{PairID: ltcbtc.ID, Pair: ltcbtc, ExSymbol: "LTCBTC", Listed: true})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, events, ex.GetName(), []expectedEvent{
+		{exchange.WITHDRAW_DISABLED, "ETH"},
+		{exchange.PAIR_DELISTED, "BTC|ETH"},
+		{exchange.PAIR_LISTED, "BTC|LTC"},
+	})
+}
+
+type expectedEvent struct {
+	Type exchange.EventType
+	Name string
+}
+
+func expectEvents(t *testing.T, events <-chan *exchange.Event, exName exchange.ExchangeName, expected []expectedEvent) {
+	t.Helper()
+	for _, e := range expected {
+		select {
+		case event := <-events:
+			name := ""
+			if event.Coin != nil {
+				name = event.Coin.Code
+			} else {
+				name = event.Pair.Name
+			}
+			if event.Type != e.Type || name != e.Name || event.ExName != exName {
+				t.Errorf("expected %s %s, got %v", e.Type, e.Name, event)
+			}
+		default:
+			t.Fatalf("missing event %s %s", e.Type, e.Name)
+		}
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %v", event)
+	default:
+	}
+}
+
+// a handler can unsubscribe itself and subscribe another one
+func Test_EventsResubscribe(t *testing.T) {
+	exMan := exchange.CreateExchangeManager()
+	var id int
+	calls := 0
+	id = exMan.Subscribe(func(event *exchange.Event) {
+		calls++
+		exMan.Unsubscribe(id)
+		id = exMan.Subscribe(func(event *exchange.Event) {})
+	})
+	defer func() { exMan.Unsubscribe(id) }()
+
+	done := make(chan struct{})
+	go func() {
+		exMan.Publish([]*exchange.Event{{ExName: "FAKE_EVENT", Type: exchange.PAIR_LISTED}})
+		exMan.Publish([]*exchange.Event{{ExName: "FAKE_EVENT", Type: exchange.PAIR_LISTED}})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Publish is blocked by the handler")
+	}
+	if calls != 1 {
+		t.Errorf("unsubscribed handler is called %d times", calls)
+	}
+}
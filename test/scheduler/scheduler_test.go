@@ -0,0 +1,114 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func Test_Scheduler(t *testing.T) {
+	var calls int32
+	var failing int32
+	ex := fake.CreateExchange(9001, "FAKE_SCHEDULER")
+	ex.OnInitData = func(e *fake.Exchange) error {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			return fmt.Errorf("%s is down", e.GetName())
+		}
+		return nil
+	}
+
+	exMan := exchange.CreateExchangeManager()
+	exMan.Add(ex)
+	scheduler := exMan.CreateScheduler(2)
+
+	if err := scheduler.RefreshNow(ex.GetName()); err != nil {
+		t.Fatal(err)
+	}
+	status := scheduler.Status(ex.GetName())
+	if status.LastSuccess.IsZero() || status.ErrorCount != 0 {
+		t.Errorf("status after success: %+v", status)
+	}
+
+	atomic.StoreInt32(&failing, 1)
+	if err := scheduler.RefreshNow(ex.GetName()); err == nil {
+		t.Errorf("failed refresh returns no error")
+	}
+	status = scheduler.Status(ex.GetName())
+	if status.ErrorCount != 1 || status.LastError == nil || !status.LastSuccess.Before(status.LastRefresh) {
+		t.Errorf("status after failure: %+v", status)
+	}
+
+	if err := scheduler.RefreshNow("FAKE_MISSING"); err == nil {
+		t.Errorf("refresh of an unknown exchange returns no error")
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	atomic.StoreInt32(&calls, 0)
+	scheduler.Schedule(ex.GetName(), 10*time.Millisecond)
+	scheduler.Start()
+	time.Sleep(100 * time.Millisecond)
+	scheduler.Stop()
+
+	stopped := atomic.LoadInt32(&calls)
+	if stopped < 2 {
+		t.Errorf("timed refresh ran %d times", stopped)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&calls) != stopped {
+		t.Errorf("refresh continues after Stop")
+	}
+
+	if err := scheduler.RefreshNow(ex.GetName()); err == nil {
+		t.Errorf("refresh after Stop returns no error")
+	}
+	scheduler.Refresh(ex.GetName())
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&calls) != stopped {
+		t.Errorf("refresh is queued after Stop")
+	}
+}
+
+// RefreshNow does not clear the pending refresh queued by Refresh
+func Test_SchedulerPending(t *testing.T) {
+	var calls int32
+	gate := make(chan struct{})
+	ex := fake.CreateExchange(9021, "FAKE_PENDING")
+	ex.OnInitData = func(e *fake.Exchange) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-gate
+		}
+		return nil
+	}
+
+	exMan := exchange.CreateExchangeManager()
+	exMan.Add(ex)
+	scheduler := exMan.CreateScheduler(2)
+
+	scheduler.Refresh(ex.GetName())
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := scheduler.RefreshNow(ex.GetName()); err != nil {
+		t.Fatal(err)
+	}
+	scheduler.Refresh(ex.GetName())
+	time.Sleep(20 * time.Millisecond)
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("pending exchange is queued again, calls: %d", n)
+	}
+
+	close(gate)
+	scheduler.Stop()
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("calls after Stop: %d", n)
+	}
+}
@@ -0,0 +1,260 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func at(ms int64) func() time.Time {
+	return func() time.Time { return time.Unix(0, ms*int64(time.Millisecond)) }
+}
+
+// hmacSHA256 signs the expected pre-hash string apart from the signer, for the
+// venues whose docs publish no key, secret and signature to check against.
+func hmacSHA256(message, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+// the example of the Binance API docs, SIGNED Endpoint Examples for POST /api/v3/order
+func Test_HmacQuerySigner(t *testing.T) {
+	signer := &exchange.HmacQuerySigner{
+		Key:        "vmPUZE6mv9SD5VNHk4HlWFsOr6aKE2zvsw0MuIgwCIPy6utIco14y7Ju91duEh8A",
+		Secret:     "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j",
+		KeyHeader:  "X-MBX-APIKEY",
+		RecvWindow: 5000,
+		Now:        at(1499827319559),
+	}
+	request := &exchange.SignRequest{Method: "POST", Path: "/api/v3/order", Query: "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1"}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559" +
+		"&signature=c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"
+	if request.Query != expected {
+		t.Errorf("Expected query %v, got %v", expected, request.Query)
+	}
+	if request.Header["X-MBX-APIKEY"] != signer.Key {
+		t.Errorf("Expected the key in X-MBX-APIKEY, got %v", request.Header)
+	}
+}
+
+// the example request of the Huobi API docs, GET /v1/order/orders. The docs
+// give the string to sign of a placeholder key and no signature, the test
+// checks the signer signs that string, not a documented signature.
+func Test_CanonicalSigner(t *testing.T) {
+	signer := &exchange.CanonicalSigner{
+		Key:    "e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx",
+		Secret: "b0xxxxxx-c6xxxxxx-94xxxxxx-dxxxx",
+		Now:    func() time.Time { return time.Date(2017, 5, 11, 15, 19, 30, 0, time.UTC) },
+	}
+	request := &exchange.SignRequest{Method: "GET", Host: "api.huobi.pro", Path: "/v1/order/orders", Params: map[string]string{"order-id": "1234567890"}}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+
+	query := "AccessKeyId=e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx&SignatureMethod=HmacSHA256&SignatureVersion=2&Timestamp=2017-05-11T15%3A19%3A30&order-id=1234567890"
+	signature := base64.StdEncoding.EncodeToString(hmacSHA256("GET\napi.huobi.pro\n/v1/order/orders\n"+query, signer.Secret))
+	expected := "AccessKeyId=e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx&Signature=" + url.QueryEscape(signature) + "&SignatureMethod=HmacSHA256" +
+		"&SignatureVersion=2&Timestamp=2017-05-11T15%3A19%3A30&order-id=1234567890"
+	if request.Query != expected {
+		t.Errorf("Expected query %v, got %v", expected, request.Query)
+	}
+
+	// signing again replaces the signature instead of signing it
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	if request.Query != expected {
+		t.Errorf("Expected the same query signed twice, got %v", request.Query)
+	}
+}
+
+func Test_PassphraseSigner(t *testing.T) {
+	// the request of the OKEx API docs, which give no key, secret and signature:
+	// the made up credentials check the signer signs the documented pre-hash string
+	okex := &exchange.PassphraseSigner{
+		Key:          "key",
+		Secret:       "secret",
+		Passphrase:   "passphrase",
+		HeaderPrefix: "OK-ACCESS-",
+		Timestamp:    exchange.IsoTimestamp,
+		Now:          at(1552042765789),
+	}
+	request := &exchange.SignRequest{
+		Method: "POST",
+		Path:   "/orders",
+		Query:  "before=2&limit=30",
+		Body:   `{"product_id":"BTC-USD-0309","order_id":"377454671037440"}`,
+	}
+	if err := okex.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"OK-ACCESS-KEY":        "key",
+		"OK-ACCESS-SIGN":       base64.StdEncoding.EncodeToString(hmacSHA256(`2019-03-08T10:59:25.789ZPOST/orders?before=2&limit=30{"product_id":"BTC-USD-0309","order_id":"377454671037440"}`, "secret")),
+		"OK-ACCESS-TIMESTAMP":  "2019-03-08T10:59:25.789Z",
+		"OK-ACCESS-PASSPHRASE": "passphrase",
+	}
+	for key, value := range expected {
+		if request.Header[key] != value {
+			t.Errorf("Expected %v %v, got %v", key, value, request.Header[key])
+		}
+	}
+
+	// the request of the Kucoin API docs, the timestamp in ms, which give no
+	// key, secret and signature either
+	kucoin := &exchange.PassphraseSigner{
+		Key:          "key",
+		Secret:       "secret",
+		Passphrase:   "passphrase",
+		HeaderPrefix: "KC-API-",
+		Now:          at(1547015186532),
+	}
+	request = &exchange.SignRequest{Method: "POST", Path: "/api/v1/deposit-addresses", Body: `{"currency":"BTC"}`}
+	if err := kucoin.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	if request.Header["KC-API-SIGN"] != base64.StdEncoding.EncodeToString(hmacSHA256(`1547015186532POST/api/v1/deposit-addresses{"currency":"BTC"}`, "secret")) {
+		t.Errorf("Unexpected KC-API-SIGN %v", request.Header["KC-API-SIGN"])
+	}
+	if request.Header["KC-API-TIMESTAMP"] != "1547015186532" {
+		t.Errorf("Expected the timestamp in ms, got %v", request.Header["KC-API-TIMESTAMP"])
+	}
+}
+
+// the examples of the BitMEX API docs, GET /api/v1/instrument and POST /api/v1/order
+func Test_ExpiresSigner(t *testing.T) {
+	signer := &exchange.ExpiresSigner{
+		Key:      "LAqUlngMIQkIUjXMUreyu3qn",
+		Secret:   "chNOOS4KvNXR_Xq4k4c9qsfoKWvnDecLATCRlcBwyKDYnWgO",
+		Lifetime: 5 * time.Second,
+		Now:      at(1518064231000),
+	}
+	request := &exchange.SignRequest{Method: "GET", Path: "/api/v1/instrument"}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"api-expires":   "1518064236",
+		"api-key":       "LAqUlngMIQkIUjXMUreyu3qn",
+		"api-signature": "c7682d435d0cfe87c16098df34ef2eb5a549d4c5a3c2b1f0f77b8af73423bf00",
+	}
+	for key, value := range expected {
+		if request.Header[key] != value {
+			t.Errorf("Expected %v %v, got %v", key, value, request.Header[key])
+		}
+	}
+
+	signer.Now = at(1518064233000)
+	request = &exchange.SignRequest{
+		Method: "POST",
+		Path:   "/api/v1/order",
+		Body:   `{"symbol":"XBTM15","price":219.0,"clOrdID":"mm_bitmex_1a/oemUeQ4CAJZgP3fjHsA","orderQty":98}`,
+	}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	if request.Header["api-signature"] != "1749cd2ccae4aa49048ae09f0b95110cee706e0944e6a14ad0b3a8cb45bd336b" {
+		t.Errorf("Unexpected api-signature %v", request.Header["api-signature"])
+	}
+}
+
+// the example of the Kraken API docs, POST /0/private/AddOrder
+func Test_NonceSigner(t *testing.T) {
+	signer := &exchange.NonceSigner{
+		Key:    "key",
+		Secret: "kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg==",
+		Nonce:  func() int64 { return 1616492376594 },
+	}
+	request := &exchange.SignRequest{
+		Method: "POST",
+		Path:   "/0/private/AddOrder",
+		Params: map[string]string{"ordertype": "limit", "pair": "XBTUSD", "price": "37500", "type": "buy", "volume": "1.25"},
+	}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+
+	if request.Query != "nonce=1616492376594&ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25" {
+		t.Errorf("Unexpected form %v", request.Query)
+	}
+	if request.Header["API-Sign"] != "4/dpxb3iT4tp/ZCVEwSnEsLxx0bqyhLpdfOpc6fn7OR8+UClSV5n9E6aSS8MPtnRfp32bAb0nmbRn6H8ndwLUQ==" {
+		t.Errorf("Unexpected API-Sign %v", request.Header["API-Sign"])
+	}
+
+	signer.Secret = "not base64"
+	if err := signer.Sign(request); err == nil {
+		t.Error("Expected an error for a secret not in base64")
+	}
+}
+
+// the BitMart API docs give no example, the made up credentials check the
+// client secret and the signature against the scheme they describe
+func Test_BearerSigner(t *testing.T) {
+	now := time.Unix(1560000000, 0)
+	tokens := 0
+	signer := &exchange.BearerSigner{
+		Key:    "key",
+		Secret: "secret",
+		Memo:   "memo",
+		Token: func(key, clientSecret string) (string, time.Duration, error) {
+			if key != "key" || clientSecret != hex.EncodeToString(hmacSHA256("key:secret:memo", "secret")) {
+				t.Errorf("Unexpected client credentials %v %v", key, clientSecret)
+			}
+			tokens++
+			return "token", time.Hour, nil
+		},
+		Now: func() time.Time { return now },
+	}
+
+	request := &exchange.SignRequest{Method: "POST", Path: "/v2/orders", Params: map[string]string{"symbol": "BMX_ETH", "amount": "1", "price": "0.1", "memo": "a b"}}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	if request.Header["X-BM-AUTHORIZATION"] != "Bearer token" {
+		t.Errorf("Unexpected X-BM-AUTHORIZATION %v", request.Header["X-BM-AUTHORIZATION"])
+	}
+	// the query sent is the one signed
+	if request.Query != "amount=1&memo=a+b&price=0.1&symbol=BMX_ETH" {
+		t.Errorf("Unexpected query %v", request.Query)
+	}
+	if request.Header["X-BM-SIGNATURE"] != hex.EncodeToString(hmacSHA256(request.Query, "secret")) {
+		t.Errorf("Unexpected X-BM-SIGNATURE %v", request.Header["X-BM-SIGNATURE"])
+	}
+	if request.Header["X-BM-TIMESTAMP"] != "1560000000000" {
+		t.Errorf("Expected the timestamp in ms, got %v", request.Header["X-BM-TIMESTAMP"])
+	}
+
+	request = &exchange.SignRequest{Method: "GET", Path: "/v2/orders", Params: map[string]string{"symbol": "BMX_ETH"}}
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := request.Header["X-BM-SIGNATURE"]; ok {
+		t.Error("Expected no X-BM-SIGNATURE for GET")
+	}
+	if tokens != 1 {
+		t.Errorf("Expected the token kept, got %v tokens", tokens)
+	}
+
+	now = now.Add(time.Hour)
+	if err := signer.Sign(request); err != nil {
+		t.Fatal(err)
+	}
+	if tokens != 2 {
+		t.Errorf("Expected a new token once expired, got %v tokens", tokens)
+	}
+}
@@ -13,12 +13,16 @@ import (
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
 	"github.com/bitontop/gored/test/conf"
+	"github.com/bitontop/gored/test/mock"
 
 	initial "github.com/bitontop/gored/initial"
 	utils "github.com/bitontop/gored/utils"
 	// "github.com/davecgh/go-spew/spew"
 )
 
+// DATA_PATH is the data directory of the repository from this package
+const DATA_PATH = "../../data"
+
 func Init() *InitHandler {
 	handler := &InitHandler{}
 	var wg sync.WaitGroup
@@ -26,7 +30,9 @@ func Init() *InitHandler {
 	coin.Init()
 	pair.Init()
 
-	utils.GetCommonDataFromJSON("https://raw.githubusercontent.com/bitontop/gored/master/data") //e.config.SourceURI)
+	// the data snapshots of the repository, no request leaves the process
+	exchange.SetHttpTransport(mock.Transport())
+	utils.GetCommonDataFromJSON(DATA_PATH)
 
 	handler.ExMan = exchange.CreateExchangeManager()
 	exchanges := handler.ExMan.GetSupportExchanges()
@@ -64,7 +70,7 @@ func initEx(wg *sync.WaitGroup, name exchange.ExchangeName, initMan *initial.Ini
 	config := &exchange.Config{}
 	config.ExName = name
 	config.Source = exchange.JSON_FILE
-	config.SourceURI = "https://raw.githubusercontent.com/bitontop/gored/master/data"
+	config.SourceURI = DATA_PATH
 
 	conf.Exchange(name, config)
 
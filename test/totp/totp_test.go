@@ -0,0 +1,62 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// the SHA1 test vectors of RFC 6238 Appendix B, the last 6 of the 8 digits
+func Test_TOTP(t *testing.T) {
+	seed := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // base32 of "12345678901234567890"
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range vectors {
+		code, err := exchange.TOTP(seed, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != expected {
+			t.Errorf("Expected %v at %v, got %v", expected, unix, code)
+		}
+	}
+}
+
+func Test_Seed(t *testing.T) {
+	at := time.Unix(1111111109, 0)
+	// as shown by the venues, in lower case, grouped and without padding
+	for _, seed := range []string{"gezd gnbv gy3t qojq gezd gnbv gy3t qojq", "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ===="} {
+		code, err := exchange.TOTP(seed, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != "081804" {
+			t.Errorf("Expected 081804 for %v, got %v", seed, code)
+		}
+	}
+
+	// the same code for the whole 30 s step
+	first, _ := exchange.TOTP("JBSWY3DPEHPK3PXP", time.Unix(1560000000, 0))
+	last, _ := exchange.TOTP("JBSWY3DPEHPK3PXP", time.Unix(1560000029, 0))
+	next, _ := exchange.TOTP("JBSWY3DPEHPK3PXP", time.Unix(1560000030, 0))
+	if first != last || first == next {
+		t.Errorf("Expected one code per 30 s, got %v %v %v", first, last, next)
+	}
+
+	for _, seed := range []string{"", "not base32!"} {
+		if _, err := exchange.TOTP(seed, at); err == nil {
+			t.Errorf("Expected an error for the seed %q", seed)
+		}
+	}
+}
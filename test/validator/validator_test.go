@@ -0,0 +1,165 @@
+package test
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/fake"
+)
+
+func setup() (*fake.Exchange, *pair.Pair) {
+	coin.Init()
+	pair.Init()
+	btc := &coin.Coin{ID: 1, Code: "BTC"}
+	eth := &coin.Coin{ID: 2, Code: "ETH"}
+	coin.AddCoin(btc)
+	coin.AddCoin(eth)
+	ethbtc := pair.SetPair(1, btc, eth)
+
+	e := fake.CreateExchange(9020, "FAKE_VALIDATOR")
+	e.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+	e.SetBalance(btc, 1)
+	e.SetBalance(eth, 10)
+	return e, ethbtc
+}
+
+func Test_Rounding(t *testing.T) {
+	e, ethbtc := setup()
+
+	// the default rounding never gives a worse rate or a larger quantity
+	rate, quantity, err := exchange.ValidateOrder(e, ethbtc, "Buy", 0.0200009, 1.239)
+	if err != nil || rate != 0.02 || quantity != 1.23 {
+		t.Errorf("Expected the buy at 0.02 x 1.23, got %v x %v %v", rate, quantity, err)
+	}
+	rate, quantity, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.0200001, 0.1+0.2)
+	if err != nil || rate != 0.020001 || quantity != 0.3 {
+		t.Errorf("Expected the sell at 0.020001 x 0.3, got %v x %v %v", rate, quantity, err)
+	}
+
+	request := &exchange.OrderRequest{
+		Pair:             ethbtc,
+		Side:             "Buy",
+		Rate:             0.0200001,
+		Quantity:         1.231,
+		PriceRounding:    exchange.CEIL,
+		QuantityRounding: exchange.CEIL,
+	}
+	rate, quantity, err = request.Validate(e)
+	if err != nil || rate != 0.020001 || quantity != 1.24 {
+		t.Errorf("Expected the buy at 0.020001 x 1.24, got %v x %v %v", rate, quantity, err)
+	}
+}
+
+func expectError(t *testing.T, err error, errType exchange.OrderErrorType, limit float64) {
+	t.Helper()
+	orderErr, ok := err.(*exchange.OrderError)
+	if !ok {
+		t.Errorf("Expected %s, got %v", errType, err)
+		return
+	}
+	if orderErr.Type != errType || orderErr.Limit != limit {
+		t.Errorf("Expected %s with limit %v, got %s with %v", errType, limit, orderErr.Type, orderErr.Limit)
+	}
+}
+
+func Test_Rejects(t *testing.T) {
+	e, ethbtc := setup()
+
+	_, _, err := exchange.ValidateOrder(e, pair.GetPairByKey("ETH|BTC"), "Buy", 0.02, 1)
+	expectError(t, err, exchange.INVALID_PAIR, 0)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "BUY", 0.02, 1)
+	expectError(t, err, exchange.INVALID_SIDE, 0)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Buy", 0, 1)
+	expectError(t, err, exchange.INVALID_PRICE, 0)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Buy", 0.0000009, 1)
+	expectError(t, err, exchange.INVALID_PRICE, 0.000001)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.02, -1)
+	expectError(t, err, exchange.INVALID_QUANTITY, 0)
+	// floored to 0 lots
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.02, 0.009)
+	expectError(t, err, exchange.MIN_QUANTITY, 0.01)
+
+	pc := e.GetPairConstraint(ethbtc)
+	pc.MinQty = 0.5
+	pc.MaxQty = 5
+	pc.MinNotional = 0.01
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.02, 0.49)
+	expectError(t, err, exchange.MIN_QUANTITY, 0.5)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.02, 5.01)
+	expectError(t, err, exchange.MAX_QUANTITY, 5)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.0199, 0.5)
+	expectError(t, err, exchange.MIN_NOTIONAL, 0.01)
+	if _, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.02, 0.5); err != nil {
+		t.Errorf("Expected the order at the minimum notional to pass, got %v", err)
+	}
+}
+
+func Test_Balance(t *testing.T) {
+	e, ethbtc := setup()
+
+	if _, _, err := exchange.ValidateOrder(e, ethbtc, "Buy", 0.02, 50); err != nil {
+		t.Errorf("Expected the buy of the whole balance to pass, got %v", err)
+	}
+	_, _, err := exchange.ValidateOrder(e, ethbtc, "Buy", 0.02, 50.01)
+	expectError(t, err, exchange.INSUFFICIENT_BALANCE, 1)
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Sell", 0.02, 10.01)
+	expectError(t, err, exchange.INSUFFICIENT_BALANCE, 10)
+
+	request := &exchange.OrderRequest{Pair: ethbtc, Side: "Sell", Rate: 0.02, Quantity: 10.01, SkipBalance: true}
+	if _, _, err := request.Validate(e); err != nil {
+		t.Errorf("Expected the balance check to be skipped, got %v", err)
+	}
+	if len(e.Orders()) != 0 {
+		t.Error("Expected no order to be placed")
+	}
+}
+
+func Test_BalanceNotLoaded(t *testing.T) {
+	_, ethbtc := setup()
+	e := fake.CreateExchange(9022, "FAKE_UNLOADED")
+	e.AddPair(ethbtc, 0.001, 0.01, 0.000001)
+
+	if _, _, err := exchange.ValidateOrder(e, ethbtc, "Buy", 0.02, 50); err != nil {
+		t.Errorf("Expected the balance check to be skipped before the balances are fetched, got %v", err)
+	}
+	e.SetBalance(ethbtc.Base, 0.5)
+	_, _, err := exchange.ValidateOrder(e, ethbtc, "Buy", 0.02, 50)
+	expectError(t, err, exchange.INSUFFICIENT_BALANCE, 0.5)
+}
+
+func Test_Errors(t *testing.T) {
+	e, ethbtc := setup()
+
+	_, _, err := exchange.ValidateOrder(e, ethbtc, "Buy", 0.02, 100)
+	if !errors.Is(err, exchange.ErrInsufficientFunds) || errors.Is(err, exchange.ErrOrderLimit) {
+		t.Errorf("Expected %v, got %v", exchange.ErrInsufficientFunds, err)
+	}
+	_, _, err = exchange.ValidateOrder(e, ethbtc, "Buy", 0.02, 0.001)
+	if !errors.Is(err, exchange.ErrOrderLimit) {
+		t.Errorf("Expected %v, got %v", exchange.ErrOrderLimit, err)
+	}
+
+	payload := `{"code":-2010,"msg":"Account has insufficient balance for requested action."}`
+	apiErr := exchange.NewApiError(e.GetName(), "LimitBuy", -2010, "Account has insufficient balance for requested action.", payload, exchange.ErrInsufficientFunds)
+	wrapped := fmt.Errorf("rebalance: %w", apiErr)
+	if !errors.Is(wrapped, exchange.ErrInsufficientFunds) || errors.Is(wrapped, exchange.ErrAuth) {
+		t.Errorf("Expected %v, got %v", exchange.ErrInsufficientFunds, wrapped)
+	}
+	var target *exchange.ApiError
+	if !errors.As(wrapped, &target) || target.Code != "-2010" || target.Payload != payload {
+		t.Errorf("Expected the code and the payload of the API, got %+v", target)
+	}
+
+	unmapped := exchange.NewApiError(e.GetName(), "CancelOrder", 1, "", "", nil)
+	if errors.Unwrap(unmapped) != nil {
+		t.Errorf("Expected an unmapped error, got %v", errors.Unwrap(unmapped))
+	}
+}
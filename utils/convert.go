@@ -0,0 +1,90 @@
+package utils
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// GetCommonData collects the registered coins and pairs, ordered by ID.
+func GetCommonData() *CommonData {
+	return &CommonData{
+		Coins: coin.GetCoins(),
+		Pairs: pair.GetPairs(),
+	}
+}
+
+// GetJsonData collects the constraints of the exchange, ordered by ID.
+// The Coin and Pair pointers are dropped, they are restored from the IDs
+// when the data is loaded.
+func GetJsonData(ex exchange.Exchange) *JsonData {
+	jsonData := &JsonData{
+		CoinConstraint: []*exchange.CoinConstraint{},
+		PairConstraint: []*exchange.PairConstraint{},
+	}
+
+	for _, c := range ex.GetCoins() {
+		if cc := ex.GetCoinConstraint(c); cc != nil {
+			tmp := *cc
+			tmp.Coin = nil
+			jsonData.CoinConstraint = append(jsonData.CoinConstraint, &tmp)
+		}
+	}
+
+	for _, p := range ex.GetPairs() {
+		if pc := ex.GetPairConstraint(p); pc != nil {
+			tmp := *pc
+			tmp.Pair = nil
+			jsonData.PairConstraint = append(jsonData.PairConstraint, &tmp)
+		}
+	}
+
+	return jsonData
+}
+
+// ConvertBaseDataToJson writes the common data to <datapath>/common.json,
+// the format read by GetCommonDataFromJSON.
+func ConvertBaseDataToJson(datapath string) error {
+	fileName := fmt.Sprintf("%s/common.json", datapath)
+	return writeJSON(fileName, GetCommonData())
+}
+
+// ConvertExchangeDataToJson writes the constraints of the exchange to
+// <datapath>/<EXCHANGE NAME>.json, the format read by GetExchangeDataFromJSON.
+// An exchange without coins or pairs is skipped, the existing file is kept.
+func ConvertExchangeDataToJson(datapath string, ex exchange.Exchange) error {
+	fileName := fmt.Sprintf("%s/%s.json", datapath, ex.GetName())
+	jsonData := GetJsonData(ex)
+	if len(jsonData.CoinConstraint) == 0 || len(jsonData.PairConstraint) == 0 {
+		return fmt.Errorf("%s has no data, %s is not updated", ex.GetName(), fileName)
+	}
+	return writeJSON(fileName, jsonData)
+}
+
+// writeJSON replaces fileName with the json of v in one rename,
+// so readers never see a partially written file.
+func writeJSON(fileName string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%s Json Marshal Err: %v", fileName, err)
+	}
+
+	tmpName := fileName + ".tmp"
+	if err := ioutil.WriteFile(tmpName, data, 0644); err != nil {
+		return fmt.Errorf("Write %s Failed: %v", tmpName, err)
+	}
+	if err := os.Rename(tmpName, fileName); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("Write %s Failed: %v", fileName, err)
+	}
+	return nil
+}
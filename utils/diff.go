@@ -0,0 +1,201 @@
+package utils
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+type ChangeType string
+
+const (
+	ADDED   ChangeType = "ADDED"
+	REMOVED ChangeType = "REMOVED"
+	CHANGED ChangeType = "CHANGED"
+)
+
+// Change is one difference between two snapshots of an exchange's constraints.
+// Exactly one of CoinID and PairID is set. Field, Old and New are only set
+// for CHANGED, Old is nil for ADDED and New is nil for REMOVED.
+type Change struct {
+	ExName exchange.ExchangeName `json:"exchange_name"`
+	Type   ChangeType            `json:"type"`
+	CoinID int                   `json:"coin_id,omitempty"`
+	PairID int                   `json:"pair_id,omitempty"`
+	Name   string                `json:"name"` // coin code or pair name, the symbol on exchange if unknown
+	Field  string                `json:"field,omitempty"`
+	Old    interface{}           `json:"old"`
+	New    interface{}           `json:"new"`
+}
+
+func (c *Change) String() string {
+	kind := "Coin"
+	if c.PairID != 0 {
+		kind = "Pair"
+	}
+	switch c.Type {
+	case CHANGED:
+		return fmt.Sprintf("%s %s %s %s %s: %v -> %v", c.ExName, kind, c.Name, c.Type, c.Field, c.Old, c.New)
+	default:
+		return fmt.Sprintf("%s %s %s %s", c.ExName, kind, c.Name, c.Type)
+	}
+}
+
+// DiffExchange compares the current constraints of the exchange, usually just
+// fetched from the exchange API, with the snapshot <datapath>/<EXCHANGE NAME>.json.
+func DiffExchange(datapath string, ex exchange.Exchange) ([]*Change, error) {
+	stored, err := GetJsonDataFromJSON(datapath, ex.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return DiffJsonData(ex.GetName(), stored, GetJsonData(ex)), nil
+}
+
+// DiffJsonData lists the changes from the old to the new snapshot,
+// coin constraints first, each part ordered by ID.
+func DiffJsonData(exName exchange.ExchangeName, old, new *JsonData) []*Change {
+	changes := []*Change{}
+
+	coinIDs := make(map[int]bool)
+	oldCoins := make(map[int]*exchange.CoinConstraint)
+	newCoins := make(map[int]*exchange.CoinConstraint)
+	for _, cc := range old.CoinConstraint {
+		oldCoins[cc.CoinID] = cc
+		coinIDs[cc.CoinID] = true
+	}
+	for _, cc := range new.CoinConstraint {
+		newCoins[cc.CoinID] = cc
+		coinIDs[cc.CoinID] = true
+	}
+	for _, id := range sortedIDs(coinIDs) {
+		o, n := oldCoins[id], newCoins[id]
+		change := Change{ExName: exName, CoinID: id}
+		switch {
+		case o == nil:
+			change.Name = coinName(n)
+			changes = append(changes, change.with(ADDED, "", nil, n))
+		case n == nil:
+			change.Name = coinName(o)
+			changes = append(changes, change.with(REMOVED, "", o, nil))
+		default:
+			change.Name = coinName(n)
+			if o.ExSymbol != n.ExSymbol {
+				changes = append(changes, change.with(CHANGED, "ExSymbol", o.ExSymbol, n.ExSymbol))
+			}
+			if o.ChainType != n.ChainType {
+				changes = append(changes, change.with(CHANGED, "ChainType", o.ChainType, n.ChainType))
+			}
+			if o.TxFee != n.TxFee {
+				changes = append(changes, change.with(CHANGED, "TxFee", o.TxFee, n.TxFee))
+			}
+			if o.Withdraw != n.Withdraw {
+				changes = append(changes, change.with(CHANGED, "Withdraw", o.Withdraw, n.Withdraw))
+			}
+			if o.Deposit != n.Deposit {
+				changes = append(changes, change.with(CHANGED, "Deposit", o.Deposit, n.Deposit))
+			}
+			if o.Confirmation != n.Confirmation {
+				changes = append(changes, change.with(CHANGED, "Confirmation", o.Confirmation, n.Confirmation))
+			}
+			if o.Listed != n.Listed {
+				changes = append(changes, change.with(CHANGED, "Listed", o.Listed, n.Listed))
+			}
+		}
+	}
+
+	pairIDs := make(map[int]bool)
+	oldPairs := make(map[int]*exchange.PairConstraint)
+	newPairs := make(map[int]*exchange.PairConstraint)
+	for _, pc := range old.PairConstraint {
+		oldPairs[pc.PairID] = pc
+		pairIDs[pc.PairID] = true
+	}
+	for _, pc := range new.PairConstraint {
+		newPairs[pc.PairID] = pc
+		pairIDs[pc.PairID] = true
+	}
+	for _, id := range sortedIDs(pairIDs) {
+		o, n := oldPairs[id], newPairs[id]
+		change := Change{ExName: exName, PairID: id}
+		switch {
+		case o == nil:
+			change.Name = pairName(n)
+			changes = append(changes, change.with(ADDED, "", nil, n))
+		case n == nil:
+			change.Name = pairName(o)
+			changes = append(changes, change.with(REMOVED, "", o, nil))
+		default:
+			change.Name = pairName(n)
+			if o.ExSymbol != n.ExSymbol {
+				changes = append(changes, change.with(CHANGED, "ExSymbol", o.ExSymbol, n.ExSymbol))
+			}
+			if o.MakerFee != n.MakerFee {
+				changes = append(changes, change.with(CHANGED, "MakerFee", o.MakerFee, n.MakerFee))
+			}
+			if o.TakerFee != n.TakerFee {
+				changes = append(changes, change.with(CHANGED, "TakerFee", o.TakerFee, n.TakerFee))
+			}
+			if o.LotSize != n.LotSize {
+				changes = append(changes, change.with(CHANGED, "LotSize", o.LotSize, n.LotSize))
+			}
+			if o.PriceFilter != n.PriceFilter {
+				changes = append(changes, change.with(CHANGED, "PriceFilter", o.PriceFilter, n.PriceFilter))
+			}
+			if o.MinQty != n.MinQty {
+				changes = append(changes, change.with(CHANGED, "MinQty", o.MinQty, n.MinQty))
+			}
+			if o.MaxQty != n.MaxQty {
+				changes = append(changes, change.with(CHANGED, "MaxQty", o.MaxQty, n.MaxQty))
+			}
+			if o.MinNotional != n.MinNotional {
+				changes = append(changes, change.with(CHANGED, "MinNotional", o.MinNotional, n.MinNotional))
+			}
+			if o.MaxOrders != n.MaxOrders {
+				changes = append(changes, change.with(CHANGED, "MaxOrders", o.MaxOrders, n.MaxOrders))
+			}
+			if o.Listed != n.Listed {
+				changes = append(changes, change.with(CHANGED, "Listed", o.Listed, n.Listed))
+			}
+		}
+	}
+
+	return changes
+}
+
+func (c Change) with(changeType ChangeType, field string, old, new interface{}) *Change {
+	c.Type = changeType
+	c.Field = field
+	c.Old = old
+	c.New = new
+	return &c
+}
+
+func sortedIDs(set map[int]bool) []int {
+	ids := []int{}
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func coinName(cc *exchange.CoinConstraint) string {
+	if c := coin.GetCoinByID(cc.CoinID); c != nil {
+		return c.Code
+	}
+	return cc.ExSymbol
+}
+
+func pairName(pc *exchange.PairConstraint) string {
+	if p := pair.GetPairByID(pc.PairID); p != nil {
+		return p.Name
+	}
+	return pc.ExSymbol
+}
@@ -0,0 +1,110 @@
+package utils
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	cmap "github.com/orcaman/concurrent-map"
+)
+
+// the last response of every microservice URL, keyed by URL
+var etagMap = cmap.New()
+
+type etagCache struct {
+	ETag string
+	Data []byte
+}
+
+// getWithETag requests strUrl with the ETag of the previous response.
+// modified is false when the service answers 304 Not Modified.
+func getWithETag(strUrl string) (data []byte, etag string, modified bool, err error) {
+	request, err := http.NewRequest("GET", strUrl, nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if tmp, ok := etagMap.Get(strUrl); ok && tmp.(*etagCache).ETag != "" {
+		request.Header.Set("If-None-Match", tmp.(*etagCache).ETag)
+	}
+
+	httpClient := exchange.NewHttpClient()
+	httpClient.Timeout = 30 * time.Second
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusNotModified:
+		return nil, "", false, nil
+	case http.StatusOK:
+	default:
+		return nil, "", false, fmt.Errorf("%s %s", strUrl, response.Status)
+	}
+
+	if data, err = ioutil.ReadAll(response.Body); err != nil {
+		return nil, "", false, err
+	}
+	return data, response.Header.Get("ETag"), true, nil
+}
+
+// GetCommonDataFromMicroservice loads the coins and pairs served by the
+// data microservice. It returns false if the data has not changed since the
+// last call or the request failed.
+func GetCommonDataFromMicroservice(uri string) bool {
+	strUrl := fmt.Sprintf("%s/common.json", uri)
+	data, etag, modified, err := getWithETag(strUrl)
+	if err != nil {
+		log.Printf("Get %s Failed: %v", strUrl, err)
+		return false
+	} else if !modified {
+		return false
+	}
+
+	if err := loadCommonData(data); err != nil {
+		log.Printf("%s Json Unmarshal Err: %v %s", strUrl, err, data)
+		return false
+	}
+	etagMap.Set(strUrl, &etagCache{ETag: etag})
+	return true
+}
+
+// GetExchangeDataFromMicroservice loads the constraints of exName served by
+// the data microservice. The common data is refreshed first so the coins and
+// pairs listed since the last call are resolved. The served data is parsed
+// again on every call, also when the service reports that it has not been
+// modified, the returned maps are not shared with the previous calls.
+func GetExchangeDataFromMicroservice(uri string, exName exchange.ExchangeName) *ExchangeData {
+	GetCommonDataFromMicroservice(uri)
+
+	strUrl := fmt.Sprintf("%s/%s.json", uri, exName)
+	data, etag, modified, err := getWithETag(strUrl)
+	if err != nil {
+		log.Printf("Get %s Failed: %v", strUrl, err)
+		return nil
+	} else if !modified {
+		tmp, ok := etagMap.Get(strUrl)
+		if !ok {
+			return nil
+		}
+		data = tmp.(*etagCache).Data
+	}
+
+	exchangeData, err := parseExchangeData(data)
+	if err != nil {
+		log.Printf("%s Json Unmarshal Err: %v %s", strUrl, err, data)
+		return nil
+	}
+	if modified {
+		etagMap.Set(strUrl, &etagCache{ETag: etag, Data: data})
+	}
+	return exchangeData
+}
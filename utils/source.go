@@ -18,10 +18,6 @@ import (
 
 func GetExchangeDataFromJSON(datapath string, exName exchange.ExchangeName) *ExchangeData {
 	fileName := fmt.Sprintf("%s/%s.json", datapath, exName)
-	exchangeData := &ExchangeData{
-		CoinConstraint: cmap.New(),
-		PairConstraint: cmap.New(),
-	}
 	var err error
 	data := []byte{}
 	if datapath[0:4] == "http" {
@@ -33,29 +29,34 @@ func GetExchangeDataFromJSON(datapath string, exName exchange.ExchangeName) *Exc
 		}
 	}
 
-	jsonData := &JsonData{}
-	if err := json.Unmarshal(data, &jsonData); err != nil {
+	exchangeData, err := parseExchangeData(data)
+	if err != nil {
 		log.Printf("%s Json Unmarshal Err: %v %s", fileName, err, data)
 		return nil
 	}
 
-	for _, cc := range jsonData.CoinConstraint {
-		key := fmt.Sprintf("%d", cc.CoinID)
-		cc.Coin = coin.GetCoinByID(cc.CoinID)
-		if cc.Coin != nil {
-			exchangeData.CoinConstraint.Set(key, cc)
-		}
-	}
+	return exchangeData
+}
 
-	for _, pc := range jsonData.PairConstraint {
-		key := fmt.Sprintf("%d", pc.PairID)
-		pc.Pair = pair.GetPairByID(pc.PairID)
-		if pc.Pair != nil {
-			exchangeData.PairConstraint.Set(key, pc)
+// GetJsonDataFromJSON reads the snapshot <datapath>/<EXCHANGE NAME>.json as it is stored,
+// without resolving the coins and pairs.
+func GetJsonDataFromJSON(datapath string, exName exchange.ExchangeName) (*JsonData, error) {
+	fileName := fmt.Sprintf("%s/%s.json", datapath, exName)
+	var err error
+	data := []byte{}
+	if datapath[0:4] == "http" {
+		data = []byte(exchange.HttpGetRequest(fileName, nil))
+	} else {
+		if data, err = ioutil.ReadFile(fileName); err != nil {
+			return nil, fmt.Errorf("Read %s Failed: %v", fileName, err)
 		}
 	}
 
-	return exchangeData
+	jsonData := &JsonData{}
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, fmt.Errorf("%s Json Unmarshal Err: %v", fileName, err)
+	}
+	return jsonData, nil
 }
 
 func GetCommonDataFromJSON(datapath string) {
@@ -71,11 +72,50 @@ func GetCommonDataFromJSON(datapath string) {
 		}
 	}
 
-	commonData := &CommonData{}
-	if err := json.Unmarshal(data, &commonData); err != nil {
+	if err := loadCommonData(data); err != nil {
 		log.Printf("%s Json Unmarshal Err: %v %s", datapath, err, data)
 		return
 	}
+}
+
+// parseExchangeData converts the JsonData format into constraint maps,
+// dropping the constraints whose coin or pair is unknown.
+func parseExchangeData(data []byte) (*ExchangeData, error) {
+	exchangeData := &ExchangeData{
+		CoinConstraint: cmap.New(),
+		PairConstraint: cmap.New(),
+	}
+
+	jsonData := &JsonData{}
+	if err := json.Unmarshal(data, &jsonData); err != nil {
+		return nil, err
+	}
+
+	for _, cc := range jsonData.CoinConstraint {
+		key := fmt.Sprintf("%d", cc.CoinID)
+		cc.Coin = coin.GetCoinByID(cc.CoinID)
+		if cc.Coin != nil {
+			exchangeData.CoinConstraint.Set(key, cc)
+		}
+	}
+
+	for _, pc := range jsonData.PairConstraint {
+		key := fmt.Sprintf("%d", pc.PairID)
+		pc.Pair = pair.GetPairByID(pc.PairID)
+		if pc.Pair != nil {
+			exchangeData.PairConstraint.Set(key, pc)
+		}
+	}
+
+	return exchangeData, nil
+}
+
+// loadCommonData registers the coins and pairs of the CommonData format.
+func loadCommonData(data []byte) error {
+	commonData := &CommonData{}
+	if err := json.Unmarshal(data, &commonData); err != nil {
+		return err
+	}
 
 	for _, c := range commonData.Coins {
 		coin.AddCoin(c)
@@ -84,4 +124,5 @@ func GetCommonDataFromJSON(datapath string) {
 	for _, p := range commonData.Pairs {
 		pair.SetPair(p.ID, p.Base, p.Target)
 	}
+	return nil
 }